@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	accommodationHandlers "github.com/Ravikiran27/GOLANG_SmartEdu-LMS/api/accommodations"
+)
+
+// Handler routes all student accommodation requests
+func AccommodationsRouter(w http.ResponseWriter, r *http.Request) {
+	// Extract the path after /api/accommodations/
+	path := strings.TrimPrefix(r.URL.Path, "/api/accommodations/")
+
+	// Route to appropriate handler based on path
+	switch path {
+	case "save":
+		accommodationHandlers.SaveAccommodation(w, r)
+	case "list":
+		accommodationHandlers.ListAccommodations(w, r)
+	case "delete":
+		accommodationHandlers.DeleteAccommodation(w, r)
+	default:
+		http.Error(w, "Not Found", http.StatusNotFound)
+	}
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+)
+
+// maxTimeMultiplier bounds the extra time a single accommodation can grant
+const maxTimeMultiplier = 4
+
+// validateAccommodation checks a grant request
+func validateAccommodation(req models.AccommodationRequest) error {
+	if req.TimeMultiplier != 0 && (req.TimeMultiplier < 1 || req.TimeMultiplier > maxTimeMultiplier) {
+		return errors.New("Time multiplier must be between 1 and 4")
+	}
+	if req.ExtraAttempts < 0 {
+		return errors.New("Extra attempts cannot be negative")
+	}
+	if req.StartDate != nil && req.EndDate != nil && !req.EndDate.After(*req.StartDate) {
+		return errors.New("End date must be after start date")
+	}
+	if req.TimeMultiplier <= 1 && req.ExtraAttempts == 0 && req.StartDate == nil && req.EndDate == nil {
+		return errors.New("Set a time multiplier, extra attempts or an alternative window")
+	}
+	return nil
+}
+
+// canManage checks that the caller may grant or remove accommodations in
+// courseID. Admins manage every accommodation; teachers only those scoped to
+// courses they teach. It returns an HTTP status and message when refused.
+func canManage(ctx context.Context, db store.Store, userID, role, courseID string) (int, string) {
+	if role == "admin" {
+		return 0, ""
+	}
+	if courseID == "" {
+		return http.StatusForbidden, "Only admins can manage global accommodations"
+	}
+
+	course, err := db.Courses().Get(ctx, courseID)
+	if err == store.ErrNotFound || (err == nil && course.IsDeleted) {
+		return http.StatusNotFound, "Course not found"
+	}
+	if err != nil {
+		return http.StatusInternalServerError, "Failed to parse course data"
+	}
+	if course.TeacherID != userID {
+		return http.StatusForbidden, "You can only manage accommodations in your own courses"
+	}
+	return 0, ""
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler removes a student's accommodation. Attempts already started keep
+// the time they were given.
+func DeleteAccommodation(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow DELETE
+	if r.Method != http.MethodDelete {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		// An empty courseId selects the student's global accommodation
+		studentID := r.URL.Query().Get("studentId")
+		courseID := r.URL.Query().Get("courseId")
+		if studentID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Student ID is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		if code, message := canManage(ctx, db, userID, role, courseID); code != 0 {
+			utils.RespondError(w, code, message)
+			return
+		}
+
+		accommodationID := store.AccommodationID(studentID, courseID)
+		accommodation, err := db.Accommodations().Get(ctx, accommodationID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Accommodation not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse accommodation data")
+			return
+		}
+
+		if err := db.Accommodations().Delete(ctx, accommodationID); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to delete accommodation")
+			return
+		}
+
+		db.AuditLogs().Create(ctx, &models.AuditLog{
+			Action:     "accommodation_removed",
+			ActorID:    userID,
+			TargetType: "accommodation",
+			TargetID:   accommodationID,
+			Details: map[string]interface{}{
+				"studentId":      accommodation.StudentID,
+				"courseId":       accommodation.CourseID,
+				"timeMultiplier": accommodation.TimeMultiplier,
+				"extraAttempts":  accommodation.ExtraAttempts,
+			},
+			Timestamp: time.Now(),
+		})
+
+		utils.RespondSuccess(w, nil, "Accommodation removed")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
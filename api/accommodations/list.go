@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler lists accommodations. Students see their own; teachers see global
+// ones and those in courses they teach; admins see all.
+func ListAccommodations(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow GET
+	if r.Method != http.MethodGet {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		studentID := r.URL.Query().Get("studentId")
+		courseID := r.URL.Query().Get("courseId")
+		if role == "student" {
+			studentID = userID
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		accommodations, err := db.Accommodations().List(ctx, studentID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch accommodations")
+			return
+		}
+
+		// Teachers only see accommodations that can affect their courses
+		teaches := make(map[string]bool)
+		visible := make([]models.Accommodation, 0, len(accommodations))
+		for _, a := range accommodations {
+			if courseID != "" && a.CourseID != courseID && a.CourseID != "" {
+				continue
+			}
+			if role == "teacher" && a.CourseID != "" {
+				allowed, seen := teaches[a.CourseID]
+				if !seen {
+					course, err := db.Courses().Get(ctx, a.CourseID)
+					allowed = err == nil && course.TeacherID == userID
+					teaches[a.CourseID] = allowed
+				}
+				if !allowed {
+					continue
+				}
+			}
+			visible = append(visible, a)
+		}
+
+		utils.RespondSuccess(w, visible, "Accommodations fetched successfully")
+	})).ServeHTTP(w, r)
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler grants a student an accommodation in a course, or globally. A
+// student has at most one per course plus one global; saving again replaces it.
+func SaveAccommodation(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		// Parse request body
+		var req models.AccommodationRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.StudentID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Student ID is required")
+			return
+		}
+		if err := validateAccommodation(req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		if code, message := canManage(ctx, db, userID, role, req.CourseID); code != 0 {
+			utils.RespondError(w, code, message)
+			return
+		}
+
+		student, err := db.Users().Get(ctx, req.StudentID)
+		if err == store.ErrNotFound || (err == nil && student.Role != "student") {
+			utils.RespondError(w, http.StatusNotFound, "Student not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse user data")
+			return
+		}
+
+		now := time.Now()
+		accommodation := models.Accommodation{
+			ID:             store.AccommodationID(req.StudentID, req.CourseID),
+			StudentID:      req.StudentID,
+			CourseID:       req.CourseID,
+			TimeMultiplier: req.TimeMultiplier,
+			ExtraAttempts:  req.ExtraAttempts,
+			StartDate:      req.StartDate,
+			EndDate:        req.EndDate,
+			Reason:         req.Reason,
+			GrantedBy:      userID,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+
+		// Keep the original grant date when replacing
+		action := "accommodation_granted"
+		previous, err := db.Accommodations().Get(ctx, accommodation.ID)
+		if err != nil && err != store.ErrNotFound {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse accommodation data")
+			return
+		}
+		if err == nil {
+			accommodation.CreatedAt = previous.CreatedAt
+			action = "accommodation_updated"
+		}
+
+		if err := db.Accommodations().Save(ctx, &accommodation); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to save accommodation")
+			return
+		}
+
+		details := map[string]interface{}{
+			"studentId":      accommodation.StudentID,
+			"courseId":       accommodation.CourseID,
+			"timeMultiplier": accommodation.TimeMultiplier,
+			"extraAttempts":  accommodation.ExtraAttempts,
+			"reason":         accommodation.Reason,
+		}
+		if accommodation.StartDate != nil {
+			details["startDate"] = *accommodation.StartDate
+		}
+		if accommodation.EndDate != nil {
+			details["endDate"] = *accommodation.EndDate
+		}
+		db.AuditLogs().Create(ctx, &models.AuditLog{
+			Action:     action,
+			ActorID:    userID,
+			TargetType: "accommodation",
+			TargetID:   accommodation.ID,
+			Details:    details,
+			Timestamp:  now,
+		})
+
+		utils.RespondSuccess(w, accommodation, "Accommodation saved")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	analyticsHandlers "github.com/Ravikiran27/GOLANG_SmartEdu-LMS/api/analytics"
+)
+
+// Handler routes all analytics requests
+func AnalyticsRouter(w http.ResponseWriter, r *http.Request) {
+	// Extract the path after /api/analytics/
+	path := strings.TrimPrefix(r.URL.Path, "/api/analytics/")
+
+	// Route to appropriate handler based on path
+	switch path {
+	case "item-analysis":
+		analyticsHandlers.ItemAnalysis(w, r)
+	default:
+		http.Error(w, "Not Found", http.StatusNotFound)
+	}
+}
@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"math"
+	"net/http"
+	"sort"
+
+	quizHandlers "github.com/Ravikiran27/GOLANG_SmartEdu-LMS/api/quizzes"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// groupFraction is the share of students in each of the top and bottom
+// groups used for the discrimination index
+const groupFraction = 0.27
+
+// Handler returns per-question statistics for a quiz: difficulty and
+// discrimination indices, option choices and time spent (teacher/admin only)
+func ItemAnalysis(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow GET
+	if r.Method != http.MethodGet {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		quizID := r.URL.Query().Get("quizId")
+		if quizID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Quiz ID is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		// Only the teacher of the quiz's course (or an admin) may analyze it
+		if _, ok := quizHandlers.OwnedQuiz(w, r, db, quizID, userID, role); !ok {
+			return
+		}
+
+		questions, err := db.Questions().ListByQuiz(ctx, quizID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch questions")
+			return
+		}
+
+		submissions, err := db.Submissions().List(ctx, store.SubmissionFilter{
+			QuizID:   quizID,
+			Statuses: []string{"submitted", "evaluated"},
+		})
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch results")
+			return
+		}
+
+		utils.RespondSuccess(w, analyzeItems(quizID, questions, submissions))
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
+
+// analyzeItems computes item statistics from each student's first completed
+// attempt, so retakes of already-seen questions do not skew the indices
+func analyzeItems(quizID string, questions []models.Question, submissions []models.QuizSubmission) models.ItemAnalysis {
+	attempts := firstAttempts(submissions)
+
+	// Rank by percentage, which stays comparable across drawn question sets
+	sort.SliceStable(attempts, func(i, j int) bool {
+		if attempts[i].Percentage != attempts[j].Percentage {
+			return attempts[i].Percentage > attempts[j].Percentage
+		}
+		return attempts[i].StudentID < attempts[j].StudentID
+	})
+
+	groupSize := 0
+	if len(attempts) >= 2 {
+		groupSize = int(math.Round(groupFraction * float64(len(attempts))))
+		if groupSize < 1 {
+			groupSize = 1
+		}
+		if groupSize > len(attempts)/2 {
+			groupSize = len(attempts) / 2
+		}
+	}
+
+	ordered := make([]models.Question, len(questions))
+	copy(ordered, questions)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Order < ordered[j].Order })
+
+	items := make([]models.ItemStatistic, 0, len(ordered))
+	for _, q := range ordered {
+		items = append(items, analyzeItem(q, attempts, groupSize))
+	}
+
+	return models.ItemAnalysis{
+		QuizID:           quizID,
+		AttemptsAnalyzed: len(attempts),
+		GroupSize:        groupSize,
+		Items:            items,
+	}
+}
+
+// analyzeItem computes the statistics for one question. attempts must be
+// ranked best first; the first and last groupSize form the top and bottom groups.
+func analyzeItem(q models.Question, attempts []models.QuizSubmission, groupSize int) models.ItemStatistic {
+	item := models.ItemStatistic{
+		QuestionID: q.ID,
+		Text:       q.Text,
+		Type:       q.Type,
+		Pool:       q.Pool,
+	}
+
+	optionIndex := make(map[string]int)
+	for i, opt := range q.Options {
+		optionIndex[opt.ID] = i
+		item.Options = append(item.Options, models.OptionStatistic{
+			OptionID:  opt.ID,
+			Text:      opt.Text,
+			IsCorrect: opt.IsCorrect,
+		})
+	}
+
+	scored, upperScored, upperCorrect, lowerScored, lowerCorrect := 0, 0, 0, 0, 0
+	totalSeconds := 0.0
+	for rank, sub := range attempts {
+		if !wasGiven(sub, q.ID) {
+			continue
+		}
+		item.Attempts++
+		upper := rank < groupSize
+		lower := rank >= len(attempts)-groupSize
+
+		answer, answered := findAnswer(sub, q.ID)
+		if answered && answer.NeedsReview {
+			item.PendingReview++
+			continue
+		}
+		if !answered || (len(answer.SelectedOptions) == 0 && answer.TextAnswer == "") {
+			item.Omitted++
+		}
+
+		// Omitted answers count as incorrect
+		correct := answered && answer.IsCorrect
+		scored++
+		if correct {
+			item.Correct++
+		}
+		if upper {
+			upperScored++
+			if correct {
+				upperCorrect++
+			}
+		}
+		if lower {
+			lowerScored++
+			if correct {
+				lowerCorrect++
+			}
+		}
+
+		if !answered {
+			continue
+		}
+		for _, optionID := range answer.SelectedOptions {
+			i, ok := optionIndex[optionID]
+			if !ok {
+				continue
+			}
+			item.Options[i].Count++
+			if upper {
+				item.Options[i].UpperCount++
+			}
+			if lower {
+				item.Options[i].LowerCount++
+			}
+		}
+
+		if seconds, ok := timeOnQuestion(sub, answer); ok {
+			totalSeconds += seconds
+			item.TimedResponses++
+		}
+	}
+
+	if scored > 0 {
+		difficulty := round2(float64(item.Correct) / float64(scored) * 100)
+		item.DifficultyIndex = &difficulty
+	}
+	if upperScored > 0 && lowerScored > 0 {
+		discrimination := round2(float64(upperCorrect)/float64(upperScored) - float64(lowerCorrect)/float64(lowerScored))
+		item.DiscriminationIndex = &discrimination
+	}
+	if item.TimedResponses > 0 {
+		average := round2(totalSeconds / float64(item.TimedResponses))
+		item.AverageTimeSeconds = &average
+	}
+	for i := range item.Options {
+		if item.Attempts > 0 {
+			item.Options[i].Percentage = round2(float64(item.Options[i].Count) / float64(item.Attempts) * 100)
+		}
+	}
+	return item
+}
+
+// firstAttempts keeps each student's earliest completed attempt
+func firstAttempts(submissions []models.QuizSubmission) []models.QuizSubmission {
+	first := make(map[string]models.QuizSubmission)
+	for _, sub := range submissions {
+		current, ok := first[sub.StudentID]
+		if !ok || sub.AttemptNumber < current.AttemptNumber ||
+			(sub.AttemptNumber == current.AttemptNumber && sub.StartedAt.Before(current.StartedAt)) {
+			first[sub.StudentID] = sub
+		}
+	}
+
+	attempts := make([]models.QuizSubmission, 0, len(first))
+	for _, sub := range first {
+		attempts = append(attempts, sub)
+	}
+	return attempts
+}
+
+// wasGiven reports whether an attempt included the question. Attempts that
+// did not record their question set were given every question.
+func wasGiven(sub models.QuizSubmission, questionID string) bool {
+	if len(sub.Questions) == 0 {
+		return true
+	}
+	for _, q := range sub.Questions {
+		if q.ID == questionID {
+			return true
+		}
+	}
+	return false
+}
+
+// findAnswer returns the attempt's answer to a question, if any
+func findAnswer(sub models.QuizSubmission, questionID string) (models.Answer, bool) {
+	for _, a := range sub.Answers {
+		if a.QuestionID == questionID {
+			return a, true
+		}
+	}
+	return models.Answer{}, false
+}
+
+// timeOnQuestion is the time from the question's first view to when its
+// answer was last received. Questions never opened through view-question
+// have no recorded time.
+func timeOnQuestion(sub models.QuizSubmission, answer models.Answer) (float64, bool) {
+	if answer.AnsweredAt == nil {
+		return 0, false
+	}
+	for _, v := range sub.QuestionViews {
+		if v.QuestionID == answer.QuestionID {
+			if answer.AnsweredAt.Before(v.ViewedAt) {
+				return 0, false
+			}
+			return answer.AnsweredAt.Sub(v.ViewedAt).Seconds(), true
+		}
+	}
+	return 0, false
+}
+
+// round2 rounds to two decimal places for display
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
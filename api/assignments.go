@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	assignmentHandlers "github.com/Ravikiran27/GOLANG_SmartEdu-LMS/api/assignments"
+)
+
+// Handler routes all assignment-related requests
+func AssignmentsRouter(w http.ResponseWriter, r *http.Request) {
+	// Extract the path after /api/assignments/
+	path := strings.TrimPrefix(r.URL.Path, "/api/assignments/")
+	path = strings.TrimPrefix(path, "assignments/") // Handle both /api/assignments and /api/assignments/assignments
+
+	// Route to appropriate handler based on path
+	switch path {
+	case "create":
+		assignmentHandlers.CreateAssignment(w, r)
+	case "list":
+		assignmentHandlers.ListAssignments(w, r)
+	case "get":
+		assignmentHandlers.GetAssignment(w, r)
+	case "publish":
+		assignmentHandlers.PublishAssignment(w, r)
+	case "submit":
+		assignmentHandlers.SubmitAssignment(w, r)
+	case "submissions":
+		assignmentHandlers.ListSubmissions(w, r)
+	case "evaluate":
+		assignmentHandlers.EvaluateAssignment(w, r)
+	case "return":
+		assignmentHandlers.ReturnSubmission(w, r)
+	default:
+		http.Error(w, "Not Found", http.StatusNotFound)
+	}
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler creates a new assignment as a draft (teacher/admin only)
+func CreateAssignment(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate and authorize (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _, role := utils.GetUserFromContext(ctx)
+
+		// Parse request body
+		var req models.CreateAssignmentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		// Validate required fields
+		if req.Title == "" || req.Description == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Title and description are required")
+			return
+		}
+		if req.CourseID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Course ID is required")
+			return
+		}
+		if req.TotalMarks <= 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Total marks must be greater than 0")
+			return
+		}
+		if req.DueDate.IsZero() || req.DueDate.Before(time.Now()) {
+			utils.RespondError(w, http.StatusBadRequest, "Due date must be in the future")
+			return
+		}
+		if req.LatePenalty < 0 || req.LatePenalty > 100 {
+			utils.RespondError(w, http.StatusBadRequest, "Late penalty must be between 0 and 100 percent per day")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		// Verify course exists and user is the teacher or admin
+		course, err := db.Courses().Get(ctx, req.CourseID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Course not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse course data")
+			return
+		}
+
+		if role != "admin" && course.TeacherID != userID {
+			utils.RespondError(w, http.StatusForbidden, "You can only create assignments for your own courses")
+			return
+		}
+
+		attachments := req.Attachments
+		if attachments == nil {
+			attachments = []models.AssignmentAttachment{}
+		}
+
+		// Create assignment object
+		now := time.Now()
+		assignment := models.Assignment{
+			CourseID:            req.CourseID,
+			CourseTitle:         course.Title,
+			TeacherID:           userID,
+			Title:               req.Title,
+			Description:         req.Description,
+			Instructions:        req.Instructions,
+			Attachments:         attachments,
+			TotalMarks:          req.TotalMarks,
+			DueDate:             req.DueDate,
+			AllowLateSubmission: req.AllowLateSubmission,
+			LatePenalty:         req.LatePenalty,
+			IsPublished:         false,
+			CreatedAt:           now,
+			UpdatedAt:           now,
+		}
+
+		if err := db.Assignments().Create(ctx, &assignment); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create assignment")
+			return
+		}
+
+		utils.RespondCreated(w, assignment, "Assignment created successfully")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler grades an assignment submission, applying the late penalty (teacher/admin only)
+func EvaluateAssignment(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate and authorize (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _, role := utils.GetUserFromContext(ctx)
+
+		// Parse request body
+		var req models.EvaluateAssignmentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.SubmissionID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Submission ID is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		// Get submission
+		submission, err := db.AssignmentSubmissions().Get(ctx, req.SubmissionID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Submission not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse submission data")
+			return
+		}
+
+		if submission.Status == "returned" {
+			utils.RespondError(w, http.StatusBadRequest, "Submission is awaiting revision by the student")
+			return
+		}
+
+		// Get assignment and verify ownership
+		assignment, err := db.Assignments().Get(ctx, submission.AssignmentID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Assignment not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse assignment data")
+			return
+		}
+
+		if role != "admin" && assignment.TeacherID != userID {
+			utils.RespondError(w, http.StatusForbidden, "You can only evaluate submissions for your own assignments")
+			return
+		}
+
+		if req.MarksAwarded < 0 || req.MarksAwarded > assignment.TotalMarks {
+			utils.RespondError(w, http.StatusBadRequest, fmt.Sprintf("Marks must be between 0 and %g", assignment.TotalMarks))
+			return
+		}
+
+		// Late penalty is applied to the teacher's marks automatically
+		penalty := latePenalty(*assignment, *submission, req.MarksAwarded)
+
+		now := time.Now()
+		submission.MarksAwarded = req.MarksAwarded - penalty
+		submission.LatePenaltyApplied = penalty
+		submission.Feedback = req.Feedback
+		submission.Status = "evaluated"
+		submission.EvaluatedAt = &now
+		submission.EvaluatedBy = userID
+
+		if err := db.AssignmentSubmissions().Save(ctx, submission); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to save evaluation")
+			return
+		}
+
+		notification := models.Notification{
+			UserID:        submission.StudentID,
+			Type:          "grade_released",
+			Title:         "Assignment Graded",
+			Message:       "Your submission has been graded: " + assignment.Title,
+			ReferenceID:   submission.AssignmentID,
+			ReferenceType: "assignment",
+			IsRead:        false,
+			CreatedAt:     now,
+		}
+		db.Notifications().Create(ctx, &notification)
+
+		utils.RespondSuccess(w, submission, "Submission evaluated successfully")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
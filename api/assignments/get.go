@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler gets a single assignment by ID
+func GetAssignment(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow GET
+	if r.Method != http.MethodGet {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _, role := utils.GetUserFromContext(ctx)
+
+		// Get assignment ID from query
+		assignmentID := r.URL.Query().Get("id")
+		if assignmentID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Assignment ID is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		assignment, err := db.Assignments().Get(ctx, assignmentID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Assignment not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse assignment data")
+			return
+		}
+
+		if assignment.IsDeleted {
+			utils.RespondError(w, http.StatusNotFound, "Assignment not found")
+			return
+		}
+
+		// Authorization checks
+		if role == "student" {
+			if !assignment.IsPublished {
+				utils.RespondError(w, http.StatusForbidden, "This assignment is not published")
+				return
+			}
+
+			enrollments, err := db.Enrollments().List(ctx, store.EnrollmentFilter{
+				StudentID: userID,
+				CourseID:  assignment.CourseID,
+				Status:    "active",
+			})
+			if err != nil || len(enrollments) == 0 {
+				utils.RespondError(w, http.StatusForbidden, "You must be enrolled in this course")
+				return
+			}
+		} else if role == "teacher" && assignment.TeacherID != userID {
+			utils.RespondError(w, http.StatusForbidden, "You can only view your own assignments")
+			return
+		}
+
+		utils.RespondSuccess(w, assignment, "Assignment fetched successfully")
+	})).ServeHTTP(w, r)
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"math"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+)
+
+// daysLate returns how many started days submittedAt falls after the due date
+func daysLate(dueDate, submittedAt time.Time) int {
+	if !submittedAt.After(dueDate) {
+		return 0
+	}
+	return int(math.Ceil(submittedAt.Sub(dueDate).Hours() / 24))
+}
+
+// latePenalty returns the marks to deduct: LatePenalty percent of marks per
+// day late, capped at the marks themselves
+func latePenalty(assignment models.Assignment, submission models.AssignmentSubmission, marks float64) float64 {
+	if !submission.IsLateSubmission || assignment.LatePenalty <= 0 {
+		return 0
+	}
+	penalty := marks * assignment.LatePenalty * float64(submission.DaysLate) / 100
+	if penalty > marks {
+		penalty = marks
+	}
+	return penalty
+}
+
+// LateSubmissionCloses returns the last moment a late submission can still
+// earn marks: the due date when late work isn't accepted, the day the penalty
+// reaches 100% otherwise. A zero time means late work is accepted forever.
+func LateSubmissionCloses(assignment models.Assignment) time.Time {
+	if !assignment.AllowLateSubmission {
+		return assignment.DueDate
+	}
+	if assignment.LatePenalty <= 0 {
+		return time.Time{}
+	}
+	days := int(math.Ceil(100/assignment.LatePenalty)) - 1
+	return assignment.DueDate.Add(time.Duration(days) * 24 * time.Hour)
+}
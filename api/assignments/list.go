@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler lists assignments (filtered by role and course)
+func ListAssignments(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow GET
+	if r.Method != http.MethodGet {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _, role := utils.GetUserFromContext(ctx)
+
+		// Get query parameters
+		courseID := r.URL.Query().Get("courseId")
+		limit := 20
+		if l := r.URL.Query().Get("limit"); l != "" {
+			if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+				limit = parsed
+			}
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		filter := store.AssignmentFilter{CourseID: courseID, Limit: limit}
+
+		// Role-based filtering
+		switch role {
+		case "admin":
+			// Admins see all assignments
+		case "teacher":
+			// Teachers see only their assignments
+			filter.TeacherID = userID
+		case "student":
+			// Students see only published assignments for courses they're enrolled in
+			filter.PublishedOnly = true
+
+			if courseID == "" {
+				enrollments, err := db.Enrollments().List(ctx, store.EnrollmentFilter{
+					StudentID: userID,
+					Status:    "active",
+				})
+				if err != nil {
+					utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch enrollments")
+					return
+				}
+
+				courseIDs := make([]string, 0)
+				for _, enrollment := range enrollments {
+					courseIDs = append(courseIDs, enrollment.CourseID)
+				}
+
+				if len(courseIDs) == 0 {
+					utils.RespondSuccess(w, []models.Assignment{}, "Assignments fetched successfully")
+					return
+				}
+
+				filter.CourseIDs = courseIDs
+			}
+		default:
+			utils.RespondError(w, http.StatusForbidden, "Invalid role")
+			return
+		}
+
+		// Nearest due date first
+		assignments, err := db.Assignments().List(ctx, filter)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch assignments")
+			return
+		}
+
+		utils.RespondSuccess(w, assignments, "Assignments fetched successfully")
+	})).ServeHTTP(w, r)
+}
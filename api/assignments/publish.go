@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler publishes or unpublishes an assignment (teacher/admin only)
+func PublishAssignment(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate and authorize (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _, role := utils.GetUserFromContext(ctx)
+
+		// Parse request body
+		var req struct {
+			AssignmentID string `json:"assignmentId"`
+			IsPublished  bool   `json:"isPublished"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.AssignmentID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Assignment ID is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		// Get assignment
+		assignment, err := db.Assignments().Get(ctx, req.AssignmentID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Assignment not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse assignment data")
+			return
+		}
+
+		if assignment.IsDeleted {
+			utils.RespondError(w, http.StatusNotFound, "Assignment not found")
+			return
+		}
+
+		if role != "admin" && assignment.TeacherID != userID {
+			utils.RespondError(w, http.StatusForbidden, "You can only publish your own assignments")
+			return
+		}
+
+		now := time.Now()
+		wasPublished := assignment.IsPublished
+		assignment.IsPublished = req.IsPublished
+		assignment.UpdatedAt = now
+
+		if err := db.Assignments().Save(ctx, assignment); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to update assignment")
+			return
+		}
+
+		// Let enrolled students know about newly published work
+		if req.IsPublished && !wasPublished {
+			enrollments, err := db.Enrollments().List(ctx, store.EnrollmentFilter{
+				CourseID: assignment.CourseID,
+				Status:   "active",
+			})
+			if err == nil {
+				for _, enrollment := range enrollments {
+					notification := models.Notification{
+						UserID:        enrollment.StudentID,
+						Type:          "assignment_due",
+						Title:         "New Assignment",
+						Message:       assignment.Title + " is due on " + assignment.DueDate.Format("Jan 2, 2006 15:04 MST"),
+						ReferenceID:   assignment.AssignmentID,
+						ReferenceType: "assignment",
+						IsRead:        false,
+						CreatedAt:     now,
+					}
+					db.Notifications().Create(ctx, &notification)
+				}
+			}
+		}
+
+		message := "Assignment unpublished successfully"
+		if req.IsPublished {
+			message = "Assignment published successfully"
+		}
+		utils.RespondSuccess(w, assignment, message)
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler returns a submission to the student for revision (teacher/admin only)
+func ReturnSubmission(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate and authorize (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _, role := utils.GetUserFromContext(ctx)
+
+		// Parse request body
+		var req models.ReturnAssignmentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.SubmissionID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Submission ID is required")
+			return
+		}
+		if req.Feedback == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Feedback is required when returning a submission")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		// Get submission
+		submission, err := db.AssignmentSubmissions().Get(ctx, req.SubmissionID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Submission not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse submission data")
+			return
+		}
+
+		if submission.Status != "submitted" {
+			utils.RespondError(w, http.StatusBadRequest, "Only submitted work can be returned for revision")
+			return
+		}
+
+		// Get assignment and verify ownership
+		assignment, err := db.Assignments().Get(ctx, submission.AssignmentID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Assignment not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse assignment data")
+			return
+		}
+
+		if role != "admin" && assignment.TeacherID != userID {
+			utils.RespondError(w, http.StatusForbidden, "You can only return submissions for your own assignments")
+			return
+		}
+
+		now := time.Now()
+		submission.Status = "returned"
+		submission.Feedback = req.Feedback
+		submission.EvaluatedBy = userID
+
+		if err := db.AssignmentSubmissions().Save(ctx, submission); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to return submission")
+			return
+		}
+
+		notification := models.Notification{
+			UserID:        submission.StudentID,
+			Type:          "assignment_returned",
+			Title:         "Revision Requested",
+			Message:       "Your teacher has returned your submission for revision: " + assignment.Title,
+			ReferenceID:   submission.AssignmentID,
+			ReferenceType: "assignment",
+			IsRead:        false,
+			CreatedAt:     now,
+		}
+		db.Notifications().Create(ctx, &notification)
+
+		utils.RespondSuccess(w, submission, "Submission returned for revision")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
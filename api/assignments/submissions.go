@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler lists submissions for an assignment (own submission for students)
+func ListSubmissions(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow GET
+	if r.Method != http.MethodGet {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _, role := utils.GetUserFromContext(ctx)
+
+		assignmentID := r.URL.Query().Get("assignmentId")
+		status := r.URL.Query().Get("status")
+		if assignmentID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Assignment ID is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		assignment, err := db.Assignments().Get(ctx, assignmentID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Assignment not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse assignment data")
+			return
+		}
+
+		filter := store.AssignmentSubmissionFilter{AssignmentID: assignmentID}
+
+		if role == "student" {
+			filter.StudentID = userID
+		} else if role == "teacher" && assignment.TeacherID != userID {
+			utils.RespondError(w, http.StatusForbidden, "You can only view submissions for your own assignments")
+			return
+		}
+
+		if status != "" {
+			filter.Statuses = []string{status}
+		}
+
+		submissions, err := db.AssignmentSubmissions().List(ctx, filter)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch submissions")
+			return
+		}
+
+		utils.RespondSuccess(w, submissions, "Submissions fetched successfully")
+	})).ServeHTTP(w, r)
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler submits (or resubmits) an assignment for a student
+func SubmitAssignment(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (students only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _, _ := utils.GetUserFromContext(ctx)
+
+		// Parse request body
+		var req models.SubmitAssignmentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.AssignmentID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Assignment ID is required")
+			return
+		}
+		if strings.TrimSpace(req.SubmissionText) == "" && len(req.Attachments) == 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Submission text or at least one attachment is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		// Get assignment
+		assignment, err := db.Assignments().Get(ctx, req.AssignmentID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Assignment not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse assignment data")
+			return
+		}
+
+		if assignment.IsDeleted || !assignment.IsPublished {
+			utils.RespondError(w, http.StatusForbidden, "This assignment is not available")
+			return
+		}
+
+		// Verify student is enrolled in the course
+		enrollments, err := db.Enrollments().List(ctx, store.EnrollmentFilter{
+			StudentID: userID,
+			CourseID:  assignment.CourseID,
+			Status:    "active",
+		})
+		if err != nil || len(enrollments) == 0 {
+			utils.RespondError(w, http.StatusForbidden, "You must be enrolled in this course")
+			return
+		}
+
+		now := time.Now()
+		attachments := make([]models.AssignmentSubmissionFile, 0, len(req.Attachments))
+		for _, file := range req.Attachments {
+			if file.Name == "" || file.URL == "" {
+				utils.RespondError(w, http.StatusBadRequest, "Attachments require a name and URL")
+				return
+			}
+			if file.UploadedAt.IsZero() {
+				file.UploadedAt = now
+			}
+			attachments = append(attachments, file)
+		}
+
+		// Look for an existing submission
+		existing, err := db.AssignmentSubmissions().List(ctx, store.AssignmentSubmissionFilter{
+			AssignmentID: req.AssignmentID,
+			StudentID:    userID,
+		})
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to check existing submission")
+			return
+		}
+
+		var submission models.AssignmentSubmission
+		isRevision := false
+
+		if len(existing) > 0 {
+			submission = existing[0]
+
+			switch submission.Status {
+			case "evaluated":
+				utils.RespondError(w, http.StatusConflict, "Submission has already been evaluated")
+				return
+			case "returned":
+				// Revisions requested by the teacher keep the lateness of the original submission
+				isRevision = true
+			}
+		} else {
+			// Get student name
+			if user, err := db.Users().Get(ctx, userID); err == nil {
+				submission.StudentName = user.DisplayName
+			}
+			submission.AssignmentID = req.AssignmentID
+			submission.StudentID = userID
+		}
+
+		if !isRevision {
+			late := daysLate(assignment.DueDate, now)
+			if late > 0 && !assignment.AllowLateSubmission {
+				utils.RespondError(w, http.StatusForbidden, "The due date has passed and late submissions are not allowed")
+				return
+			}
+			submission.IsLateSubmission = late > 0
+			submission.DaysLate = late
+		}
+
+		submission.SubmissionText = req.SubmissionText
+		submission.Attachments = attachments
+		submission.SubmittedAt = now
+		submission.Status = "submitted"
+
+		if len(existing) > 0 {
+			err = db.AssignmentSubmissions().Save(ctx, &submission)
+		} else {
+			err = db.AssignmentSubmissions().Create(ctx, &submission)
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to submit assignment")
+			return
+		}
+
+		message := "Assignment submitted successfully"
+		if submission.IsLateSubmission {
+			message = "Assignment submitted late"
+		}
+		utils.RespondSuccess(w, submission, message)
+	}), "student").ServeHTTP(w, r)
+}
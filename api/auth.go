@@ -23,6 +23,18 @@ func AuthRouter(w http.ResponseWriter, r *http.Request) {
 		authHandlers.UpdateProfile(w, r)
 	case "set-role":
 		authHandlers.SetRole(w, r)
+	case "import":
+		authHandlers.ImportUsers(w, r)
+	case "invite":
+		authHandlers.CreateInvitation(w, r)
+	case "invitations":
+		authHandlers.ListInvitations(w, r)
+	case "revoke-invitation":
+		authHandlers.RevokeInvitation(w, r)
+	case "role-requests":
+		authHandlers.ListRoleRequests(w, r)
+	case "review-role-request":
+		authHandlers.ReviewRoleRequest(w, r)
 	default:
 		http.Error(w, "Not Found", http.StatusNotFound)
 	}
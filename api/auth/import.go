@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// maxRosterBytes limits the size of an uploaded roster
+const maxRosterBytes = 5 << 20
+
+// ImportUsers creates users from a roster CSV sent as the request body
+// (Admin only). dryRun=true only validates; courseIds enrolls the new
+// students in each listed course.
+func ImportUsers(w http.ResponseWriter, r *http.Request) {
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	utils.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		ctx := r.Context()
+		uid, _, _ := utils.GetUserFromContext(ctx)
+
+		dryRun := r.URL.Query().Get("dryRun") == "true"
+		var courseIDs []string
+		if ids := r.URL.Query().Get("courseIds"); ids != "" {
+			for _, id := range strings.Split(ids, ",") {
+				courseIDs = append(courseIDs, strings.TrimSpace(id))
+			}
+		}
+
+		rows, err := ParseRoster(http.MaxBytesReader(w, r.Body, maxRosterBytes))
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
+			return
+		}
+
+		courses, err := RosterCourses(ctx, db, courseIDs)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		report, err := ImportRoster(ctx, db, utils.GetAccountImporter(), rows, courses, dryRun, uid)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		message := "Users imported"
+		if dryRun {
+			message = "Roster validated"
+		}
+		utils.RespondSuccess(w, report, message)
+	}, "admin")(w, r)
+}
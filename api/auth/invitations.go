@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// ListInvitations lists every invitation code, newest first (Admin only)
+func ListInvitations(w http.ResponseWriter, r *http.Request) {
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	utils.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		ctx := r.Context()
+
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
+			return
+		}
+
+		invitations, err := db.Invitations().List(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch invitations")
+			return
+		}
+
+		utils.RespondSuccess(w, invitations)
+	}, "admin")(w, r)
+}
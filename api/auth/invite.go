@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// inviteCodeLength is the length of generated invitation codes
+const inviteCodeLength = 10
+
+// CreateInvitation creates an invitation code for registering a teacher, or
+// a student from outside the allowed email domains (Admin only)
+func CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	utils.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		ctx := r.Context()
+		uid, _, _ := utils.GetUserFromContext(ctx)
+
+		var req models.InvitationRequest
+		if err := utils.ParseJSONBody(r, &req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.Role == "" {
+			req.Role = "teacher"
+		}
+		if req.Role != "teacher" && req.Role != "student" {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid role. Must be teacher or student")
+			return
+		}
+		if req.Email != "" && !utils.ValidateEmail(req.Email) {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid email")
+			return
+		}
+		if req.MaxUses < 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Max uses cannot be negative")
+			return
+		}
+		if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+			utils.RespondError(w, http.StatusBadRequest, "Expiry must be in the future")
+			return
+		}
+
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
+			return
+		}
+
+		code, err := utils.NewCode(inviteCodeLength)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to generate invitation code")
+			return
+		}
+
+		invitation := models.Invitation{
+			Code:      code,
+			Role:      req.Role,
+			Email:     strings.ToLower(req.Email),
+			MaxUses:   req.MaxUses,
+			ExpiresAt: req.ExpiresAt,
+			CreatedBy: uid,
+			CreatedAt: utils.GetCurrentTimestamp(),
+		}
+		if err := db.Invitations().Save(ctx, &invitation); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create invitation")
+			return
+		}
+
+		utils.RespondCreated(w, invitation, "Invitation created")
+	}, "admin")(w, r)
+}
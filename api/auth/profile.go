@@ -1,11 +1,9 @@
 package handler
 
 import (
-	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
 	"net/http"
-
-	"cloud.google.com/go/firestore"
 )
 
 // GetProfile retrieves the authenticated user's profile
@@ -25,30 +23,27 @@ func GetProfile(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		uid, _, _ := utils.GetUserFromContext(ctx)
 
-		// Get Firestore client
-		firestoreClient, err := utils.GetFirestoreClient(ctx)
+		// Get data store
+		db, err := store.GetStore(ctx)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
 			return
 		}
 
 		// Get user document
-		doc, err := firestoreClient.Collection("users").Doc(uid).Get(ctx)
-		if err != nil {
+		user, err := db.Users().Get(ctx, uid)
+		if err == store.ErrNotFound {
 			utils.RespondError(w, http.StatusNotFound, "User not found")
 			return
 		}
-
-		var user models.User
-		if err := doc.DataTo(&user); err != nil {
+		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse user data")
 			return
 		}
 
 		// Update last login
-		firestoreClient.Collection("users").Doc(uid).Update(ctx, []firestore.Update{
-			{Path: "metadata.lastLogin", Value: utils.GetCurrentTimestamp()},
-		})
+		user.Metadata.LastLogin = utils.GetCurrentTimestamp()
+		db.Users().Save(ctx, user)
 
 		utils.RespondSuccess(w, user)
 	})(w, r)
@@ -3,13 +3,19 @@ package handler
 import (
 	"context"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
 	"net/http"
+	"time"
 
 	"firebase.google.com/go/v4/auth"
 )
 
-// Register creates a new user with Firebase Auth and Firestore
+// Register creates a new user with Firebase Auth and Firestore. Public
+// sign-up creates students from the allowed email domains. A teacher needs an
+// invitation code; without one the account starts as a student with a
+// pending teacher request for an admin to review. Admins are never created
+// here.
 func Register(w http.ResponseWriter, r *http.Request) {
 	utils.EnableCORS(w, r)
 	if r.Method == "OPTIONS" {
@@ -31,17 +37,55 @@ func Register(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate required fields
-	if req.Email == "" || req.Password == "" || req.DisplayName == "" || req.Role == "" {
-		utils.RespondError(w, http.StatusBadRequest, "Email, password, displayName, and role are required")
+	if req.Email == "" || req.Password == "" || req.DisplayName == "" {
+		utils.RespondError(w, http.StatusBadRequest, "Email, password, and displayName are required")
+		return
+	}
+	if req.Role == "" {
+		req.Role = "student"
+	}
+
+	// Admin accounts only come from SetRole or the bootstrap command
+	if req.Role == "admin" {
+		utils.RespondError(w, http.StatusForbidden, "Admin accounts cannot be self-registered")
 		return
 	}
 
 	// Validate role
-	if req.Role != "admin" && req.Role != "teacher" && req.Role != "student" {
-		utils.RespondError(w, http.StatusBadRequest, "Invalid role. Must be admin, teacher, or student")
+	if req.Role != "teacher" && req.Role != "student" {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid role. Must be teacher or student")
+		return
+	}
+
+	// Get data store
+	db, err := store.GetStore(ctx)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
 		return
 	}
 
+	// An invitation sets the role and lifts the email domain restriction
+	role := "student"
+	requestTeacher := false
+	if req.InviteCode != "" {
+		invitation, err := db.Invitations().Get(ctx, req.InviteCode)
+		if err != nil && err != store.ErrNotFound {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch invitation")
+			return
+		}
+		if err == store.ErrNotFound || !store.InvitationUsable(*invitation, req.Email, time.Now()) {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid or expired invitation code")
+			return
+		}
+		role = invitation.Role
+	} else {
+		if !utils.EmailDomainAllowed(req.Email) {
+			utils.RespondError(w, http.StatusForbidden, "Sign-up is not open to this email domain")
+			return
+		}
+		requestTeacher = req.Role == "teacher"
+	}
+
 	// Get Firebase Auth client
 	authClient, err := utils.GetAuthClient(ctx)
 	if err != nil {
@@ -65,7 +109,7 @@ func Register(w http.ResponseWriter, r *http.Request) {
 
 	// Set custom claims for role-based access
 	claims := map[string]interface{}{
-		"role": req.Role,
+		"role": role,
 	}
 	if err := authClient.SetCustomUserClaims(ctx, firebaseUser.UID, claims); err != nil {
 		// Rollback: delete user if claim setting fails
@@ -74,21 +118,13 @@ func Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get Firestore client
-	firestoreClient, err := utils.GetFirestoreClient(ctx)
-	if err != nil {
-		authClient.DeleteUser(ctx, firebaseUser.UID)
-		utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
-		return
-	}
-
-	// Create user document in Firestore
+	// Create user document
 	now := utils.GetCurrentTimestamp()
 	user := models.User{
 		UID:         firebaseUser.UID,
 		Email:       req.Email,
 		DisplayName: req.DisplayName,
-		Role:        req.Role,
+		Role:        role,
 		PhotoURL:    "",
 		IsActive:    true,
 		CreatedAt:   now,
@@ -101,17 +137,60 @@ func Register(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
-	_, err = firestoreClient.Collection("users").Doc(firebaseUser.UID).Set(ctx, user)
-	if err != nil {
+	if err := db.Users().Save(ctx, &user); err != nil {
 		// Rollback: delete Firebase Auth user
 		authClient.DeleteUser(ctx, firebaseUser.UID)
 		utils.RespondError(w, http.StatusInternalServerError, "Failed to create user document")
 		return
 	}
 
+	// Count the invitation use only once the account exists, so a failed
+	// sign-up never uses it up. Another sign-up may have used it up meanwhile.
+	if req.InviteCode != "" {
+		if _, err := db.Invitations().Redeem(ctx, req.InviteCode, req.Email, time.Now()); err != nil {
+			// Rollback: delete the user document and Firebase Auth user
+			db.Users().Delete(ctx, firebaseUser.UID)
+			authClient.DeleteUser(ctx, firebaseUser.UID)
+			if err == store.ErrInvitationUnusable || err == store.ErrNotFound {
+				utils.RespondError(w, http.StatusBadRequest, "Invalid or expired invitation code")
+				return
+			}
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to redeem invitation")
+			return
+		}
+	}
+
+	// Queue the teacher request for an admin
+	if requestTeacher {
+		request := models.RoleRequest{
+			UserID:      firebaseUser.UID,
+			Email:       req.Email,
+			DisplayName: req.DisplayName,
+			Role:        "teacher",
+			Status:      "pending",
+			Department:  req.Department,
+			CreatedAt:   now,
+		}
+		if err := db.RoleRequests().Save(ctx, &request); err != nil {
+			// Rollback: delete the user document and Firebase Auth user
+			db.Users().Delete(ctx, firebaseUser.UID)
+			authClient.DeleteUser(ctx, firebaseUser.UID)
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to save teacher request")
+			return
+		}
+
+		utils.RespondCreated(w, map[string]interface{}{
+			"uid":            firebaseUser.UID,
+			"email":          req.Email,
+			"role":           role,
+			"teacherRequest": request.Status,
+		}, "User registered; teacher access is awaiting approval")
+		return
+	}
+
 	utils.RespondCreated(w, map[string]interface{}{
 		"uid":   firebaseUser.UID,
 		"email": req.Email,
-		"role":  req.Role,
+		"role":  role,
 	}, "User registered successfully")
 }
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// ReviewRoleRequest approves or rejects a pending teacher request (Admin
+// only). Approval sets the teacher role claim; the user gets it on their
+// next token refresh. Either way the user is notified.
+func ReviewRoleRequest(w http.ResponseWriter, r *http.Request) {
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	utils.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		ctx := r.Context()
+		uid, _, _ := utils.GetUserFromContext(ctx)
+
+		var req models.ReviewRoleRequest
+		if err := utils.ParseJSONBody(r, &req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if req.UserID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "User ID is required")
+			return
+		}
+
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
+			return
+		}
+
+		request, err := db.RoleRequests().Get(ctx, req.UserID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Request not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch request")
+			return
+		}
+		if request.Status != "pending" {
+			utils.RespondError(w, http.StatusConflict, "Request has already been reviewed")
+			return
+		}
+
+		now := utils.GetCurrentTimestamp()
+		if req.Approve {
+			authClient, err := utils.GetAuthClient(ctx)
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize auth client")
+				return
+			}
+			claims := map[string]interface{}{
+				"role": request.Role,
+			}
+			if err := authClient.SetCustomUserClaims(ctx, request.UserID, claims); err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to set role")
+				return
+			}
+
+			user, err := db.Users().Get(ctx, request.UserID)
+			if err == nil {
+				user.Role = request.Role
+				user.UpdatedAt = now
+				err = db.Users().Save(ctx, user)
+			}
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to update user role in database")
+				return
+			}
+		}
+
+		request.Status = "rejected"
+		if req.Approve {
+			request.Status = "approved"
+		}
+		request.ReviewedBy = uid
+		request.ReviewNote = req.Note
+		request.ReviewedAt = &now
+		if err := db.RoleRequests().Save(ctx, request); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to update request")
+			return
+		}
+
+		message := "Your request for teacher access was not approved."
+		if req.Approve {
+			message = "Your request for teacher access was approved. Sign in again to use it."
+		}
+		if req.Note != "" {
+			message += " " + req.Note
+		}
+		db.Notifications().Create(ctx, &models.Notification{
+			UserID:        request.UserID,
+			Type:          "role_request",
+			Title:         "Teacher access " + request.Status,
+			Message:       message,
+			ReferenceID:   request.UserID,
+			ReferenceType: "user",
+			CreatedAt:     now,
+		})
+
+		db.AuditLogs().Create(ctx, &models.AuditLog{
+			Action:     "role_request_" + request.Status,
+			ActorID:    uid,
+			TargetType: "user",
+			TargetID:   request.UserID,
+			Details: map[string]interface{}{
+				"role": request.Role,
+				"note": req.Note,
+			},
+			Timestamp: now,
+		})
+
+		utils.RespondSuccess(w, request, "Request "+request.Status)
+	}, "admin")(w, r)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// RevokeInvitation stops an invitation code from being used (Admin only)
+func RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	utils.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		ctx := r.Context()
+
+		var req struct {
+			Code string `json:"code"`
+		}
+		if err := utils.ParseJSONBody(r, &req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if req.Code == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Code is required")
+			return
+		}
+
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
+			return
+		}
+
+		invitation, err := db.Invitations().Get(ctx, req.Code)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Invitation not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch invitation")
+			return
+		}
+
+		invitation.Revoked = true
+		if err := db.Invitations().Save(ctx, invitation); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to revoke invitation")
+			return
+		}
+
+		utils.RespondSuccess(w, invitation, "Invitation revoked")
+	}, "admin")(w, r)
+}
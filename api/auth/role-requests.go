@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// ListRoleRequests lists teacher requests, pending ones unless status is
+// given, oldest first (Admin only)
+func ListRoleRequests(w http.ResponseWriter, r *http.Request) {
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	utils.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		ctx := r.Context()
+
+		status := r.URL.Query().Get("status")
+		switch status {
+		case "":
+			status = "pending"
+		case "all":
+			status = ""
+		case "pending", "approved", "rejected":
+		default:
+			utils.RespondError(w, http.StatusBadRequest, "Invalid status")
+			return
+		}
+
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
+			return
+		}
+
+		requests, err := db.RoleRequests().List(ctx, status)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch requests")
+			return
+		}
+
+		utils.RespondSuccess(w, requests)
+	}, "admin")(w, r)
+}
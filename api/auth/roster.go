@@ -0,0 +1,350 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+	"github.com/google/uuid"
+)
+
+// MaxRosterRows caps the users in one import
+const MaxRosterRows = 5000
+
+// Row statuses in an import report
+const (
+	rosterValid   = "valid"
+	rosterCreated = "created"
+	rosterExists  = "exists"
+	rosterInvalid = "invalid"
+	rosterFailed  = "failed"
+)
+
+// rosterColumns maps lower-cased CSV headers to row fields
+var rosterColumns = map[string]func(*models.RosterRow, string){
+	"email":       func(r *models.RosterRow, v string) { r.Email = strings.ToLower(v) },
+	"displayname": func(r *models.RosterRow, v string) { r.DisplayName = v },
+	"role":        func(r *models.RosterRow, v string) { r.Role = strings.ToLower(v) },
+	"department":  func(r *models.RosterRow, v string) { r.Department = v },
+	"rollnumber":  func(r *models.RosterRow, v string) { r.RollNumber = v },
+	"employeeid":  func(r *models.RosterRow, v string) { r.EmployeeID = v },
+}
+
+// ParseRoster reads a roster CSV. The header row names the columns in any
+// order; email and displayName are required, the rest are optional and
+// unknown columns are ignored. Blank lines are skipped.
+func ParseRoster(r io.Reader) ([]models.RosterRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("Roster is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Invalid CSV: %v", err)
+	}
+
+	setters := make([]func(*models.RosterRow, string), len(header))
+	found := make(map[string]bool)
+	for i, name := range header {
+		key := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		setters[i] = rosterColumns[key]
+		found[key] = true
+	}
+	if !found["email"] || !found["displayname"] {
+		return nil, errors.New("Roster must have email and displayName columns")
+	}
+
+	rows := make([]models.RosterRow, 0)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Invalid CSV: %v", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		row := models.RosterRow{Line: line}
+		blank := true
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			if value != "" {
+				blank = false
+			}
+			if i < len(setters) && setters[i] != nil {
+				setters[i](&row, value)
+			}
+		}
+		if blank {
+			continue
+		}
+		if row.Role == "" {
+			row.Role = "student"
+		}
+
+		rows = append(rows, row)
+		if len(rows) > MaxRosterRows {
+			return nil, fmt.Errorf("Roster has more than %d rows", MaxRosterRows)
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("Roster has no users")
+	}
+	return rows, nil
+}
+
+// RosterCourses loads the courses imported students are enrolled in
+func RosterCourses(ctx context.Context, db store.Store, courseIDs []string) ([]models.Course, error) {
+	courses := make([]models.Course, 0, len(courseIDs))
+	seen := make(map[string]bool)
+	for _, id := range courseIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		course, err := db.Courses().Get(ctx, id)
+		if err != nil || course.IsDeleted {
+			return nil, fmt.Errorf("Course %s not found", id)
+		}
+		courses = append(courses, *course)
+	}
+	return courses, nil
+}
+
+// ImportRoster validates every row, then, unless dryRun is set, creates the
+// valid users in batches with their role claim and profile and enrolls new
+// students in courses. Rows whose email already has an account are left
+// alone. The error is only set when the import could not run at all.
+func ImportRoster(ctx context.Context, db store.Store, importer utils.AccountImporter, rows []models.RosterRow, courses []models.Course, dryRun bool, actorID string) (*models.RosterImport, error) {
+	report := &models.RosterImport{
+		DryRun:    dryRun,
+		CourseIDs: make([]string, len(courses)),
+		Total:     len(rows),
+		Rows:      make([]models.RosterRowResult, len(rows)),
+	}
+	for i, c := range courses {
+		report.CourseIDs[i] = c.CourseID
+	}
+
+	// Validate each row on its own and against earlier rows
+	emails := make(map[string]int)
+	rollNumbers := make(map[string]int)
+	for i, row := range rows {
+		result := models.RosterRowResult{Line: row.Line, Email: row.Email, Role: row.Role}
+		result.Errors = validateRosterRow(row)
+
+		if line, ok := emails[row.Email]; ok && row.Email != "" {
+			result.Errors = append(result.Errors, fmt.Sprintf("Duplicate email (line %d)", line))
+		} else {
+			emails[row.Email] = row.Line
+		}
+		if line, ok := rollNumbers[row.RollNumber]; ok && row.RollNumber != "" {
+			result.Errors = append(result.Errors, fmt.Sprintf("Duplicate roll number (line %d)", line))
+		} else {
+			rollNumbers[row.RollNumber] = row.Line
+		}
+
+		result.Status = rosterValid
+		if len(result.Errors) > 0 {
+			result.Status = rosterInvalid
+		}
+		report.Rows[i] = result
+	}
+
+	// Rows that already have an account are skipped
+	valid := rowsWithStatus(report, rosterValid)
+	for _, batch := range rosterBatches(valid) {
+		lookup := make([]string, len(batch))
+		for j, i := range batch {
+			lookup[j] = rows[i].Email
+		}
+		existing, err := importer.ExistingEmails(ctx, lookup)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to look up existing users: %v", err)
+		}
+		for _, i := range batch {
+			if existing[rows[i].Email] {
+				report.Rows[i].Status = rosterExists
+				report.Rows[i].Errors = append(report.Rows[i].Errors, "Email already registered")
+			}
+		}
+	}
+
+	if !dryRun {
+		enrollments := make(map[string]int)
+		for _, batch := range rosterBatches(rowsWithStatus(report, rosterValid)) {
+			importBatch(ctx, db, importer, rows, batch, courses, report, enrollments)
+		}
+		for courseID, n := range enrollments {
+			db.Courses().AddEnrollments(ctx, courseID, n)
+		}
+
+		db.AuditLogs().Create(ctx, &models.AuditLog{
+			Action:     "users_imported",
+			ActorID:    actorID,
+			TargetType: "user",
+			Details: map[string]interface{}{
+				"total":     report.Total,
+				"created":   countStatus(report, rosterCreated),
+				"failed":    countStatus(report, rosterFailed),
+				"courseIds": report.CourseIDs,
+			},
+			Timestamp: utils.GetCurrentTimestamp(),
+		})
+	}
+
+	for _, result := range report.Rows {
+		switch result.Status {
+		case rosterValid:
+			report.Valid++
+		case rosterCreated:
+			report.Created++
+			report.Enrolled += len(result.Enrolled)
+		case rosterExists:
+			report.Existing++
+		case rosterInvalid:
+			report.Invalid++
+		case rosterFailed:
+			report.Failed++
+		}
+	}
+	return report, nil
+}
+
+// importBatch creates one batch of accounts, their profiles and enrollments
+func importBatch(ctx context.Context, db store.Store, importer utils.AccountImporter, rows []models.RosterRow, batch []int, courses []models.Course, report *models.RosterImport, enrollments map[string]int) {
+	accounts := make([]utils.Account, len(batch))
+	for j, i := range batch {
+		accounts[j] = utils.Account{
+			UID:         uuid.New().String(),
+			Email:       rows[i].Email,
+			DisplayName: rows[i].DisplayName,
+			Role:        rows[i].Role,
+		}
+	}
+
+	errs, err := importer.ImportAccounts(ctx, accounts)
+	for j, i := range batch {
+		result := &report.Rows[i]
+		accountErr := err
+		if accountErr == nil && j < len(errs) {
+			accountErr = errs[j]
+		}
+		if accountErr != nil {
+			result.Status = rosterFailed
+			result.Errors = append(result.Errors, "Failed to create user: "+accountErr.Error())
+			continue
+		}
+
+		row := rows[i]
+		now := utils.GetCurrentTimestamp()
+		user := models.User{
+			UID:         accounts[j].UID,
+			Email:       row.Email,
+			DisplayName: row.DisplayName,
+			Role:        row.Role,
+			IsActive:    true,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			Metadata: models.UserMetadata{
+				Department: row.Department,
+				RollNumber: row.RollNumber,
+				EmployeeID: row.EmployeeID,
+			},
+		}
+		if err := db.Users().Save(ctx, &user); err != nil {
+			// Rollback: delete the account so the row can be imported again
+			importer.DeleteAccount(ctx, user.UID)
+			result.Status = rosterFailed
+			result.Errors = append(result.Errors, "Failed to create user document")
+			continue
+		}
+
+		result.Status = rosterCreated
+		result.UID = user.UID
+		if row.Role != "student" {
+			continue
+		}
+
+		for _, course := range courses {
+			enrollment := models.Enrollment{
+				EnrollmentID:       uuid.New().String(),
+				StudentID:          user.UID,
+				StudentName:        user.DisplayName,
+				CourseID:           course.CourseID,
+				CourseTitle:        course.Title,
+				EnrolledAt:         now,
+				Progress:           0,
+				CompletedMaterials: []string{},
+				Status:             "active",
+				LastAccessedAt:     now,
+			}
+			if err := db.Enrollments().Save(ctx, &enrollment); err != nil {
+				result.Errors = append(result.Errors, "Failed to enroll in "+course.CourseID)
+				continue
+			}
+			result.Enrolled = append(result.Enrolled, course.CourseID)
+			enrollments[course.CourseID]++
+		}
+	}
+}
+
+// validateRosterRow checks one row's fields
+func validateRosterRow(row models.RosterRow) []string {
+	var errs []string
+	if row.Email == "" {
+		errs = append(errs, "Email is required")
+	} else if !utils.ValidateEmail(row.Email) {
+		errs = append(errs, "Invalid email")
+	}
+	if row.DisplayName == "" {
+		errs = append(errs, "Display name is required")
+	}
+	if row.Role == "admin" {
+		errs = append(errs, "Admin accounts cannot be imported; use set-role")
+	} else if row.Role != "teacher" && row.Role != "student" {
+		errs = append(errs, "Invalid role. Must be teacher or student")
+	}
+	return errs
+}
+
+// rowsWithStatus returns the indexes of rows with the given status
+func rowsWithStatus(report *models.RosterImport, status string) []int {
+	indexes := make([]int, 0)
+	for i, result := range report.Rows {
+		if result.Status == status {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+func countStatus(report *models.RosterImport, status string) int {
+	return len(rowsWithStatus(report, status))
+}
+
+// rosterBatches splits row indexes into importer-sized batches
+func rosterBatches(indexes []int) [][]int {
+	batches := make([][]int, 0)
+	for len(indexes) > 0 {
+		n := utils.AccountBatchSize
+		if len(indexes) < n {
+			n = len(indexes)
+		}
+		batches = append(batches, indexes[:n])
+		indexes = indexes[n:]
+	}
+	return batches
+}
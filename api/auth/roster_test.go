@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// fakeImporter records imported accounts in memory. Emails in existing
+// already have an account and emails in failing are refused.
+type fakeImporter struct {
+	existing map[string]bool
+	failing  map[string]bool
+	imported []utils.Account
+	deleted  []string
+}
+
+func (f *fakeImporter) ExistingEmails(ctx context.Context, emails []string) (map[string]bool, error) {
+	found := make(map[string]bool)
+	for _, email := range emails {
+		if f.existing[email] {
+			found[email] = true
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeImporter) ImportAccounts(ctx context.Context, accounts []utils.Account) ([]error, error) {
+	errs := make([]error, len(accounts))
+	for i, account := range accounts {
+		if f.failing[account.Email] {
+			errs[i] = errors.New("email rejected")
+			continue
+		}
+		f.imported = append(f.imported, account)
+	}
+	return errs, nil
+}
+
+func (f *fakeImporter) DeleteAccount(ctx context.Context, uid string) error {
+	f.deleted = append(f.deleted, uid)
+	return nil
+}
+
+func TestParseRosterHeader(t *testing.T) {
+	tests := []struct {
+		name string
+		csv  string
+	}{
+		{"byte order mark", "\ufeffemail,displayName\nada@example.com,Ada\n"},
+		{"any column order", "role,displayName,department,email\n,Ada,Maths,ada@example.com\n"},
+		{"any case", "EMAIL, DisplayName ,ROLE\nada@example.com,Ada,\n"},
+		{"unknown columns ignored", "email,notes,displayName\nada@example.com,hi,Ada\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := ParseRoster(strings.NewReader(tt.csv))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rows) != 1 {
+				t.Fatalf("got %d rows, want 1", len(rows))
+			}
+			row := rows[0]
+			if row.Email != "ada@example.com" || row.DisplayName != "Ada" || row.Role != "student" || row.Line != 2 {
+				t.Errorf("row = %+v", row)
+			}
+		})
+	}
+}
+
+func TestParseRosterRows(t *testing.T) {
+	rows, err := ParseRoster(strings.NewReader("email,displayName,role,rollNumber\nAda@Example.com,Ada,Teacher,\n\n, ,\nbob@example.com,Bob,,R2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want blank lines skipped: %+v", len(rows), rows)
+	}
+	if rows[0].Email != "ada@example.com" || rows[0].Role != "teacher" {
+		t.Errorf("email and role not lower-cased: %+v", rows[0])
+	}
+	if rows[1].Line != 5 || rows[1].RollNumber != "R2" {
+		t.Errorf("second row = %+v, want line 5 with roll number R2", rows[1])
+	}
+
+	for _, bad := range []string{"", "email,role\nada@example.com,student\n", "email,displayName\n"} {
+		if _, err := ParseRoster(strings.NewReader(bad)); err == nil {
+			t.Errorf("ParseRoster(%q) succeeded", bad)
+		}
+	}
+}
+
+func rosterFixture(t *testing.T) (*store.MemoryStore, []models.Course) {
+	t.Helper()
+	db := store.NewMemoryStore()
+	course := models.Course{CourseID: "course", Title: "Course"}
+	db.Courses().Save(context.Background(), &course)
+	return db, []models.Course{course}
+}
+
+func TestImportRosterDuplicates(t *testing.T) {
+	db, courses := rosterFixture(t)
+	rows := []models.RosterRow{
+		{Line: 2, Email: "ada@example.com", DisplayName: "Ada", Role: "student", RollNumber: "R1"},
+		{Line: 3, Email: "ada@example.com", DisplayName: "Ada again", Role: "student", RollNumber: "R2"},
+		{Line: 4, Email: "bob@example.com", DisplayName: "Bob", Role: "student", RollNumber: "R1"},
+	}
+
+	report, err := ImportRoster(context.Background(), db, &fakeImporter{}, rows, courses, true, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		status string
+		err    string
+	}{
+		{rosterValid, ""},
+		{rosterInvalid, "Duplicate email (line 2)"},
+		{rosterInvalid, "Duplicate roll number (line 2)"},
+	}
+	for i, w := range want {
+		result := report.Rows[i]
+		if result.Status != w.status || (w.err != "" && (len(result.Errors) != 1 || result.Errors[0] != w.err)) {
+			t.Errorf("line %d = %s %v, want %s %q", result.Line, result.Status, result.Errors, w.status, w.err)
+		}
+	}
+	if report.Valid != 1 || report.Invalid != 2 {
+		t.Errorf("valid %d, invalid %d; want 1 and 2", report.Valid, report.Invalid)
+	}
+}
+
+func TestImportRosterDryRun(t *testing.T) {
+	db, courses := rosterFixture(t)
+	ctx := context.Background()
+	importer := &fakeImporter{existing: map[string]bool{"bob@example.com": true}}
+	rows := []models.RosterRow{
+		{Line: 2, Email: "ada@example.com", DisplayName: "Ada", Role: "student"},
+		{Line: 3, Email: "bob@example.com", DisplayName: "Bob", Role: "student"},
+	}
+
+	report, err := ImportRoster(ctx, db, importer, rows, courses, true, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Rows[0].Status != rosterValid || report.Rows[1].Status != rosterExists {
+		t.Errorf("statuses = %s, %s; want valid, exists", report.Rows[0].Status, report.Rows[1].Status)
+	}
+
+	// A dry run creates nothing
+	if len(importer.imported) != 0 {
+		t.Errorf("dry run imported %d accounts", len(importer.imported))
+	}
+	if enrollments, _ := db.Enrollments().List(ctx, store.EnrollmentFilter{}); len(enrollments) != 0 {
+		t.Errorf("dry run created %d enrollments", len(enrollments))
+	}
+}
+
+func TestImportRosterStatuses(t *testing.T) {
+	db, courses := rosterFixture(t)
+	ctx := context.Background()
+	importer := &fakeImporter{
+		existing: map[string]bool{"old@example.com": true},
+		failing:  map[string]bool{"bad@example.com": true},
+	}
+	rows := []models.RosterRow{
+		{Line: 2, Email: "ada@example.com", DisplayName: "Ada", Role: "student"},
+		{Line: 3, Email: "tom@example.com", DisplayName: "Tom", Role: "teacher"},
+		{Line: 4, Email: "old@example.com", DisplayName: "Old", Role: "student"},
+		{Line: 5, Email: "bad@example.com", DisplayName: "Bad", Role: "student"},
+	}
+
+	report, err := ImportRoster(ctx, db, importer, rows, courses, false, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantStatus := []string{rosterCreated, rosterCreated, rosterExists, rosterFailed}
+	for i, want := range wantStatus {
+		if got := report.Rows[i].Status; got != want {
+			t.Errorf("line %d status = %s, want %s", report.Rows[i].Line, got, want)
+		}
+	}
+	if report.Created != 2 || report.Existing != 1 || report.Failed != 1 {
+		t.Errorf("created %d, existing %d, failed %d; want 2, 1, 1", report.Created, report.Existing, report.Failed)
+	}
+
+	// Only the student is enrolled
+	if enrolled := report.Rows[0].Enrolled; len(enrolled) != 1 || enrolled[0] != "course" {
+		t.Errorf("student enrolled in %v, want [course]", enrolled)
+	}
+	if enrolled := report.Rows[1].Enrolled; len(enrolled) != 0 {
+		t.Errorf("teacher enrolled in %v", enrolled)
+	}
+	enrollments, _ := db.Enrollments().List(ctx, store.EnrollmentFilter{CourseID: "course"})
+	if len(enrollments) != 1 || enrollments[0].StudentID != report.Rows[0].UID {
+		t.Errorf("enrollments = %+v, want only the student's", enrollments)
+	}
+	if course, _ := db.Courses().Get(ctx, "course"); course.EnrollmentCount != 1 {
+		t.Errorf("course enrollment count = %d, want 1", course.EnrollmentCount)
+	}
+
+	// Profiles are saved with their role
+	if user, err := db.Users().Get(ctx, report.Rows[1].UID); err != nil || user.Role != "teacher" {
+		t.Errorf("teacher profile = %+v, %v", user, err)
+	}
+}
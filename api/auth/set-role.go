@@ -1,10 +1,9 @@
 package handler
 
 import (
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
 	"net/http"
-
-	"cloud.google.com/go/firestore"
 )
 
 // SetRole assigns or updates a user's role (Admin only)
@@ -60,17 +59,19 @@ func SetRole(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Update Firestore
-		firestoreClient, err := utils.GetFirestoreClient(ctx)
+		// Update stored profile
+		db, err := store.GetStore(ctx)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
 			return
 		}
 
-		_, err = firestoreClient.Collection("users").Doc(req.UID).Update(ctx, []firestore.Update{
-			{Path: "role", Value: req.Role},
-			{Path: "updatedAt", Value: utils.GetCurrentTimestamp()},
-		})
+		user, err := db.Users().Get(ctx, req.UID)
+		if err == nil {
+			user.Role = req.Role
+			user.UpdatedAt = utils.GetCurrentTimestamp()
+			err = db.Users().Save(ctx, user)
+		}
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to update user role in database")
 			return
@@ -2,10 +2,9 @@ package handler
 
 import (
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
 	"net/http"
-
-	"cloud.google.com/go/firestore"
 )
 
 // UpdateProfile updates the authenticated user's profile
@@ -32,51 +31,43 @@ func UpdateProfile(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Get Firestore client
-		firestoreClient, err := utils.GetFirestoreClient(ctx)
+		// Get data store
+		db, err := store.GetStore(ctx)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
 			return
 		}
 
-		// Build update fields
-		updates := []firestore.Update{
-			{Path: "updatedAt", Value: utils.GetCurrentTimestamp()},
+		user, err := db.Users().Get(ctx, uid)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch updated profile")
+			return
 		}
 
+		// Apply update fields
+		user.UpdatedAt = utils.GetCurrentTimestamp()
 		if req.DisplayName != "" {
-			updates = append(updates, firestore.Update{Path: "displayName", Value: req.DisplayName})
+			user.DisplayName = req.DisplayName
 		}
 		if req.PhotoURL != "" {
-			updates = append(updates, firestore.Update{Path: "photoURL", Value: req.PhotoURL})
+			user.PhotoURL = req.PhotoURL
 		}
 		if req.Department != "" {
-			updates = append(updates, firestore.Update{Path: "metadata.department", Value: req.Department})
+			user.Metadata.Department = req.Department
 		}
 		if req.RollNumber != "" {
-			updates = append(updates, firestore.Update{Path: "metadata.rollNumber", Value: req.RollNumber})
+			user.Metadata.RollNumber = req.RollNumber
 		}
 		if req.EmployeeID != "" {
-			updates = append(updates, firestore.Update{Path: "metadata.employeeId", Value: req.EmployeeID})
+			user.Metadata.EmployeeID = req.EmployeeID
 		}
 
 		// Update user document
-		_, err = firestoreClient.Collection("users").Doc(uid).Update(ctx, updates)
-		if err != nil {
+		if err := db.Users().Save(ctx, user); err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to update profile")
 			return
 		}
 
-		// Fetch updated user
-		doc, err := firestoreClient.Collection("users").Doc(uid).Get(ctx)
-		if err != nil {
-			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch updated profile")
-			return
-		}
-
-		var user models.User
-		doc.DataTo(&user)
-
 		utils.RespondSuccess(w, user, "Profile updated successfully")
 	})(w, r)
 }
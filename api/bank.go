@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	bankHandlers "github.com/Ravikiran27/GOLANG_SmartEdu-LMS/api/bank"
+)
+
+// Handler routes all question bank requests
+func BankRouter(w http.ResponseWriter, r *http.Request) {
+	// Extract the path after /api/bank/
+	path := strings.TrimPrefix(r.URL.Path, "/api/bank/")
+
+	// Route to appropriate handler based on path
+	switch path {
+	case "create":
+		bankHandlers.CreateBankQuestion(w, r)
+	case "list":
+		bankHandlers.ListBankQuestions(w, r)
+	case "get":
+		bankHandlers.GetBankQuestion(w, r)
+	case "update":
+		bankHandlers.UpdateBankQuestion(w, r)
+	case "delete":
+		bankHandlers.DeleteBankQuestion(w, r)
+	case "attach":
+		bankHandlers.AttachBankQuestion(w, r)
+	case "sync":
+		bankHandlers.SyncBankQuestion(w, r)
+	default:
+		http.Error(w, "Not Found", http.StatusNotFound)
+	}
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	quizHandlers "github.com/Ravikiran27/GOLANG_SmartEdu-LMS/api/quizzes"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler attaches a bank question to a quiz or exam. The quiz or exam gets a
+// copy of one version, so later bank edits never change what students saw.
+func AttachBankQuestion(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		// Parse request body
+		var req models.AttachBankQuestionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.BankQuestionID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Question ID is required")
+			return
+		}
+		if (req.QuizID == "") == (req.ExamID == "") {
+			utils.RespondError(w, http.StatusBadRequest, "Exactly one of quizId or examId is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		question, err := db.QuestionBank().Get(ctx, req.BankQuestionID)
+		if err == store.ErrNotFound || (err == nil && question.IsDeleted) {
+			utils.RespondError(w, http.StatusNotFound, "Question not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse question data")
+			return
+		}
+
+		if !canView(*question, userID, role, userDepartment(ctx, db, userID)) {
+			utils.RespondError(w, http.StatusForbidden, "You do not have access to this question")
+			return
+		}
+
+		// Attach the latest version unless a specific one is requested
+		version := question.Version
+		if req.Version > 0 {
+			version = req.Version
+		}
+		snapshot, err := db.QuestionBank().GetVersion(ctx, req.BankQuestionID, version)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Question version not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse question data")
+			return
+		}
+
+		// Load the target and the questions it already has
+		var existing []models.Question
+		order := 0
+		drawn := false
+		if req.QuizID != "" {
+			quiz, ok := quizHandlers.OwnedQuiz(w, r, db, req.QuizID, userID, role)
+			if !ok {
+				return
+			}
+			order = quiz.QuestionCount + 1
+			drawn = !quizHandlers.CountsQuestionMarks(*quiz)
+			existing, err = db.Questions().ListByQuiz(ctx, req.QuizID)
+		} else {
+			exam, err := db.Exams().Get(ctx, req.ExamID)
+			if err == store.ErrNotFound || (err == nil && exam.IsDeleted) {
+				utils.RespondError(w, http.StatusNotFound, "Exam not found")
+				return
+			}
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to parse exam data")
+				return
+			}
+			if role != "admin" && exam.TeacherID != userID {
+				utils.RespondError(w, http.StatusForbidden, "You can only add questions to your own exams")
+				return
+			}
+			if exam.IsPublished && time.Now().After(exam.StartTime) {
+				utils.RespondError(w, http.StatusBadRequest, "Cannot add questions after the exam has started")
+				return
+			}
+			order = exam.QuestionsCount + 1
+			existing, err = db.Questions().ListByExam(ctx, req.ExamID)
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch questions")
+			return
+		}
+
+		for _, q := range existing {
+			if q.BankQuestionID == req.BankQuestionID {
+				utils.RespondError(w, http.StatusConflict, "Question is already attached")
+				return
+			}
+		}
+
+		attached := attachedQuestion(*snapshot, req.QuizID, req.ExamID, order, time.Now())
+		if req.QuizID != "" {
+			attached.Pool = req.Pool
+		}
+		if err := db.Questions().Create(ctx, &attached); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to add question")
+			return
+		}
+
+		// Update question count (and total marks for quizzes that ask every question)
+		if req.QuizID != "" {
+			marks := attached.Points
+			if drawn {
+				marks = 0
+			}
+			err = db.Quizzes().AddQuestionStats(ctx, req.QuizID, 1, marks)
+		} else {
+			err = db.Exams().AddQuestions(ctx, req.ExamID, 1)
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to update question count")
+			return
+		}
+
+		utils.RespondSuccess(w, map[string]interface{}{
+			"question": attached,
+		}, "Question attached successfully")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
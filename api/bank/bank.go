@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// validBankTypes lists the question types the bank can hold
+var validBankTypes = map[string]bool{
+	"mcq":          true,
+	"true_false":   true,
+	"short_answer": true,
+	"long_answer":  true,
+	"descriptive":  true,
+}
+
+// validateBankQuestion checks a create or edit request
+func validateBankQuestion(req models.BankQuestionRequest) error {
+	return utils.ValidateQuestion(models.Question{
+		Type:            req.Type,
+		Text:            req.Text,
+		Points:          req.Points,
+		Options:         req.Options,
+		CorrectAnswer:   req.CorrectAnswer,
+		AcceptedAnswers: req.AcceptedAnswers,
+		ScoringPolicy:   req.ScoringPolicy,
+		Difficulty:      req.Difficulty,
+	}, validBankTypes)
+}
+
+// normalizeTags trims, lower-cases and de-duplicates tags so searches match
+// regardless of how a teacher typed them
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// applyRequest copies the editable fields of req onto question
+func applyRequest(question *models.BankQuestion, req models.BankQuestionRequest) {
+	question.Type = req.Type
+	question.Text = req.Text
+	question.ImageURL = req.ImageURL
+	question.Points = req.Points
+	question.Options = req.Options
+	question.CorrectAnswer = req.CorrectAnswer
+	question.AcceptedAnswers = req.AcceptedAnswers
+	question.ScoringPolicy = req.ScoringPolicy
+	question.Explanation = req.Explanation
+	question.Topics = normalizeTags(req.Topics)
+	question.Difficulty = req.Difficulty
+	question.LearningOutcomes = normalizeTags(req.LearningOutcomes)
+	question.Shared = req.Shared
+}
+
+// userDepartment returns the caller's department, if their profile has one
+func userDepartment(ctx context.Context, db store.Store, uid string) string {
+	user, err := db.Users().Get(ctx, uid)
+	if err != nil {
+		return ""
+	}
+	return user.Metadata.Department
+}
+
+// canView reports whether a teacher may use a bank question: their own, or
+// one shared with their department. Admins can use any question.
+func canView(question models.BankQuestion, uid, role, department string) bool {
+	if role == "admin" || question.OwnerID == uid {
+		return true
+	}
+	return question.Shared && department != "" && question.Department == department
+}
+
+// attachedQuestion copies a bank question version into a quiz or exam
+// question. Quizzes grade written answers as long_answer and exams as
+// descriptive, so the type is mapped to what the target understands.
+func attachedQuestion(snapshot models.BankQuestionVersion, quizID, examID string, order int, now time.Time) models.Question {
+	bq := snapshot.Question
+
+	questionType := bq.Type
+	if quizID != "" && questionType == "descriptive" {
+		questionType = "long_answer"
+	}
+	if examID != "" && questionType == "long_answer" {
+		questionType = "descriptive"
+	}
+
+	return models.Question{
+		QuizID:          quizID,
+		ExamID:          examID,
+		Type:            questionType,
+		Text:            bq.Text,
+		QuestionText:    bq.Text,
+		ImageURL:        bq.ImageURL,
+		Marks:           bq.Points,
+		Points:          bq.Points,
+		Options:         bq.Options,
+		CorrectAnswer:   bq.CorrectAnswer,
+		AcceptedAnswers: bq.AcceptedAnswers,
+		ScoringPolicy:   bq.ScoringPolicy,
+		Explanation:     bq.Explanation,
+		Order:           order,
+		Difficulty:      bq.Difficulty,
+		BankQuestionID:  snapshot.BankQuestionID,
+		BankVersion:     snapshot.Version,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
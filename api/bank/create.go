@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler adds a question to the caller's question bank (teacher/admin only)
+func CreateBankQuestion(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+
+		// Parse request body
+		var req models.BankQuestionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if err := validateBankQuestion(req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		now := time.Now()
+		question := models.BankQuestion{
+			OwnerID:    userID,
+			Department: userDepartment(ctx, db, userID),
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		applyRequest(&question, req)
+
+		if err := db.QuestionBank().Create(ctx, &question, userID); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create question")
+			return
+		}
+
+		utils.RespondCreated(w, question, "Question added to bank")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
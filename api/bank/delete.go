@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler removes a question from the bank (soft delete). Existing quiz and
+// exam copies are unaffected.
+func DeleteBankQuestion(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow DELETE or POST
+	if r.Method != http.MethodDelete && r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		// Parse request body
+		var req struct {
+			BankQuestionID string `json:"bankQuestionId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.BankQuestionID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Question ID is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		question, err := db.QuestionBank().Get(ctx, req.BankQuestionID)
+		if err == store.ErrNotFound || (err == nil && question.IsDeleted) {
+			utils.RespondError(w, http.StatusNotFound, "Question not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse question data")
+			return
+		}
+
+		if role != "admin" && question.OwnerID != userID {
+			utils.RespondError(w, http.StatusForbidden, "You can only delete your own questions")
+			return
+		}
+
+		// Deletion is recorded as a version so the history stays complete
+		question.IsDeleted = true
+		question.UpdatedAt = time.Now()
+		err = db.QuestionBank().Revise(ctx, question, question.Version, userID)
+		if err == store.ErrConflict {
+			utils.RespondError(w, http.StatusConflict, "Question was edited by someone else, reload and try again")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to delete question")
+			return
+		}
+
+		utils.RespondSuccess(w, nil, "Question deleted successfully")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
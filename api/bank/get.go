@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler returns a bank question with its version history and where it is used
+func GetBankQuestion(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow GET
+	if r.Method != http.MethodGet {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		bankQuestionID := r.URL.Query().Get("id")
+		if bankQuestionID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Question ID is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		question, err := db.QuestionBank().Get(ctx, bankQuestionID)
+		if err == store.ErrNotFound || (err == nil && question.IsDeleted) {
+			utils.RespondError(w, http.StatusNotFound, "Question not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse question data")
+			return
+		}
+
+		if !canView(*question, userID, role, userDepartment(ctx, db, userID)) {
+			utils.RespondError(w, http.StatusForbidden, "You do not have access to this question")
+			return
+		}
+
+		versions, err := db.QuestionBank().ListVersions(ctx, bankQuestionID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch question history")
+			return
+		}
+
+		// Quiz and exam copies, with the version each one uses
+		attachments := make([]map[string]interface{}, 0)
+		if copies, err := db.Questions().ListByBankQuestion(ctx, bankQuestionID); err == nil {
+			for _, q := range copies {
+				attachments = append(attachments, map[string]interface{}{
+					"questionId":  q.ID,
+					"quizId":      q.QuizID,
+					"examId":      q.ExamID,
+					"bankVersion": q.BankVersion,
+				})
+			}
+		}
+
+		utils.RespondSuccess(w, map[string]interface{}{
+			"question":    question,
+			"versions":    versions,
+			"attachments": attachments,
+		})
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler searches the question bank. Teachers see their own questions plus
+// those shared with their department; scope=mine limits results to their own.
+func ListBankQuestions(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow GET
+	if r.Method != http.MethodGet {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+		query := r.URL.Query()
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		filter := store.BankFilter{
+			Topic:           strings.ToLower(strings.TrimSpace(query.Get("topic"))),
+			Difficulty:      query.Get("difficulty"),
+			LearningOutcome: strings.ToLower(strings.TrimSpace(query.Get("outcome"))),
+			Type:            query.Get("type"),
+			Text:            strings.TrimSpace(query.Get("q")),
+		}
+		if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit > 0 {
+			filter.Limit = limit
+		}
+
+		// Admins search everything unless they ask for their own questions
+		scope := query.Get("scope")
+		if role != "admin" || scope == "mine" {
+			filter.OwnerID = userID
+			if scope != "mine" {
+				filter.SharedDepartment = userDepartment(ctx, db, userID)
+			}
+		}
+
+		questions, err := db.QuestionBank().List(ctx, filter)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch questions")
+			return
+		}
+
+		utils.RespondSuccess(w, map[string]interface{}{
+			"questions": questions,
+			"count":     len(questions),
+		})
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
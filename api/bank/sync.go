@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	quizHandlers "github.com/Ravikiran27/GOLANG_SmartEdu-LMS/api/quizzes"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler moves the caller's quiz and exam copies of a bank question to its
+// latest version. Copies whose grading would change on quizzes that already
+// have attempts, or on exams that have started, are skipped so past
+// submissions still match the question as it was asked.
+func SyncBankQuestion(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		// Parse request body
+		var req struct {
+			BankQuestionID string `json:"bankQuestionId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.BankQuestionID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Question ID is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		question, err := db.QuestionBank().Get(ctx, req.BankQuestionID)
+		if err == store.ErrNotFound || (err == nil && question.IsDeleted) {
+			utils.RespondError(w, http.StatusNotFound, "Question not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse question data")
+			return
+		}
+
+		if !canView(*question, userID, role, userDepartment(ctx, db, userID)) {
+			utils.RespondError(w, http.StatusForbidden, "You do not have access to this question")
+			return
+		}
+
+		latest, err := db.QuestionBank().GetVersion(ctx, req.BankQuestionID, question.Version)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse question data")
+			return
+		}
+
+		copies, err := db.Questions().ListByBankQuestion(ctx, req.BankQuestionID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch questions")
+			return
+		}
+
+		now := time.Now()
+		updated := make([]string, 0)
+		skipped := make([]map[string]string, 0)
+		skip := func(questionID, reason string) {
+			skipped = append(skipped, map[string]string{"questionId": questionID, "reason": reason})
+		}
+
+		for _, copy := range copies {
+			if copy.BankVersion == latest.Version {
+				continue
+			}
+
+			refreshed := attachedQuestion(*latest, copy.QuizID, copy.ExamID, copy.Order, now)
+			refreshed.ID = copy.ID
+			refreshed.QuestionID = copy.QuestionID
+			refreshed.Pool = copy.Pool
+			refreshed.CreatedAt = copy.CreatedAt
+
+			if copy.QuizID != "" {
+				quiz, err := db.Quizzes().Get(ctx, copy.QuizID)
+				if err != nil || quiz.IsDeleted {
+					continue
+				}
+				if teaches, err := quizHandlers.TeachesQuiz(ctx, db, *quiz, userID, role); err != nil || !teaches {
+					continue
+				}
+
+				// Wording can always follow the bank; grading changes can't once
+				// students have attempted the quiz
+				gradingChanged := quizHandlers.GradingChanged(copy, refreshed)
+				if gradingChanged && !quiz.Attempted {
+					attempted, err := quizHandlers.QuizHasAttempts(ctx, db, copy.QuizID)
+					if err != nil || attempted {
+						skip(copy.ID, "quiz has attempts")
+						continue
+					}
+				}
+
+				// Save the copy and correct the quiz's total marks together
+				err = db.Questions().UpdateQuizQuestion(ctx, &refreshed, quizHandlers.CountsQuestionMarks(*quiz), gradingChanged)
+				if err == store.ErrQuizAttempted {
+					skip(copy.ID, "quiz has attempts")
+					continue
+				}
+				if err != nil {
+					skip(copy.ID, "failed to save")
+					continue
+				}
+			} else {
+				exam, err := db.Exams().Get(ctx, copy.ExamID)
+				if err != nil || exam.IsDeleted || (role != "admin" && exam.TeacherID != userID) {
+					continue
+				}
+				attempts, err := db.ExamSubmissions().List(ctx, store.ExamSubmissionFilter{ExamID: copy.ExamID})
+				if err != nil || len(attempts) > 0 || (exam.IsPublished && now.After(exam.StartTime)) {
+					skip(copy.ID, "exam has started")
+					continue
+				}
+				if err := db.Questions().Save(ctx, &refreshed); err != nil {
+					skip(copy.ID, "failed to save")
+					continue
+				}
+			}
+			updated = append(updated, copy.ID)
+		}
+
+		utils.RespondSuccess(w, map[string]interface{}{
+			"version": latest.Version,
+			"updated": updated,
+			"skipped": skipped,
+		}, "Attached copies synced")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
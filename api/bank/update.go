@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler edits a bank question, recording the edit as a new version. Quiz
+// and exam copies keep the version they were attached with until synced.
+func UpdateBankQuestion(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow PUT or POST
+	if r.Method != http.MethodPut && r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		// Parse request body
+		var req models.UpdateBankQuestionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.BankQuestionID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Question ID is required")
+			return
+		}
+		if req.Version <= 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Version is required")
+			return
+		}
+		if err := validateBankQuestion(req.BankQuestionRequest); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		question, err := db.QuestionBank().Get(ctx, req.BankQuestionID)
+		if err == store.ErrNotFound || (err == nil && question.IsDeleted) {
+			utils.RespondError(w, http.StatusNotFound, "Question not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse question data")
+			return
+		}
+
+		// Only the owner edits; shared questions are reused, not modified
+		if role != "admin" && question.OwnerID != userID {
+			utils.RespondError(w, http.StatusForbidden, "You can only edit your own questions")
+			return
+		}
+
+		applyRequest(question, req.BankQuestionRequest)
+		question.UpdatedAt = time.Now()
+
+		err = db.QuestionBank().Revise(ctx, question, req.Version, userID)
+		if err == store.ErrConflict {
+			utils.RespondError(w, http.StatusConflict, "Question was edited by someone else, reload and try again")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to update question")
+			return
+		}
+
+		utils.RespondSuccess(w, question, "Question updated successfully")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
@@ -27,8 +27,26 @@ func CoursesRouter(w http.ResponseWriter, r *http.Request) {
 		courseHandlers.DeleteCourse(w, r)
 	case "enroll":
 		courseHandlers.EnrollCourse(w, r)
+	case "join":
+		courseHandlers.JoinCourse(w, r)
+	case "create-join-code":
+		courseHandlers.CreateJoinCode(w, r)
+	case "join-codes":
+		courseHandlers.ListJoinCodes(w, r)
+	case "revoke-join-code":
+		courseHandlers.RevokeJoinCode(w, r)
+	case "join-requests":
+		courseHandlers.ListJoinRequests(w, r)
+	case "review-join-request":
+		courseHandlers.ReviewJoinRequest(w, r)
 	case "my-enrollments":
 		courseHandlers.GetMyEnrollments(w, r)
+	case "gradebook":
+		courseHandlers.GetGradebook(w, r)
+	case "gradebook-policy":
+		courseHandlers.UpdateGradebookPolicy(w, r)
+	case "gradebook-export":
+		courseHandlers.ExportGradebook(w, r)
 	default:
 		http.Error(w, "Not Found", http.StatusNotFound)
 	}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// joinCodeLength is the length of generated course join codes
+const joinCodeLength = 8
+
+// CreateJoinCode creates a join code for a course (Teacher/Admin only).
+// Students enroll with it in invite-code and approval-required courses.
+func CreateJoinCode(w http.ResponseWriter, r *http.Request) {
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	utils.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		ctx := r.Context()
+		uid, _, role := utils.GetUserFromContext(ctx)
+
+		var req models.JoinCodeRequest
+		if err := utils.ParseJSONBody(r, &req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if req.CourseID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Course ID is required")
+			return
+		}
+		if req.MaxUses < 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Max uses cannot be negative")
+			return
+		}
+		if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+			utils.RespondError(w, http.StatusBadRequest, "Expiry must be in the future")
+			return
+		}
+
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
+			return
+		}
+
+		course, err := db.Courses().Get(ctx, req.CourseID)
+		if err != nil || course.IsDeleted {
+			utils.RespondError(w, http.StatusNotFound, "Course not found")
+			return
+		}
+		if role == "teacher" && course.TeacherID != uid {
+			utils.RespondError(w, http.StatusForbidden, "You can only manage your own courses")
+			return
+		}
+
+		code, err := utils.NewCode(joinCodeLength)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to generate join code")
+			return
+		}
+
+		joinCode := models.JoinCode{
+			Code:      code,
+			CourseID:  course.CourseID,
+			MaxUses:   req.MaxUses,
+			ExpiresAt: req.ExpiresAt,
+			CreatedBy: uid,
+			CreatedAt: utils.GetCurrentTimestamp(),
+		}
+		if err := db.JoinCodes().Save(ctx, &joinCode); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create join code")
+			return
+		}
+
+		utils.RespondCreated(w, joinCode, "Join code created")
+	}, "teacher", "admin")(w, r)
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
 	"net/http"
 
@@ -36,23 +37,24 @@ func CreateCourse(w http.ResponseWriter, r *http.Request) {
 			utils.RespondError(w, http.StatusBadRequest, "Title, description, category, and difficulty are required")
 			return
 		}
+		if !validEnrollmentMode(req.EnrollmentMode) {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid enrollment mode. Must be open, invite_code, approval_required or closed")
+			return
+		}
 
 		// Get user info for teacher name
-		firestoreClient, err := utils.GetFirestoreClient(ctx)
+		db, err := store.GetStore(ctx)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
 			return
 		}
 
-		userDoc, err := firestoreClient.Collection("users").Doc(uid).Get(ctx)
+		user, err := db.Users().Get(ctx, uid)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to get user info")
 			return
 		}
 
-		var user models.User
-		userDoc.DataTo(&user)
-
 		// Create course document
 		now := utils.GetCurrentTimestamp()
 		course := models.Course{
@@ -68,13 +70,13 @@ func CreateCourse(w http.ResponseWriter, r *http.Request) {
 			Materials:       []models.CourseMaterial{},
 			EnrollmentCount: 0,
 			IsPublished:     false,
+			EnrollmentMode:  req.EnrollmentMode,
 			CreatedAt:       now,
 			UpdatedAt:       now,
 			IsDeleted:       false,
 		}
 
-		_, err = firestoreClient.Collection("courses").Doc(course.CourseID).Set(ctx, course)
-		if err != nil {
+		if err := db.Courses().Save(ctx, &course); err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to create course")
 			return
 		}
@@ -1,11 +1,9 @@
 package handler
 
 import (
-	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
 	"net/http"
-
-	"cloud.google.com/go/firestore"
 )
 
 // DeleteCourse soft deletes a course (Teacher/Admin only)
@@ -31,22 +29,19 @@ func DeleteCourse(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		firestoreClient, err := utils.GetFirestoreClient(ctx)
+		db, err := store.GetStore(ctx)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
 			return
 		}
 
 		// Get existing course
-		doc, err := firestoreClient.Collection("courses").Doc(courseID).Get(ctx)
+		course, err := db.Courses().Get(ctx, courseID)
 		if err != nil {
 			utils.RespondError(w, http.StatusNotFound, "Course not found")
 			return
 		}
 
-		var course models.Course
-		doc.DataTo(&course)
-
 		// Authorization: teacher can only delete own courses
 		if role == "teacher" && course.TeacherID != uid {
 			utils.RespondError(w, http.StatusForbidden, "You can only delete your own courses")
@@ -54,11 +49,9 @@ func DeleteCourse(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// Soft delete
-		_, err = firestoreClient.Collection("courses").Doc(courseID).Update(ctx, []firestore.Update{
-			{Path: "isDeleted", Value: true},
-			{Path: "updatedAt", Value: utils.GetCurrentTimestamp()},
-		})
-		if err != nil {
+		course.IsDeleted = true
+		course.UpdatedAt = utils.GetCurrentTimestamp()
+		if err := db.Courses().Save(ctx, course); err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to delete course")
 			return
 		}
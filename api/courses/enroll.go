@@ -1,15 +1,18 @@
 package handler
 
 import (
+	"context"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
-	"cloud.google.com/go/firestore"
 )
 
-// EnrollCourse enrolls a student in a course
+// EnrollCourse enrolls a student in a course. Courses that are not open need
+// a join code, or queue an enrollment request for the teacher to review.
 func EnrollCourse(w http.ResponseWriter, r *http.Request) {
 	utils.EnableCORS(w, r)
 	if r.Method == "OPTIONS" {
@@ -37,75 +40,155 @@ func EnrollCourse(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		firestoreClient, err := utils.GetFirestoreClient(ctx)
+		db, err := store.GetStore(ctx)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
 			return
 		}
 
-		// Check if course exists and is published
-		courseDoc, err := firestoreClient.Collection("courses").Doc(req.CourseID).Get(ctx)
-		if err != nil {
-			utils.RespondError(w, http.StatusNotFound, "Course not found")
-			return
-		}
+		enrollStudent(ctx, w, db, uid, req)
+	}, "student")(w, r)
+}
 
-		var course models.Course
-		courseDoc.DataTo(&course)
+// enrollStudent applies the course's enrollment mode to the request and
+// writes the response: the new enrollment, or a pending join request
+func enrollStudent(ctx context.Context, w http.ResponseWriter, db store.Store, uid string, req models.EnrollmentRequest) {
+	// Check if course exists and is published
+	course, err := db.Courses().Get(ctx, req.CourseID)
+	if err != nil || course.IsDeleted {
+		utils.RespondError(w, http.StatusNotFound, "Course not found")
+		return
+	}
 
-		if !course.IsPublished {
-			utils.RespondError(w, http.StatusBadRequest, "Course is not published")
-			return
-		}
+	if !course.IsPublished {
+		utils.RespondError(w, http.StatusBadRequest, "Course is not published")
+		return
+	}
 
-		// Check if already enrolled
-		existingEnrollment := firestoreClient.Collection("enrollments").
-			Where("studentId", "==", uid).
-			Where("courseId", "==", req.CourseID).
-			Limit(1).
-			Documents(ctx)
-		
-		if doc, err := existingEnrollment.Next(); err == nil && doc != nil {
-			utils.RespondError(w, http.StatusConflict, "Already enrolled in this course")
+	// Check if already enrolled
+	existing, err := db.Enrollments().List(ctx, store.EnrollmentFilter{
+		StudentID: uid,
+		CourseID:  req.CourseID,
+	})
+	if err == nil && len(existing) > 0 {
+		utils.RespondError(w, http.StatusConflict, "Already enrolled in this course")
+		return
+	}
+
+	// Get student info
+	user, err := db.Users().Get(ctx, uid)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to get user info")
+		return
+	}
+
+	// A join code admits the student to an invite-only course, or skips the
+	// approval queue
+	now := utils.GetCurrentTimestamp()
+	switch course.EnrollmentMode {
+	case models.EnrollmentClosed:
+		utils.RespondError(w, http.StatusForbidden, "Course is not accepting enrollments")
+		return
+	case models.EnrollmentApproval:
+		if req.JoinCode == "" {
+			requestToJoin(ctx, w, db, course, user, req.Message, now)
 			return
 		}
-
-		// Get student info
-		userDoc, err := firestoreClient.Collection("users").Doc(uid).Get(ctx)
-		if err != nil {
-			utils.RespondError(w, http.StatusInternalServerError, "Failed to get user info")
+	case models.EnrollmentInviteCode:
+		if req.JoinCode == "" {
+			utils.RespondError(w, http.StatusForbidden, "A join code is required to enroll in this course")
 			return
 		}
+	default:
+		req.JoinCode = ""
+	}
 
-		var user models.User
-		userDoc.DataTo(&user)
-
-		// Create enrollment
-		now := utils.GetCurrentTimestamp()
-		enrollment := models.Enrollment{
-			EnrollmentID:       uuid.New().String(),
-			StudentID:          uid,
-			StudentName:        user.DisplayName,
-			CourseID:           req.CourseID,
-			CourseTitle:        course.Title,
-			EnrolledAt:         now,
-			Progress:           0,
-			CompletedMaterials: []string{},
-			Status:             "active",
-			LastAccessedAt:     now,
-		}
+	enrollment := newEnrollment(course, user, now)
+	if err := db.Enrollments().Save(ctx, &enrollment); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to create enrollment")
+		return
+	}
 
-		_, err = firestoreClient.Collection("enrollments").Doc(enrollment.EnrollmentID).Set(ctx, enrollment)
-		if err != nil {
-			utils.RespondError(w, http.StatusInternalServerError, "Failed to create enrollment")
+	// Count the join code use only once the enrollment exists, so a failed
+	// save never uses it up. Another student may have used it up meanwhile.
+	if req.JoinCode != "" {
+		if _, err := db.JoinCodes().Redeem(ctx, req.JoinCode, course.CourseID, now); err != nil {
+			// Rollback: remove the enrollment
+			db.Enrollments().Delete(ctx, enrollment.EnrollmentID)
+			if err == store.ErrNotFound || err == store.ErrJoinCodeUnusable {
+				utils.RespondError(w, http.StatusForbidden, "Join code is invalid or has expired")
+				return
+			}
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to redeem join code")
 			return
 		}
+	}
 
-		// Increment course enrollment count
-		firestoreClient.Collection("courses").Doc(req.CourseID).Update(ctx, []firestore.Update{
-			{Path: "enrollmentCount", Value: firestore.Increment(1)},
-		})
+	// Increment course enrollment count
+	db.Courses().AddEnrollments(ctx, course.CourseID, 1)
 
-		utils.RespondCreated(w, enrollment, "Enrolled successfully")
-	}, "student")(w, r)
+	utils.RespondCreated(w, enrollment, "Enrolled successfully")
+}
+
+// requestToJoin queues a join request for a course that requires approval
+// and tells the teacher about it
+func requestToJoin(ctx context.Context, w http.ResponseWriter, db store.Store, course *models.Course, user *models.User, message string, now time.Time) {
+	previous, err := db.JoinRequests().Get(ctx, course.CourseID, user.UID)
+	if err == nil && previous.Status == "pending" {
+		utils.RespondError(w, http.StatusConflict, "Enrollment request is already pending")
+		return
+	}
+
+	request := models.JoinRequest{
+		CourseID:     course.CourseID,
+		CourseTitle:  course.Title,
+		StudentID:    user.UID,
+		StudentName:  user.DisplayName,
+		StudentEmail: user.Email,
+		Message:      message,
+		Status:       "pending",
+		CreatedAt:    now,
+	}
+	if err := db.JoinRequests().Save(ctx, &request); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to create enrollment request")
+		return
+	}
+
+	db.Notifications().Create(ctx, &models.Notification{
+		UserID:        course.TeacherID,
+		Type:          "enrollment_request",
+		Title:         "New enrollment request",
+		Message:       user.DisplayName + " asked to join " + course.Title + ".",
+		ReferenceID:   course.CourseID,
+		ReferenceType: "course",
+		CreatedAt:     now,
+	})
+
+	utils.RespondJSON(w, http.StatusAccepted, request)
+}
+
+// newEnrollment returns an active enrollment of user in course
+func newEnrollment(course *models.Course, user *models.User, now time.Time) models.Enrollment {
+	return models.Enrollment{
+		EnrollmentID:       uuid.New().String(),
+		StudentID:          user.UID,
+		StudentName:        user.DisplayName,
+		CourseID:           course.CourseID,
+		CourseTitle:        course.Title,
+		EnrolledAt:         now,
+		Progress:           0,
+		CompletedMaterials: []string{},
+		Status:             "active",
+		LastAccessedAt:     now,
+	}
+}
+
+// validEnrollmentMode reports whether mode is a known enrollment mode; empty
+// leaves the mode unchanged
+func validEnrollmentMode(mode string) bool {
+	switch mode {
+	case "", models.EnrollmentOpen, models.EnrollmentInviteCode, models.EnrollmentApproval, models.EnrollmentClosed:
+		return true
+	}
+	return false
 }
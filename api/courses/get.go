@@ -1,7 +1,7 @@
 package handler
 
 import (
-	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
 	"net/http"
 )
@@ -29,22 +29,19 @@ func GetCourse(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		firestoreClient, err := utils.GetFirestoreClient(ctx)
+		db, err := store.GetStore(ctx)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
 			return
 		}
 
 		// Get course document
-		doc, err := firestoreClient.Collection("courses").Doc(courseID).Get(ctx)
+		course, err := db.Courses().Get(ctx, courseID)
 		if err != nil {
 			utils.RespondError(w, http.StatusNotFound, "Course not found")
 			return
 		}
 
-		var course models.Course
-		doc.DataTo(&course)
-
 		// Check if course is deleted
 		if course.IsDeleted {
 			utils.RespondError(w, http.StatusNotFound, "Course not found")
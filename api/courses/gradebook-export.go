@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// ExportGradebook downloads a course's gradebook as CSV or XLSX for the
+// registrar: one row per student with every item, category and the overall
+// grade (teacher/admin only)
+func ExportGradebook(w http.ResponseWriter, r *http.Request) {
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	utils.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		ctx := r.Context()
+		uid, _, role := utils.GetUserFromContext(ctx)
+
+		courseID := r.URL.Query().Get("courseId")
+		if courseID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Course ID is required")
+			return
+		}
+		format, ok := utils.ExportFormat(r)
+		if !ok {
+			utils.RespondError(w, http.StatusBadRequest, "Format must be csv or xlsx")
+			return
+		}
+
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
+			return
+		}
+
+		course, err := db.Courses().Get(ctx, courseID)
+		if err == store.ErrNotFound || (err == nil && course.IsDeleted) {
+			utils.RespondError(w, http.StatusNotFound, "Course not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse course data")
+			return
+		}
+
+		if role == "teacher" && course.TeacherID != uid {
+			utils.RespondError(w, http.StatusForbidden, "You can only export gradebooks for your own courses")
+			return
+		}
+
+		studentIDs, err := classStudents(ctx, db, courseID, "")
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch enrollments")
+			return
+		}
+
+		gradebook, err := buildGradebook(ctx, db, *course, studentIDs)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to build gradebook")
+			return
+		}
+
+		utils.RespondSheet(w, format, "gradebook-"+course.CourseID, gradebookSheet(*course, *gradebook))
+	}, "teacher", "admin")(w, r)
+}
+
+// gradebookSheet lays out the gradebook with percentages in every score
+// column. Pending and upcoming items are left blank; missing ones show 0.
+func gradebookSheet(course models.Course, gradebook models.Gradebook) utils.Sheet {
+	sheet := utils.Sheet{
+		Name:   course.Title,
+		Header: []string{"Roll Number", "Student Name", "Email"},
+	}
+	for _, item := range gradebook.Items {
+		sheet.Header = append(sheet.Header, item.Title+" (%)")
+	}
+	for _, category := range gradebook.Policy.Categories {
+		sheet.Header = append(sheet.Header, strings.ToUpper(category.Type[:1])+category.Type[1:]+" (%)")
+	}
+	sheet.Header = append(sheet.Header, "Total (%)", "Letter")
+
+	for _, student := range gradebook.Students {
+		row := []interface{}{student.RollNumber, student.StudentName, student.Email}
+
+		entries := make(map[string]models.GradeEntry, len(student.Entries))
+		for _, e := range student.Entries {
+			entries[e.ItemID] = e
+		}
+		for _, item := range gradebook.Items {
+			row = append(row, entries[item.ID].Percentage)
+		}
+
+		categories := make(map[string]*float64, len(student.Categories))
+		for _, c := range student.Categories {
+			categories[c.Type] = c.Percentage
+		}
+		for _, category := range gradebook.Policy.Categories {
+			row = append(row, categories[category.Type])
+		}
+
+		row = append(row, student.Percentage, student.Letter)
+		sheet.Rows = append(sheet.Rows, row)
+	}
+	return sheet
+}
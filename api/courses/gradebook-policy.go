@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// UpdateGradebookPolicy sets a course's category weights, drop rules,
+// default attempt policy and letter grades
+func UpdateGradebookPolicy(w http.ResponseWriter, r *http.Request) {
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	utils.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		ctx := r.Context()
+		uid, _, role := utils.GetUserFromContext(ctx)
+
+		var req struct {
+			CourseID string `json:"courseId"`
+			models.GradebookPolicy
+		}
+		if err := utils.ParseJSONBody(r, &req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if req.CourseID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Course ID is required")
+			return
+		}
+
+		policy := req.GradebookPolicy
+		if err := normalizeGradebookPolicy(&policy); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
+			return
+		}
+
+		course, err := db.Courses().Get(ctx, req.CourseID)
+		if err == store.ErrNotFound || (err == nil && course.IsDeleted) {
+			utils.RespondError(w, http.StatusNotFound, "Course not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse course data")
+			return
+		}
+
+		if role == "teacher" && course.TeacherID != uid {
+			utils.RespondError(w, http.StatusForbidden, "You can only update your own courses")
+			return
+		}
+
+		course.Gradebook = &policy
+		course.UpdatedAt = utils.GetCurrentTimestamp()
+		if err := db.Courses().Save(ctx, course); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to update course")
+			return
+		}
+
+		utils.RespondSuccess(w, policy, "Gradebook policy updated")
+	}, "teacher", "admin")(w, r)
+}
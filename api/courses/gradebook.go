@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// GetGradebook returns a course's weighted grades. Teachers and admins get
+// the whole class with a summary, or one student with studentId; students
+// get their own row.
+func GetGradebook(w http.ResponseWriter, r *http.Request) {
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	utils.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		ctx := r.Context()
+		uid, _, role := utils.GetUserFromContext(ctx)
+
+		courseID := r.URL.Query().Get("courseId")
+		if courseID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Course ID is required")
+			return
+		}
+		studentID := r.URL.Query().Get("studentId")
+		if role == "student" {
+			studentID = uid
+		}
+
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
+			return
+		}
+
+		course, err := db.Courses().Get(ctx, courseID)
+		if err == store.ErrNotFound || (err == nil && course.IsDeleted) {
+			utils.RespondError(w, http.StatusNotFound, "Course not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse course data")
+			return
+		}
+
+		if role == "teacher" && course.TeacherID != uid {
+			utils.RespondError(w, http.StatusForbidden, "You can only view gradebooks for your own courses")
+			return
+		}
+
+		studentIDs, err := classStudents(ctx, db, courseID, studentID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch enrollments")
+			return
+		}
+		if studentID != "" && len(studentIDs) == 0 {
+			utils.RespondError(w, http.StatusNotFound, "Student is not enrolled in this course")
+			return
+		}
+
+		gradebook, err := buildGradebook(ctx, db, *course, studentIDs)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to build gradebook")
+			return
+		}
+		if studentID != "" {
+			gradebook.Class = nil
+		}
+
+		utils.RespondSuccess(w, gradebook)
+	}, "student", "teacher", "admin")(w, r)
+}
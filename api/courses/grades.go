@@ -0,0 +1,531 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	assignmentHandlers "github.com/Ravikiran27/GOLANG_SmartEdu-LMS/api/assignments"
+	quizHandlers "github.com/Ravikiran27/GOLANG_SmartEdu-LMS/api/quizzes"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// categoryItemTypes maps each gradebook category to the items it holds
+var categoryItemTypes = map[string]string{
+	"quizzes":     "quiz",
+	"assignments": "assignment",
+	"exams":       "exam",
+}
+
+// defaultGradebookPolicy is used until the teacher configures the course
+func defaultGradebookPolicy() models.GradebookPolicy {
+	return models.GradebookPolicy{
+		Categories: []models.GradeCategory{
+			{Type: "quizzes", Weight: 20},
+			{Type: "assignments", Weight: 30},
+			{Type: "exams", Weight: 50},
+		},
+		AttemptPolicy: "best",
+		LetterGrades: []models.LetterGrade{
+			{Letter: "A", MinPercentage: 90},
+			{Letter: "B", MinPercentage: 80},
+			{Letter: "C", MinPercentage: 70},
+			{Letter: "D", MinPercentage: 60},
+			{Letter: "F", MinPercentage: 0},
+		},
+	}
+}
+
+// coursePolicy returns the course's gradebook policy or the default
+func coursePolicy(course models.Course) models.GradebookPolicy {
+	if course.Gradebook == nil {
+		return defaultGradebookPolicy()
+	}
+	return *course.Gradebook
+}
+
+// normalizeGradebookPolicy validates a policy and puts it in canonical form:
+// letters highest first and the default attempt policy filled in
+func normalizeGradebookPolicy(policy *models.GradebookPolicy) error {
+	if len(policy.Categories) == 0 {
+		return errors.New("At least one category is required")
+	}
+	seen := make(map[string]bool)
+	total := 0.0
+	for _, c := range policy.Categories {
+		if _, ok := categoryItemTypes[c.Type]; !ok {
+			return errors.New("Category type must be quizzes, assignments or exams")
+		}
+		if seen[c.Type] {
+			return errors.New("Each category can only appear once")
+		}
+		seen[c.Type] = true
+		if c.Weight < 0 || c.DropLowest < 0 {
+			return errors.New("Weights and drop counts cannot be negative")
+		}
+		total += c.Weight
+	}
+	if math.Abs(total-100) > 0.01 {
+		return errors.New("Category weights must add up to 100")
+	}
+
+	if policy.AttemptPolicy == "" {
+		policy.AttemptPolicy = "best"
+	}
+	if !quizHandlers.ValidAttemptPolicy(policy.AttemptPolicy) {
+		return errors.New("Invalid attempt policy. Must be best, latest, or average")
+	}
+
+	if len(policy.LetterGrades) == 0 {
+		policy.LetterGrades = defaultGradebookPolicy().LetterGrades
+	}
+	sort.SliceStable(policy.LetterGrades, func(i, j int) bool {
+		return policy.LetterGrades[i].MinPercentage > policy.LetterGrades[j].MinPercentage
+	})
+	for i, l := range policy.LetterGrades {
+		if strings.TrimSpace(l.Letter) == "" || l.MinPercentage < 0 || l.MinPercentage > 100 {
+			return errors.New("Each letter grade needs a letter and a minimum between 0 and 100")
+		}
+		if i > 0 && l.MinPercentage == policy.LetterGrades[i-1].MinPercentage {
+			return errors.New("Letter grade minimums must be different")
+		}
+	}
+	if policy.LetterGrades[len(policy.LetterGrades)-1].MinPercentage != 0 {
+		return errors.New("The lowest letter grade must start at 0")
+	}
+	return nil
+}
+
+// result is one submission reduced to what the gradebook needs
+type result struct {
+	percentage float64
+	graded     bool // false while awaiting manual grading
+	attempt    int
+	at         time.Time
+}
+
+// gradedItem is a gradebook column with every student's results on it
+type gradedItem struct {
+	models.GradebookItem
+	quiz    *models.Quiz
+	closes  time.Time           // when unsubmitted work counts as missing; zero = never
+	results map[string][]result // by student
+}
+
+// courseItems loads the published quizzes, exams and assignments in the
+// policy's categories, with their completed submissions
+func courseItems(ctx context.Context, db store.Store, courseID string, policy models.GradebookPolicy) ([]gradedItem, error) {
+	included := make(map[string]bool)
+	for _, c := range policy.Categories {
+		included[categoryItemTypes[c.Type]] = true
+	}
+
+	items := make([]gradedItem, 0)
+	if included["quiz"] {
+		quizzes, err := db.Quizzes().List(ctx, store.QuizFilter{CourseID: courseID, PublishedOnly: true})
+		if err != nil {
+			return nil, err
+		}
+		for i := range quizzes {
+			quiz := quizzes[i]
+			submissions, err := db.Submissions().List(ctx, store.SubmissionFilter{
+				QuizID:   quiz.ID,
+				Statuses: []string{"submitted", "evaluated"},
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			item := gradedItem{
+				GradebookItem: models.GradebookItem{
+					ID:            quiz.ID,
+					Type:          "quiz",
+					Title:         quiz.Title,
+					TotalMarks:    quiz.TotalMarks,
+					AttemptPolicy: quiz.AttemptPolicy,
+				},
+				quiz:    &quiz,
+				results: make(map[string][]result),
+			}
+			if item.AttemptPolicy == "" {
+				item.AttemptPolicy = policy.AttemptPolicy
+			}
+			if closes := quizHandlers.ClosesAt(quiz, nil, ""); !closes.IsZero() {
+				item.DueAt = &closes
+			}
+			for _, sub := range submissions {
+				item.results[sub.StudentID] = append(item.results[sub.StudentID], result{
+					percentage: sub.Percentage,
+					graded:     sub.Status == "evaluated",
+					attempt:    sub.AttemptNumber,
+					at:         sub.SubmittedAt,
+				})
+			}
+			items = append(items, item)
+		}
+	}
+
+	if included["exam"] {
+		exams, err := db.Exams().List(ctx, store.ExamFilter{CourseID: courseID, PublishedOnly: true})
+		if err != nil {
+			return nil, err
+		}
+		for _, exam := range exams {
+			if exam.IsDeleted {
+				continue
+			}
+			submissions, err := db.ExamSubmissions().List(ctx, store.ExamSubmissionFilter{
+				ExamID:   exam.ExamID,
+				Statuses: []string{"submitted", "partially_evaluated", "evaluated"},
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			endTime := exam.EndTime
+			item := gradedItem{
+				GradebookItem: models.GradebookItem{
+					ID:         exam.ExamID,
+					Type:       "exam",
+					Title:      exam.Title,
+					TotalMarks: exam.TotalMarks,
+					DueAt:      &endTime,
+				},
+				closes:  endTime,
+				results: make(map[string][]result),
+			}
+			for _, sub := range submissions {
+				item.results[sub.StudentID] = append(item.results[sub.StudentID], result{
+					percentage: sub.Percentage,
+					graded:     sub.Status == "evaluated",
+					at:         sub.SubmittedAt,
+				})
+			}
+			items = append(items, item)
+		}
+	}
+
+	if included["assignment"] {
+		assignments, err := db.Assignments().List(ctx, store.AssignmentFilter{CourseID: courseID, PublishedOnly: true})
+		if err != nil {
+			return nil, err
+		}
+		for _, assignment := range assignments {
+			if assignment.IsDeleted {
+				continue
+			}
+			submissions, err := db.AssignmentSubmissions().List(ctx, store.AssignmentSubmissionFilter{
+				AssignmentID: assignment.AssignmentID,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			dueDate := assignment.DueDate
+			item := gradedItem{
+				GradebookItem: models.GradebookItem{
+					ID:         assignment.AssignmentID,
+					Type:       "assignment",
+					Title:      assignment.Title,
+					TotalMarks: assignment.TotalMarks,
+					DueAt:      &dueDate,
+				},
+				closes:  assignmentHandlers.LateSubmissionCloses(assignment),
+				results: make(map[string][]result),
+			}
+			for _, sub := range submissions {
+				// Late penalties are already taken off MarksAwarded
+				percentage := 0.0
+				if assignment.TotalMarks > 0 {
+					percentage = sub.MarksAwarded / assignment.TotalMarks * 100
+				}
+				item.results[sub.StudentID] = append(item.results[sub.StudentID], result{
+					percentage: percentage,
+					graded:     sub.Status == "evaluated",
+					at:         sub.SubmittedAt,
+				})
+			}
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+// classStudents returns the course's actively enrolled students, or just
+// studentID when it is set and enrolled
+func classStudents(ctx context.Context, db store.Store, courseID, studentID string) ([]string, error) {
+	enrollments, err := db.Enrollments().List(ctx, store.EnrollmentFilter{
+		CourseID:  courseID,
+		StudentID: studentID,
+		Status:    "active",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	studentIDs := make([]string, 0, len(enrollments))
+	for _, e := range enrollments {
+		studentIDs = append(studentIDs, e.StudentID)
+	}
+	return studentIDs, nil
+}
+
+// buildGradebook computes the gradebook and class summary for the given
+// students
+func buildGradebook(ctx context.Context, db store.Store, course models.Course, studentIDs []string) (*models.Gradebook, error) {
+	policy := coursePolicy(course)
+	items, err := courseItems(ctx, db, course.CourseID, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	students := make([]models.StudentGrade, 0, len(studentIDs))
+	for _, studentID := range studentIDs {
+		row := models.StudentGrade{StudentID: studentID}
+		if user, err := db.Users().Get(ctx, studentID); err == nil {
+			row.StudentName = user.DisplayName
+			row.Email = user.Email
+			row.RollNumber = user.Metadata.RollNumber
+		}
+
+		accommodation, err := store.StudentAccommodation(ctx, db, studentID, course.CourseID)
+		if err != nil {
+			return nil, err
+		}
+		students = append(students, gradeStudent(policy, items, row, accommodation, now))
+	}
+	sort.SliceStable(students, func(i, j int) bool {
+		if students[i].StudentName != students[j].StudentName {
+			return students[i].StudentName < students[j].StudentName
+		}
+		return students[i].StudentID < students[j].StudentID
+	})
+
+	gradebook := &models.Gradebook{
+		CourseID: course.CourseID,
+		Policy:   policy,
+		Items:    make([]models.GradebookItem, len(items)),
+		Students: students,
+		Class:    classSummary(policy, items, students),
+	}
+	for i, item := range items {
+		gradebook.Items[i] = item.GradebookItem
+	}
+	return gradebook, nil
+}
+
+// gradeStudent builds one student's gradebook row
+func gradeStudent(policy models.GradebookPolicy, items []gradedItem, student models.StudentGrade, accommodation *models.Accommodation, now time.Time) models.StudentGrade {
+	student.Entries = make([]models.GradeEntry, len(items))
+	for i, item := range items {
+		student.Entries[i] = gradeEntry(item, student.StudentID, accommodation, now)
+	}
+
+	weighted, totalWeight := 0.0, 0.0
+	for _, c := range policy.Categories {
+		category := gradeCategory(c, items, student.Entries)
+		student.Categories = append(student.Categories, category)
+		if category.Percentage != nil && c.Weight > 0 {
+			weighted += *category.Percentage * c.Weight
+			totalWeight += c.Weight
+		}
+	}
+
+	// Weights are rescaled over the categories that have grades so far
+	if totalWeight > 0 {
+		overall := round2(weighted / totalWeight)
+		student.Percentage = &overall
+		student.Letter = letterGrade(policy.LetterGrades, overall)
+	}
+	return student
+}
+
+// gradeEntry reduces a student's submissions on one item to a single result
+func gradeEntry(item gradedItem, studentID string, accommodation *models.Accommodation, now time.Time) models.GradeEntry {
+	entry := models.GradeEntry{ItemID: item.ID}
+	results := item.results[studentID]
+	entry.Attempts = len(results)
+
+	graded := make([]result, 0, len(results))
+	for _, r := range results {
+		if r.graded {
+			graded = append(graded, r)
+		}
+	}
+
+	switch {
+	case len(graded) > 0:
+		percentage := round2(combineAttempts(graded, item.AttemptPolicy))
+		entry.Status = "graded"
+		entry.Percentage = &percentage
+	case len(results) > 0:
+		entry.Status = "pending"
+	default:
+		// Nothing submitted: zero once the item has closed for this student,
+		// including any late submission period
+		due := item.closes
+		switch {
+		case item.quiz != nil:
+			due = quizHandlers.ClosesAt(*item.quiz, accommodation, studentID)
+		case item.Type == "exam":
+			_, due = utils.AccommodatedWindow(time.Time{}, due, accommodation)
+		}
+		if !due.IsZero() && now.After(due) {
+			zero := 0.0
+			entry.Status = "missing"
+			entry.Percentage = &zero
+		} else {
+			entry.Status = "upcoming"
+		}
+	}
+	return entry
+}
+
+// combineAttempts applies an attempt policy to graded results
+func combineAttempts(results []result, policy string) float64 {
+	switch policy {
+	case "latest":
+		latest := results[0]
+		for _, r := range results[1:] {
+			if r.attempt > latest.attempt || (r.attempt == latest.attempt && r.at.After(latest.at)) {
+				latest = r
+			}
+		}
+		return latest.percentage
+	case "average":
+		total := 0.0
+		for _, r := range results {
+			total += r.percentage
+		}
+		return total / float64(len(results))
+	default:
+		best := results[0].percentage
+		for _, r := range results[1:] {
+			best = math.Max(best, r.percentage)
+		}
+		return best
+	}
+}
+
+// gradeCategory averages a category's counted entries after dropping the
+// lowest ones, and marks the dropped entries
+func gradeCategory(c models.GradeCategory, items []gradedItem, entries []models.GradeEntry) models.CategoryGrade {
+	category := models.CategoryGrade{Type: c.Type, Weight: c.Weight}
+	itemType := categoryItemTypes[c.Type]
+
+	counted := make([]int, 0)
+	for i, item := range items {
+		if item.Type == itemType && entries[i].Percentage != nil {
+			counted = append(counted, i)
+		}
+	}
+	if len(counted) == 0 {
+		return category
+	}
+
+	sort.SliceStable(counted, func(a, b int) bool {
+		return *entries[counted[a]].Percentage < *entries[counted[b]].Percentage
+	})
+	drop := c.DropLowest
+	if drop > len(counted)-1 {
+		drop = len(counted) - 1
+	}
+	for _, i := range counted[:drop] {
+		entries[i].Dropped = true
+	}
+
+	total := 0.0
+	for _, i := range counted[drop:] {
+		total += *entries[i].Percentage
+	}
+	average := round2(total / float64(len(counted)-drop))
+	category.Percentage = &average
+	category.Counted = len(counted) - drop
+	category.Dropped = drop
+	return category
+}
+
+// letterGrade returns the letter for a percentage; letters are highest first
+func letterGrade(letters []models.LetterGrade, percentage float64) string {
+	for _, l := range letters {
+		if percentage >= l.MinPercentage {
+			return l.Letter
+		}
+	}
+	return ""
+}
+
+// classSummary describes the class from every student's row
+func classSummary(policy models.GradebookPolicy, items []gradedItem, students []models.StudentGrade) *models.ClassSummary {
+	summary := &models.ClassSummary{
+		Students:           len(students),
+		LetterDistribution: make(map[string]int),
+		Items:              make([]models.ItemSummary, len(items)),
+	}
+	for _, l := range policy.LetterGrades {
+		summary.LetterDistribution[l.Letter] = 0
+	}
+
+	overall := make([]float64, 0, len(students))
+	for _, s := range students {
+		if s.Percentage != nil {
+			overall = append(overall, *s.Percentage)
+			summary.LetterDistribution[s.Letter]++
+		}
+	}
+	summary.AveragePercentage = average(overall)
+	if len(overall) > 0 {
+		sort.Float64s(overall)
+		median := overall[len(overall)/2]
+		if len(overall)%2 == 0 {
+			median = (overall[len(overall)/2-1] + median) / 2
+		}
+		median = round2(median)
+		summary.MedianPercentage = &median
+	}
+
+	for i, item := range items {
+		itemSummary := models.ItemSummary{ItemID: item.ID}
+		scores := make([]float64, 0)
+		for _, s := range students {
+			entry := s.Entries[i]
+			switch entry.Status {
+			case "graded":
+				itemSummary.Graded++
+				scores = append(scores, *entry.Percentage)
+			case "missing":
+				itemSummary.Missing++
+			case "pending":
+				itemSummary.Pending++
+			}
+		}
+		itemSummary.AveragePercentage = average(scores)
+		summary.Items[i] = itemSummary
+	}
+	return summary
+}
+
+// average returns the rounded mean, or nil for no values
+func average(values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	mean := round2(total / float64(len(values)))
+	return &mean
+}
+
+// round2 rounds to two decimal places for display
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	assignmentHandlers "github.com/Ravikiran27/GOLANG_SmartEdu-LMS/api/assignments"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+)
+
+func percentages(values ...float64) []models.GradeEntry {
+	entries := make([]models.GradeEntry, len(values))
+	for i := range values {
+		entries[i].Percentage = &values[i]
+	}
+	return entries
+}
+
+func TestGradeCategory(t *testing.T) {
+	quizzes := func(n int) []gradedItem {
+		items := make([]gradedItem, n)
+		for i := range items {
+			items[i].Type = "quiz"
+		}
+		return items
+	}
+
+	tests := []struct {
+		name        string
+		category    models.GradeCategory
+		items       []gradedItem
+		entries     []models.GradeEntry
+		want        float64
+		wantCounted int
+		wantDropped int
+	}{
+		{"average", models.GradeCategory{Type: "quizzes"}, quizzes(3), percentages(60, 80, 100), 80, 3, 0},
+		{"drop lowest", models.GradeCategory{Type: "quizzes", DropLowest: 1}, quizzes(3), percentages(90, 40, 70), 80, 2, 1},
+		{"always keeps one", models.GradeCategory{Type: "quizzes", DropLowest: 5}, quizzes(2), percentages(50, 90), 90, 1, 1},
+		{"rounded to two places", models.GradeCategory{Type: "quizzes"}, quizzes(3), percentages(100, 100, 0), 66.67, 3, 0},
+		{"other categories ignored", models.GradeCategory{Type: "quizzes"},
+			[]gradedItem{{GradebookItem: models.GradebookItem{Type: "quiz"}}, {GradebookItem: models.GradebookItem{Type: "exam"}}},
+			percentages(70, 10), 70, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := gradeCategory(tt.category, tt.items, tt.entries)
+			if got.Percentage == nil || *got.Percentage != tt.want || got.Counted != tt.wantCounted || got.Dropped != tt.wantDropped {
+				t.Errorf("got %v counted=%d dropped=%d, want %v %d %d", got.Percentage, got.Counted, got.Dropped, tt.want, tt.wantCounted, tt.wantDropped)
+			}
+		})
+	}
+
+	// Ungraded entries are left out, and a category with none has no grade
+	entries := []models.GradeEntry{{}, {}}
+	if got := gradeCategory(models.GradeCategory{Type: "quizzes"}, quizzes(2), entries); got.Percentage != nil {
+		t.Errorf("empty category = %v, want nil", *got.Percentage)
+	}
+}
+
+func TestGradeCategoryMarksDropped(t *testing.T) {
+	items := []gradedItem{{GradebookItem: models.GradebookItem{Type: "exam"}}, {GradebookItem: models.GradebookItem{Type: "exam"}}}
+	entries := percentages(95, 35)
+	gradeCategory(models.GradeCategory{Type: "exams", DropLowest: 1}, items, entries)
+	if entries[0].Dropped || !entries[1].Dropped {
+		t.Errorf("dropped = %v, %v; want the 35 dropped", entries[0].Dropped, entries[1].Dropped)
+	}
+}
+
+func TestCombineAttempts(t *testing.T) {
+	now := time.Now()
+	results := []result{
+		{percentage: 70, attempt: 1, at: now},
+		{percentage: 90, attempt: 2, at: now.Add(time.Hour)},
+		{percentage: 50, attempt: 3, at: now.Add(2 * time.Hour)},
+	}
+
+	tests := []struct {
+		policy string
+		want   float64
+	}{
+		{"best", 90},
+		{"latest", 50},
+		{"average", 70},
+		{"", 90},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			if got := combineAttempts(results, tt.policy); got != tt.want {
+				t.Errorf("combineAttempts(%q) = %v, want %v", tt.policy, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLetterGrade(t *testing.T) {
+	letters := defaultGradebookPolicy().LetterGrades
+
+	tests := []struct {
+		percentage float64
+		want       string
+	}{
+		{100, "A"},
+		{90, "A"},
+		{89.99, "B"},
+		{80, "B"},
+		{70, "C"},
+		{60, "D"},
+		{59.99, "F"},
+		{0, "F"},
+	}
+
+	for _, tt := range tests {
+		if got := letterGrade(letters, tt.percentage); got != tt.want {
+			t.Errorf("letterGrade(%v) = %q, want %q", tt.percentage, got, tt.want)
+		}
+	}
+}
+
+func TestGradeEntryLateSubmission(t *testing.T) {
+	now := time.Now()
+	assignment := func(allowLate bool, penalty float64, due time.Duration) gradedItem {
+		dueDate := now.Add(-due)
+		a := models.Assignment{DueDate: dueDate, AllowLateSubmission: allowLate, LatePenalty: penalty}
+		return gradedItem{
+			GradebookItem: models.GradebookItem{Type: "assignment", DueAt: &dueDate},
+			closes:        assignmentHandlers.LateSubmissionCloses(a),
+		}
+	}
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name string
+		item gradedItem
+		want string
+	}{
+		{"not yet due", assignment(false, 0, -day), "upcoming"},
+		{"overdue, no late work", assignment(false, 0, day), "missing"},
+		{"late work still earns marks", assignment(true, 10, 3*day), "upcoming"},
+		{"late penalty reached 100%", assignment(true, 10, 10*day), "missing"},
+		{"late work without a penalty", assignment(true, 0, 30*day), "upcoming"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gradeEntry(tt.item, "student", nil, now); got.Status != tt.want {
+				t.Errorf("status = %q, want %q", got.Status, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// ListJoinCodes lists a course's join codes, newest first (Teacher/Admin only)
+func ListJoinCodes(w http.ResponseWriter, r *http.Request) {
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	utils.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		ctx := r.Context()
+		uid, _, role := utils.GetUserFromContext(ctx)
+
+		courseID := r.URL.Query().Get("courseId")
+		if courseID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Course ID is required")
+			return
+		}
+
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
+			return
+		}
+
+		course, err := db.Courses().Get(ctx, courseID)
+		if err != nil || course.IsDeleted {
+			utils.RespondError(w, http.StatusNotFound, "Course not found")
+			return
+		}
+		if role == "teacher" && course.TeacherID != uid {
+			utils.RespondError(w, http.StatusForbidden, "You can only manage your own courses")
+			return
+		}
+
+		joinCodes, err := db.JoinCodes().List(ctx, courseID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch join codes")
+			return
+		}
+
+		utils.RespondSuccess(w, joinCodes)
+	}, "teacher", "admin")(w, r)
+}
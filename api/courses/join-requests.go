@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// ListJoinRequests lists a course's enrollment requests, pending ones unless
+// status is given, oldest first. Students see only their own requests and
+// may leave out courseId.
+func ListJoinRequests(w http.ResponseWriter, r *http.Request) {
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	utils.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		ctx := r.Context()
+		uid, _, role := utils.GetUserFromContext(ctx)
+
+		filter := store.JoinRequestFilter{
+			CourseID: r.URL.Query().Get("courseId"),
+			Status:   r.URL.Query().Get("status"),
+		}
+		switch filter.Status {
+		case "":
+			filter.Status = "pending"
+		case "all":
+			filter.Status = ""
+		case "pending", "approved", "rejected":
+		default:
+			utils.RespondError(w, http.StatusBadRequest, "Invalid status")
+			return
+		}
+		if role == "student" {
+			filter.StudentID = uid
+		} else if filter.CourseID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Course ID is required")
+			return
+		}
+
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
+			return
+		}
+
+		if role == "teacher" {
+			course, err := db.Courses().Get(ctx, filter.CourseID)
+			if err != nil || course.IsDeleted {
+				utils.RespondError(w, http.StatusNotFound, "Course not found")
+				return
+			}
+			if course.TeacherID != uid {
+				utils.RespondError(w, http.StatusForbidden, "You can only manage your own courses")
+				return
+			}
+		}
+
+		requests, err := db.JoinRequests().List(ctx, filter)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch requests")
+			return
+		}
+
+		utils.RespondSuccess(w, requests)
+	}, "student", "teacher", "admin")(w, r)
+}
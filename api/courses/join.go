@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// JoinCourse enrolls a student using only a join code, as carried by a join
+// link; the code names the course
+func JoinCourse(w http.ResponseWriter, r *http.Request) {
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	utils.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		ctx := r.Context()
+		uid, _, _ := utils.GetUserFromContext(ctx)
+
+		var req struct {
+			Code string `json:"code"`
+		}
+		if err := utils.ParseJSONBody(r, &req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		code := strings.ToUpper(strings.TrimSpace(req.Code))
+		if code == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Code is required")
+			return
+		}
+
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
+			return
+		}
+
+		joinCode, err := db.JoinCodes().Get(ctx, code)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusForbidden, "Join code is invalid or has expired")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch join code")
+			return
+		}
+
+		enrollStudent(ctx, w, db, uid, models.EnrollmentRequest{
+			CourseID: joinCode.CourseID,
+			JoinCode: code,
+		})
+	}, "student")(w, r)
+}
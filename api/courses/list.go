@@ -1,12 +1,9 @@
 package handler
 
 import (
-	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
 	"net/http"
-
-	"cloud.google.com/go/firestore"
-	"google.golang.org/api/iterator"
 )
 
 // ListCourses retrieves all courses (filtered by role)
@@ -25,57 +22,43 @@ func ListCourses(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		uid, _, role := utils.GetUserFromContext(ctx)
 
-		firestoreClient, err := utils.GetFirestoreClient(ctx)
+		db, err := store.GetStore(ctx)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
 			return
 		}
 
-		var query firestore.Query
-		coursesRef := firestoreClient.Collection("courses")
+		var filter store.CourseFilter
 
 		// Filter based on role
 		switch role {
 		case "student":
 			// Students see only published courses
-			query = coursesRef.Where("isPublished", "==", true).Where("isDeleted", "==", false)
+			filter.PublishedOnly = true
 		case "teacher":
 			// Teachers see their own courses + published courses
 			teacherParam := r.URL.Query().Get("teacher")
 			if teacherParam == "me" {
-				query = coursesRef.Where("teacherId", "==", uid).Where("isDeleted", "==", false)
+				filter.TeacherID = uid
 			} else {
-				query = coursesRef.Where("isPublished", "==", true).Where("isDeleted", "==", false)
+				filter.PublishedOnly = true
 			}
 		case "admin":
 			// Admins see all courses
-			query = coursesRef.Where("isDeleted", "==", false)
 		default:
-			query = coursesRef.Where("isPublished", "==", true).Where("isDeleted", "==", false)
+			filter.PublishedOnly = true
 		}
 
 		// Pagination
 		page, pageSize := utils.GetPaginationParams(r)
-		query = query.OrderBy("createdAt", firestore.Desc).Limit(pageSize).Offset((page - 1) * pageSize)
+		filter.Limit = pageSize
+		filter.Offset = (page - 1) * pageSize
 
 		// Execute query
-		iter := query.Documents(ctx)
-		defer iter.Stop()
-
-		var courses []models.Course
-		for {
-			doc, err := iter.Next()
-			if err == iterator.Done {
-				break
-			}
-			if err != nil {
-				utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch courses")
-				return
-			}
-
-			var course models.Course
-			doc.DataTo(&course)
-			courses = append(courses, course)
+		courses, err := db.Courses().List(ctx, filter)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch courses")
+			return
 		}
 
 		utils.RespondSuccess(w, map[string]interface{}{
@@ -1,12 +1,9 @@
 package handler
 
 import (
-	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
 	"net/http"
-
-	"cloud.google.com/go/firestore"
-	"google.golang.org/api/iterator"
 )
 
 // GetMyEnrollments retrieves student's enrollments
@@ -25,33 +22,17 @@ func GetMyEnrollments(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		uid, _, _ := utils.GetUserFromContext(ctx)
 
-		firestoreClient, err := utils.GetFirestoreClient(ctx)
+		db, err := store.GetStore(ctx)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
 			return
 		}
 
 		// Get enrollments
-		iter := firestoreClient.Collection("enrollments").
-			Where("studentId", "==", uid).
-			OrderBy("enrolledAt", firestore.Desc).
-			Documents(ctx)
-		defer iter.Stop()
-
-		var enrollments []models.Enrollment
-		for {
-			doc, err := iter.Next()
-			if err == iterator.Done {
-				break
-			}
-			if err != nil {
-				utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch enrollments")
-				return
-			}
-
-			var enrollment models.Enrollment
-			doc.DataTo(&enrollment)
-			enrollments = append(enrollments, enrollment)
+		enrollments, err := db.Enrollments().List(ctx, store.EnrollmentFilter{StudentID: uid})
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch enrollments")
+			return
 		}
 
 		utils.RespondSuccess(w, enrollments)
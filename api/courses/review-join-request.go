@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// ReviewJoinRequest approves or rejects a pending enrollment request
+// (Teacher/Admin only). Approval enrolls the student; either way the student
+// is notified.
+func ReviewJoinRequest(w http.ResponseWriter, r *http.Request) {
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	utils.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		ctx := r.Context()
+		uid, _, role := utils.GetUserFromContext(ctx)
+
+		var req models.ReviewJoinRequest
+		if err := utils.ParseJSONBody(r, &req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if req.CourseID == "" || req.StudentID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Course ID and student ID are required")
+			return
+		}
+
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
+			return
+		}
+
+		course, err := db.Courses().Get(ctx, req.CourseID)
+		if err != nil || course.IsDeleted {
+			utils.RespondError(w, http.StatusNotFound, "Course not found")
+			return
+		}
+		if role == "teacher" && course.TeacherID != uid {
+			utils.RespondError(w, http.StatusForbidden, "You can only manage your own courses")
+			return
+		}
+
+		request, err := db.JoinRequests().Get(ctx, req.CourseID, req.StudentID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Request not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch request")
+			return
+		}
+		if request.Status != "pending" {
+			utils.RespondError(w, http.StatusConflict, "Request has already been reviewed")
+			return
+		}
+
+		now := utils.GetCurrentTimestamp()
+		request.Status = "rejected"
+		var enrollment *models.Enrollment
+		if req.Approve {
+			request.Status = "approved"
+			student := &models.User{UID: request.StudentID, DisplayName: request.StudentName}
+			approved := newEnrollment(course, student, now)
+			enrollment = &approved
+		}
+		request.ReviewedBy = uid
+		request.ReviewNote = req.Note
+		request.ReviewedAt = &now
+
+		// Record the review and the enrollment together, so a second reviewer
+		// or a join code used meanwhile can't enroll the student twice
+		if _, err := db.JoinRequests().Review(ctx, request, enrollment); err != nil {
+			if err == store.ErrRequestReviewed {
+				utils.RespondError(w, http.StatusConflict, "Request has already been reviewed")
+				return
+			}
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to update request")
+			return
+		}
+
+		title := "Enrollment request declined"
+		message := "Your request to join " + course.Title + " was not approved."
+		if req.Approve {
+			title = "Enrollment request approved"
+			message = "Your request to join " + course.Title + " was approved. You are now enrolled."
+		}
+		if req.Note != "" {
+			message += " " + req.Note
+		}
+		db.Notifications().Create(ctx, &models.Notification{
+			UserID:        request.StudentID,
+			Type:          "enrollment_request",
+			Title:         title,
+			Message:       message,
+			ReferenceID:   course.CourseID,
+			ReferenceType: "course",
+			CreatedAt:     now,
+		})
+
+		utils.RespondSuccess(w, request, "Request "+request.Status)
+	}, "teacher", "admin")(w, r)
+}
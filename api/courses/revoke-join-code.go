@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// RevokeJoinCode stops a join code from being used (Teacher/Admin only)
+func RevokeJoinCode(w http.ResponseWriter, r *http.Request) {
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	utils.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		ctx := r.Context()
+		uid, _, role := utils.GetUserFromContext(ctx)
+
+		var req struct {
+			Code string `json:"code"`
+		}
+		if err := utils.ParseJSONBody(r, &req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if req.Code == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Code is required")
+			return
+		}
+
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
+			return
+		}
+
+		joinCode, err := db.JoinCodes().Get(ctx, req.Code)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Join code not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch join code")
+			return
+		}
+
+		if role == "teacher" {
+			course, err := db.Courses().Get(ctx, joinCode.CourseID)
+			if err != nil || course.TeacherID != uid {
+				utils.RespondError(w, http.StatusForbidden, "You can only manage your own courses")
+				return
+			}
+		}
+
+		joinCode.Revoked = true
+		if err := db.JoinCodes().Save(ctx, joinCode); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to revoke join code")
+			return
+		}
+
+		utils.RespondSuccess(w, joinCode, "Join code revoked")
+	}, "teacher", "admin")(w, r)
+}
@@ -2,10 +2,9 @@ package handler
 
 import (
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
 	"net/http"
-
-	"cloud.google.com/go/firestore"
 )
 
 // UpdateCourse updates an existing course (Teacher/Admin only)
@@ -37,71 +36,67 @@ func UpdateCourse(w http.ResponseWriter, r *http.Request) {
 			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
 			return
 		}
+		if !validEnrollmentMode(req.EnrollmentMode) {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid enrollment mode. Must be open, invite_code, approval_required or closed")
+			return
+		}
 
-		firestoreClient, err := utils.GetFirestoreClient(ctx)
+		db, err := store.GetStore(ctx)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize firestore")
 			return
 		}
 
 		// Get existing course
-		doc, err := firestoreClient.Collection("courses").Doc(courseID).Get(ctx)
+		course, err := db.Courses().Get(ctx, courseID)
 		if err != nil {
 			utils.RespondError(w, http.StatusNotFound, "Course not found")
 			return
 		}
 
-		var course models.Course
-		doc.DataTo(&course)
-
 		// Authorization check: teacher can only update own courses
 		if role == "teacher" && course.TeacherID != uid {
 			utils.RespondError(w, http.StatusForbidden, "You can only update your own courses")
 			return
 		}
 
-		// Build updates
-		updates := []firestore.Update{
-			{Path: "updatedAt", Value: utils.GetCurrentTimestamp()},
-		}
+		// Apply updates
+		course.UpdatedAt = utils.GetCurrentTimestamp()
 
 		if req.Title != "" {
-			updates = append(updates, firestore.Update{Path: "title", Value: req.Title})
+			course.Title = req.Title
 		}
 		if req.Description != "" {
-			updates = append(updates, firestore.Update{Path: "description", Value: req.Description})
+			course.Description = req.Description
 		}
 		if req.Syllabus != "" {
-			updates = append(updates, firestore.Update{Path: "syllabus", Value: req.Syllabus})
+			course.Syllabus = req.Syllabus
 		}
 		if req.Category != "" {
-			updates = append(updates, firestore.Update{Path: "category", Value: req.Category})
+			course.Category = req.Category
 		}
 		if req.Difficulty != "" {
-			updates = append(updates, firestore.Update{Path: "difficulty", Value: req.Difficulty})
+			course.Difficulty = req.Difficulty
 		}
 		if req.Thumbnail != "" {
-			updates = append(updates, firestore.Update{Path: "thumbnail", Value: req.Thumbnail})
+			course.Thumbnail = req.Thumbnail
 		}
 		if req.Materials != nil {
-			updates = append(updates, firestore.Update{Path: "materials", Value: req.Materials})
+			course.Materials = req.Materials
 		}
 		if req.IsPublished {
-			updates = append(updates, firestore.Update{Path: "isPublished", Value: req.IsPublished})
+			course.IsPublished = req.IsPublished
+		}
+		if req.EnrollmentMode != "" {
+			course.EnrollmentMode = req.EnrollmentMode
 		}
 
 		// Update document
-		_, err = firestoreClient.Collection("courses").Doc(courseID).Update(ctx, updates)
-		if err != nil {
+		if err := db.Courses().Save(ctx, course); err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to update course")
 			return
 		}
 
-		// Fetch updated course
-		updatedDoc, _ := firestoreClient.Collection("courses").Doc(courseID).Get(ctx)
-		var updatedCourse models.Course
-		updatedDoc.DataTo(&updatedCourse)
-
-		utils.RespondSuccess(w, updatedCourse, "Course updated successfully")
+		utils.RespondSuccess(w, course, "Course updated successfully")
 	}, "teacher", "admin")(w, r)
 }
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	examHandlers "github.com/Ravikiran27/GOLANG_SmartEdu-LMS/api/exams"
+)
+
+// Handler routes all exam-related requests
+func ExamsRouter(w http.ResponseWriter, r *http.Request) {
+	// Extract the path after /api/exams/
+	path := strings.TrimPrefix(r.URL.Path, "/api/exams/")
+	path = strings.TrimPrefix(path, "exams/") // Handle both /api/exams and /api/exams/exams
+
+	// Route to appropriate handler based on path
+	switch path {
+	case "create":
+		examHandlers.CreateExam(w, r)
+	case "list":
+		examHandlers.ListExams(w, r)
+	case "get":
+		examHandlers.GetExam(w, r)
+	case "add-question":
+		examHandlers.AddQuestion(w, r)
+	case "start":
+		examHandlers.StartExam(w, r)
+	case "save-progress":
+		examHandlers.SaveProgress(w, r)
+	case "submit":
+		examHandlers.SubmitExam(w, r)
+	case "evaluate":
+		examHandlers.EvaluateExam(w, r)
+	case "results":
+		examHandlers.GetResults(w, r)
+	case "sweep-expired":
+		examHandlers.SweepExpired(w, r)
+	default:
+		http.Error(w, "Not Found", http.StatusNotFound)
+	}
+}
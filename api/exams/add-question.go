@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler adds a question to an exam (teacher/admin only)
+func AddQuestion(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate and authorize (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _, role := utils.GetUserFromContext(ctx)
+
+		// Parse request body
+		var req models.CreateQuestionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		// Validate required fields
+		if req.ExamID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Exam ID is required")
+			return
+		}
+		if req.QuestionText == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Question text is required")
+			return
+		}
+		if req.Marks <= 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Marks must be greater than 0")
+			return
+		}
+
+		// Validate question type
+		validTypes := map[string]bool{
+			"mcq":          true,
+			"true_false":   true,
+			"short_answer": true,
+			"descriptive":  true,
+		}
+		if !validTypes[req.Type] {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid question type")
+			return
+		}
+
+		// Validate MCQ and True/False questions
+		if utils.IsObjectiveQuestion(req.Type) {
+			if len(req.Options) == 0 {
+				utils.RespondError(w, http.StatusBadRequest, "Options are required for MCQ and True/False questions")
+				return
+			}
+
+			hasCorrect := false
+			for _, opt := range req.Options {
+				if opt.IsCorrect {
+					hasCorrect = true
+					break
+				}
+			}
+			if !hasCorrect {
+				utils.RespondError(w, http.StatusBadRequest, "At least one option must be marked as correct")
+				return
+			}
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		// Verify exam exists and user is the teacher or admin
+		exam, err := db.Exams().Get(ctx, req.ExamID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Exam not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse exam data")
+			return
+		}
+
+		if exam.IsDeleted {
+			utils.RespondError(w, http.StatusNotFound, "Exam not found")
+			return
+		}
+
+		if role != "admin" && exam.TeacherID != userID {
+			utils.RespondError(w, http.StatusForbidden, "You can only add questions to your own exams")
+			return
+		}
+
+		// The paper is frozen once students may have seen it
+		now := time.Now()
+		if exam.IsPublished && now.After(exam.StartTime) {
+			utils.RespondError(w, http.StatusBadRequest, "Cannot add questions after the exam has started")
+			return
+		}
+
+		// Create question object
+		question := models.Question{
+			ExamID:        req.ExamID,
+			Type:          req.Type,
+			Text:          req.QuestionText,
+			QuestionText:  req.QuestionText,
+			ImageURL:      req.ImageURL,
+			Marks:         req.Marks,
+			Points:        req.Marks,
+			Options:       req.Options,
+			CorrectAnswer: req.CorrectAnswer,
+			Explanation:   req.Explanation,
+			Order:         exam.QuestionsCount + 1,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+
+		// Save question
+		if err := db.Questions().Create(ctx, &question); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to add question")
+			return
+		}
+
+		// Update exam question count
+		if err := db.Exams().AddQuestions(ctx, req.ExamID, 1); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to update exam")
+			return
+		}
+
+		utils.RespondSuccess(w, map[string]interface{}{
+			"question": question,
+		}, "Question added successfully")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler creates a new exam (teacher/admin only)
+func CreateExam(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate and authorize (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _, role := utils.GetUserFromContext(ctx)
+
+		// Parse request body
+		var req models.CreateExamRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		// Validate required fields
+		if req.Title == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Title is required")
+			return
+		}
+		if req.CourseID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Course ID is required")
+			return
+		}
+		if req.Duration <= 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Duration must be greater than 0")
+			return
+		}
+		if req.TotalMarks <= 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Total marks must be greater than 0")
+			return
+		}
+		if req.PassingMarks < 0 || req.PassingMarks > req.TotalMarks {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid passing marks")
+			return
+		}
+		if req.NegativeMarking && req.NegativeMarkValue <= 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Negative mark value must be greater than 0")
+			return
+		}
+
+		// Validate exam type
+		validTypes := map[string]bool{
+			"midterm":  true,
+			"final":    true,
+			"practice": true,
+		}
+		if !validTypes[req.ExamType] {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid exam type. Must be midterm, final, or practice")
+			return
+		}
+
+		// Validate the scheduled window
+		if req.StartTime.IsZero() || req.EndTime.IsZero() {
+			utils.RespondError(w, http.StatusBadRequest, "Start time and end time are required")
+			return
+		}
+		if !req.EndTime.After(req.StartTime) {
+			utils.RespondError(w, http.StatusBadRequest, "End time must be after start time")
+			return
+		}
+		if req.EndTime.Before(time.Now()) {
+			utils.RespondError(w, http.StatusBadRequest, "End time must be in the future")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		// Verify course exists and user is the teacher or admin
+		course, err := db.Courses().Get(ctx, req.CourseID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Course not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse course data")
+			return
+		}
+
+		if role != "admin" && course.TeacherID != userID {
+			utils.RespondError(w, http.StatusForbidden, "You can only create exams for your own courses")
+			return
+		}
+
+		// Create exam object
+		now := time.Now()
+		exam := models.Exam{
+			CourseID:                 req.CourseID,
+			CourseTitle:              course.Title,
+			TeacherID:                userID,
+			Title:                    req.Title,
+			Description:              req.Description,
+			Duration:                 req.Duration,
+			TotalMarks:               req.TotalMarks,
+			PassingMarks:             req.PassingMarks,
+			NegativeMarking:          req.NegativeMarking,
+			NegativeMarkValue:        req.NegativeMarkValue,
+			QuestionsCount:           0,
+			RandomizeQuestions:       req.RandomizeQuestions,
+			ExamType:                 req.ExamType,
+			StartTime:                req.StartTime,
+			EndTime:                  req.EndTime,
+			Instructions:             req.Instructions,
+			IsPublished:              req.IsPublished,
+			RequiresManualEvaluation: req.RequiresManualEvaluation,
+			CreatedAt:                now,
+			UpdatedAt:                now,
+		}
+
+		// Save exam
+		if err := db.Exams().Create(ctx, &exam); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create exam")
+			return
+		}
+
+		utils.RespondCreated(w, exam, "Exam created successfully")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
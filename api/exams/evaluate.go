@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler records manual evaluation of an exam submission (teacher/admin only)
+func EvaluateExam(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate and authorize (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _, role := utils.GetUserFromContext(ctx)
+
+		// Parse request body
+		var req models.EvaluateExamRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.SubmissionID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Submission ID is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		// Get submission
+		submission, err := db.ExamSubmissions().Get(ctx, req.SubmissionID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Submission not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse submission data")
+			return
+		}
+
+		if submission.Status == "in_progress" {
+			utils.RespondError(w, http.StatusBadRequest, "Exam has not been submitted yet")
+			return
+		}
+
+		// Get exam and verify ownership
+		exam, err := db.Exams().Get(ctx, submission.ExamID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Exam not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse exam data")
+			return
+		}
+
+		if role != "admin" && exam.TeacherID != userID {
+			utils.RespondError(w, http.StatusForbidden, "You can only evaluate submissions for your own exams")
+			return
+		}
+
+		// Get questions to validate awarded marks
+		questionList, err := db.Questions().ListByExam(ctx, submission.ExamID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch questions")
+			return
+		}
+
+		questions := make(map[string]models.Question)
+		for _, q := range questionList {
+			questions[q.ID] = q
+		}
+
+		answerIndex := make(map[string]int)
+		for i, answer := range submission.Answers {
+			answerIndex[answer.QuestionID] = i
+		}
+
+		// Apply evaluations
+		for _, evaluation := range req.Evaluations {
+			i, ok := answerIndex[evaluation.QuestionID]
+			if !ok {
+				utils.RespondError(w, http.StatusBadRequest, fmt.Sprintf("No answer found for question %s", evaluation.QuestionID))
+				return
+			}
+
+			question := questions[evaluation.QuestionID]
+			if evaluation.MarksAwarded < 0 || evaluation.MarksAwarded > question.Marks {
+				utils.RespondError(w, http.StatusBadRequest, fmt.Sprintf("Marks for question %s must be between 0 and %g", evaluation.QuestionID, question.Marks))
+				return
+			}
+
+			submission.Answers[i].MarksAwarded = evaluation.MarksAwarded
+			submission.Answers[i].TeacherFeedback = evaluation.TeacherFeedback
+			submission.Answers[i].IsEvaluated = true
+		}
+
+		if req.TeacherComments != "" {
+			submission.TeacherComments = req.TeacherComments
+		}
+
+		now := time.Now()
+		recalculateSubmission(submission, *exam, true)
+		submission.EvaluatedBy = userID
+		if submission.Status == "evaluated" {
+			submission.EvaluatedAt = &now
+		}
+
+		if err := db.ExamSubmissions().Save(ctx, submission); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to save evaluation")
+			return
+		}
+
+		// Notify the student once the result is final
+		if submission.Status == "evaluated" {
+			notification := models.Notification{
+				UserID:        submission.StudentID,
+				Type:          "grade_released",
+				Title:         "Exam Evaluated",
+				Message:       "Your result is available for: " + exam.Title,
+				ReferenceID:   submission.ExamID,
+				ReferenceType: "exam",
+				IsRead:        false,
+				CreatedAt:     now,
+			}
+			db.Notifications().Create(ctx, &notification)
+		}
+
+		utils.RespondSuccess(w, submission, "Evaluation saved successfully")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
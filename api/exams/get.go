@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler gets a single exam by ID
+func GetExam(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow GET
+	if r.Method != http.MethodGet {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _, role := utils.GetUserFromContext(ctx)
+
+		// Get exam ID from query
+		examID := r.URL.Query().Get("id")
+		if examID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Exam ID is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		// Get exam
+		exam, err := db.Exams().Get(ctx, examID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Exam not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse exam data")
+			return
+		}
+
+		if exam.IsDeleted {
+			utils.RespondError(w, http.StatusNotFound, "Exam not found")
+			return
+		}
+
+		// Authorization checks
+		if role == "student" {
+			// Students can only see published exams
+			if !exam.IsPublished {
+				utils.RespondError(w, http.StatusForbidden, "This exam is not published")
+				return
+			}
+
+			// Verify student is enrolled in the course
+			enrollments, err := db.Enrollments().List(ctx, store.EnrollmentFilter{
+				StudentID: userID,
+				CourseID:  exam.CourseID,
+				Status:    "active",
+			})
+			if err != nil || len(enrollments) == 0 {
+				utils.RespondError(w, http.StatusForbidden, "You must be enrolled in this course")
+				return
+			}
+		} else if role == "teacher" {
+			// Teachers can only see their own exams
+			if exam.TeacherID != userID {
+				utils.RespondError(w, http.StatusForbidden, "You can only view your own exams")
+				return
+			}
+		}
+		// Admins can see all exams
+
+		utils.RespondSuccess(w, exam, "Exam fetched successfully")
+	})).ServeHTTP(w, r)
+}
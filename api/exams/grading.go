@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Reasons the server closed an attempt after its deadline
+const (
+	submitReasonTimedOut      = "timed_out"      // the student submitted too late
+	submitReasonAutoSubmitted = "auto_submitted" // the student never submitted
+)
+
+// withAccommodation moves exam's window to the student's alternative window,
+// if their accommodation sets one
+func withAccommodation(exam models.Exam, accommodation *models.Accommodation) models.Exam {
+	exam.StartTime, exam.EndTime = utils.AccommodatedWindow(exam.StartTime, exam.EndTime, accommodation)
+	return exam
+}
+
+// examDeadline returns the moment an attempt started at startedAt, with its
+// duration stretched by multiplier, must be submitted by
+func examDeadline(exam models.Exam, startedAt time.Time, multiplier float64) time.Time {
+	deadline := startedAt.Add(utils.ScaleDuration(time.Duration(exam.Duration)*time.Minute, multiplier))
+	if deadline.After(exam.EndTime) {
+		deadline = exam.EndTime
+	}
+	return deadline
+}
+
+// attemptExpired reports whether an attempt's deadline, plus the grace period,
+// has passed
+func attemptExpired(exam models.Exam, submission models.ExamSubmission, now time.Time) bool {
+	return now.After(examDeadline(exam, submission.StartedAt, submission.TimeMultiplier).Add(utils.GracePeriod()))
+}
+
+// closeExpiredAttempt grades an attempt that ran past its deadline with the
+// answers it already holds; answers sent after the deadline are never added
+func closeExpiredAttempt(submission *models.ExamSubmission, exam models.Exam, questions map[string]models.Question, reason string, now time.Time) {
+	deadline := examDeadline(exam, submission.StartedAt, submission.TimeMultiplier)
+	submission.SubmittedAt = now
+	submission.TimeTaken = int(deadline.Sub(submission.StartedAt).Minutes())
+	submission.SubmitReason = reason
+	submission.Answers = gradeAnswers(exam, questions, submission.Answers)
+	recalculateSubmission(submission, exam, false)
+	if submission.Status == "evaluated" {
+		submission.EvaluatedAt = &now
+	}
+}
+
+// examQuestions returns an exam's questions, answer keys included, by ID
+func examQuestions(ctx context.Context, db store.Store, examID string) (map[string]models.Question, error) {
+	list, err := db.Questions().ListByExam(ctx, examID)
+	if err != nil {
+		return nil, err
+	}
+	questions := make(map[string]models.Question, len(list))
+	for _, q := range list {
+		questions[q.ID] = q
+	}
+	return questions, nil
+}
+
+// upsertAnswer replaces the answer to the same question, or appends it. Saved
+// answers are left ungraded until the attempt is submitted or closed.
+func upsertAnswer(answers []models.ExamSubmittedAnswer, answer models.ExamSubmittedAnswer) []models.ExamSubmittedAnswer {
+	for i := range answers {
+		if answers[i].QuestionID == answer.QuestionID {
+			answers[i] = answer
+			return answers
+		}
+	}
+	return append(answers, answer)
+}
+
+// gradeAnswers grades each answer against its question. Answers to questions
+// no longer in the exam are dropped.
+func gradeAnswers(exam models.Exam, questions map[string]models.Question, answers []models.ExamSubmittedAnswer) []models.ExamSubmittedAnswer {
+	graded := make([]models.ExamSubmittedAnswer, 0, len(answers))
+	for _, a := range answers {
+		if question, ok := questions[a.QuestionID]; ok {
+			graded = append(graded, gradeAnswer(exam, question, a.SelectedAnswer))
+		}
+	}
+	return graded
+}
+
+// gradeAnswer evaluates a single answer. Subjective answers are left pending
+// for manual evaluation; skipped questions are never penalised.
+func gradeAnswer(exam models.Exam, question models.Question, selected string) models.ExamSubmittedAnswer {
+	answer := models.ExamSubmittedAnswer{
+		QuestionID:     question.ID,
+		QuestionType:   question.Type,
+		SelectedAnswer: selected,
+	}
+
+	if selected == "" {
+		answer.IsEvaluated = true
+		return answer
+	}
+
+	if !utils.IsObjectiveQuestion(question.Type) {
+		return answer
+	}
+
+	isCorrect := false
+	for _, opt := range question.Options {
+		if opt.ID == selected {
+			isCorrect = opt.IsCorrect
+			break
+		}
+	}
+
+	answer.IsCorrect = &isCorrect
+	answer.IsEvaluated = true
+	if isCorrect {
+		answer.MarksAwarded = question.Marks
+	} else if exam.NegativeMarking {
+		answer.MarksAwarded = -exam.NegativeMarkValue
+	}
+	return answer
+}
+
+// recalculateSubmission refreshes marks, percentage, pass state and status.
+// reviewed indicates a teacher has looked at the submission.
+func recalculateSubmission(submission *models.ExamSubmission, exam models.Exam, reviewed bool) {
+	marks := 0.0
+	pending := 0
+	for _, answer := range submission.Answers {
+		marks += answer.MarksAwarded
+		if !answer.IsEvaluated {
+			pending++
+		}
+	}
+	if marks < 0 {
+		marks = 0
+	}
+
+	totalMarks := submission.TotalMarks
+	if totalMarks == 0 {
+		totalMarks = exam.TotalMarks
+	}
+
+	submission.MarksObtained = marks
+	submission.Percentage = 0
+	if totalMarks > 0 {
+		submission.Percentage = (marks / totalMarks) * 100
+	}
+	submission.Passed = marks >= exam.PassingMarks
+
+	switch {
+	case pending == 0 && (reviewed || !exam.RequiresManualEvaluation):
+		submission.Status = "evaluated"
+	case reviewed:
+		submission.Status = "partially_evaluated"
+	default:
+		submission.Status = "submitted"
+	}
+}
+
+// hideUnreleasedMarks blanks marks that a student must not see before evaluation completes
+func hideUnreleasedMarks(submission *models.ExamSubmission, exam models.Exam) {
+	if !exam.RequiresManualEvaluation || submission.Status == "evaluated" {
+		return
+	}
+	submission.MarksObtained = 0
+	submission.Percentage = 0
+	submission.Passed = false
+	for i := range submission.Answers {
+		submission.Answers[i].IsCorrect = nil
+		submission.Answers[i].MarksAwarded = 0
+	}
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler lists exams (filtered by role and course)
+func ListExams(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow GET
+	if r.Method != http.MethodGet {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _, role := utils.GetUserFromContext(ctx)
+
+		// Get query parameters
+		courseID := r.URL.Query().Get("courseId")
+		examType := r.URL.Query().Get("examType")
+		limit := 20
+		if l := r.URL.Query().Get("limit"); l != "" {
+			if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+				limit = parsed
+			}
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		filter := store.ExamFilter{CourseID: courseID, ExamType: examType, Limit: limit}
+
+		// Role-based filtering
+		switch role {
+		case "admin":
+			// Admins see all exams
+		case "teacher":
+			// Teachers see only their exams
+			filter.TeacherID = userID
+		case "student":
+			// Students see only published exams for courses they're enrolled in
+			filter.PublishedOnly = true
+
+			if courseID == "" {
+				enrollments, err := db.Enrollments().List(ctx, store.EnrollmentFilter{
+					StudentID: userID,
+					Status:    "active",
+				})
+				if err != nil {
+					utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch enrollments")
+					return
+				}
+
+				courseIDs := make([]string, 0)
+				for _, enrollment := range enrollments {
+					courseIDs = append(courseIDs, enrollment.CourseID)
+				}
+
+				if len(courseIDs) == 0 {
+					utils.RespondSuccess(w, []models.Exam{}, "Exams fetched successfully")
+					return
+				}
+
+				filter.CourseIDs = courseIDs
+			}
+		default:
+			utils.RespondError(w, http.StatusForbidden, "Invalid role")
+			return
+		}
+
+		// Upcoming exams first
+		exams, err := db.Exams().List(ctx, filter)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch exams")
+			return
+		}
+
+		utils.RespondSuccess(w, exams, "Exams fetched successfully")
+	})).ServeHTTP(w, r)
+}
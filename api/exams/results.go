@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler gets exam results for a student or all students (for teachers)
+func GetResults(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow GET
+	if r.Method != http.MethodGet {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _, role := utils.GetUserFromContext(ctx)
+
+		// Get query parameters
+		examID := r.URL.Query().Get("examId")
+		submissionID := r.URL.Query().Get("submissionId")
+
+		if examID == "" && submissionID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Either examId or submissionId is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		// If specific submission requested
+		if submissionID != "" {
+			submission, err := db.ExamSubmissions().Get(ctx, submissionID)
+			if err == store.ErrNotFound {
+				utils.RespondError(w, http.StatusNotFound, "Submission not found")
+				return
+			}
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to parse submission data")
+				return
+			}
+
+			if role == "student" && submission.StudentID != userID {
+				utils.RespondError(w, http.StatusForbidden, "You can only view your own results")
+				return
+			}
+
+			exam, err := db.Exams().Get(ctx, submission.ExamID)
+			if err == store.ErrNotFound {
+				utils.RespondError(w, http.StatusNotFound, "Exam not found")
+				return
+			}
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to parse exam data")
+				return
+			}
+
+			if role == "teacher" && exam.TeacherID != userID {
+				utils.RespondError(w, http.StatusForbidden, "You can only view results for your own exams")
+				return
+			}
+
+			if role == "student" {
+				hideUnreleasedMarks(submission, *exam)
+			}
+
+			utils.RespondSuccess(w, submission, "Results fetched successfully")
+			return
+		}
+
+		// Get exam
+		exam, err := db.Exams().Get(ctx, examID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Exam not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse exam data")
+			return
+		}
+
+		// Build filter based on role
+		filter := store.ExamSubmissionFilter{
+			ExamID:   examID,
+			Statuses: []string{"submitted", "partially_evaluated", "evaluated"},
+		}
+
+		if role == "student" {
+			filter.StudentID = userID
+		} else if role == "teacher" && exam.TeacherID != userID {
+			utils.RespondError(w, http.StatusForbidden, "You can only view results for your own exams")
+			return
+		}
+
+		submissions, err := db.ExamSubmissions().List(ctx, filter)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch results")
+			return
+		}
+
+		if role == "student" {
+			for i := range submissions {
+				hideUnreleasedMarks(&submissions[i], *exam)
+			}
+		}
+
+		stats := map[string]interface{}{
+			"totalSubmissions": len(submissions),
+		}
+
+		// Statistics for teachers/admins are based on fully evaluated submissions
+		if role == "teacher" || role == "admin" {
+			evaluated := 0
+			passed := 0
+			totalMarks := 0.0
+			highest := 0.0
+			lowest := 0.0
+			for _, sub := range submissions {
+				if sub.Status != "evaluated" {
+					continue
+				}
+				if evaluated == 0 || sub.MarksObtained > highest {
+					highest = sub.MarksObtained
+				}
+				if evaluated == 0 || sub.MarksObtained < lowest {
+					lowest = sub.MarksObtained
+				}
+				evaluated++
+				totalMarks += sub.MarksObtained
+				if sub.Passed {
+					passed++
+				}
+			}
+
+			averageMarks := 0.0
+			passRate := 0.0
+			if evaluated > 0 {
+				averageMarks = totalMarks / float64(evaluated)
+				passRate = float64(passed) / float64(evaluated) * 100
+			}
+
+			stats["totalEvaluated"] = evaluated
+			stats["pendingEvaluation"] = len(submissions) - evaluated
+			stats["averageMarks"] = averageMarks
+			stats["highestMarks"] = highest
+			stats["lowestMarks"] = lowest
+			stats["passRate"] = passRate
+			stats["totalPassed"] = passed
+			stats["totalFailed"] = evaluated - passed
+		}
+
+		utils.RespondSuccess(w, map[string]interface{}{
+			"submissions": submissions,
+			"statistics":  stats,
+		}, "Results fetched successfully")
+	})).ServeHTTP(w, r)
+}
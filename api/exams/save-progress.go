@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler autosaves exam answers without submitting. Saved answers are graded
+// when the student submits or, if they never do, when the attempt is closed
+// after its deadline.
+func SaveProgress(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (students only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _, _ := utils.GetUserFromContext(ctx)
+
+		// Parse request body
+		var req models.SubmitExamRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.ExamID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Exam ID is required")
+			return
+		}
+		if len(req.Answers) == 0 {
+			utils.RespondError(w, http.StatusBadRequest, "At least one answer is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		// Find the student's in-progress attempt
+		inProgress, err := db.ExamSubmissions().List(ctx, store.ExamSubmissionFilter{
+			ExamID:    req.ExamID,
+			StudentID: userID,
+			Statuses:  []string{"in_progress"},
+		})
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch submission")
+			return
+		}
+		if len(inProgress) == 0 {
+			utils.RespondError(w, http.StatusNotFound, "No exam attempt in progress")
+			return
+		}
+
+		submission := inProgress[0]
+
+		// Get exam details
+		exam, err := db.Exams().Get(ctx, req.ExamID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Exam not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse exam data")
+			return
+		}
+
+		accommodation, err := store.StudentAccommodation(ctx, db, userID, exam.CourseID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch accommodations")
+			return
+		}
+		*exam = withAccommodation(*exam, accommodation)
+
+		now := time.Now()
+		if attemptExpired(*exam, submission, now) {
+			utils.RespondError(w, http.StatusForbidden, "Time limit exceeded")
+			return
+		}
+
+		questions, err := examQuestions(ctx, db, req.ExamID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch questions")
+			return
+		}
+
+		inAttempt := make(map[string]bool)
+		for _, id := range submission.QuestionIDs {
+			inAttempt[id] = true
+		}
+
+		// Answers to unknown questions are reported back rather than failing
+		// the whole save
+		saved := 0
+		rejected := make([]string, 0)
+		for _, a := range req.Answers {
+			question, exists := questions[a.QuestionID]
+			if !exists || !inAttempt[a.QuestionID] {
+				rejected = append(rejected, a.QuestionID)
+				continue
+			}
+			submission.Answers = upsertAnswer(submission.Answers, models.ExamSubmittedAnswer{
+				QuestionID:     question.ID,
+				QuestionType:   question.Type,
+				SelectedAnswer: a.SelectedAnswer,
+			})
+			saved++
+		}
+
+		if saved > 0 && !saveAttempt(w, r, db, &submission, "Failed to save progress") {
+			return
+		}
+
+		utils.RespondSuccess(w, map[string]interface{}{
+			"submissionId": submission.SubmissionID,
+			"saved":        saved,
+			"rejected":     rejected,
+			"savedAt":      now,
+			"deadline":     examDeadline(*exam, submission.StartedAt, submission.TimeMultiplier),
+		}, "Progress saved")
+	}), "student").ServeHTTP(w, r)
+}
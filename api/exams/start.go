@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler starts an exam attempt for a student
+func StartExam(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (students only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _, _ := utils.GetUserFromContext(ctx)
+
+		// Parse request body
+		var req struct {
+			ExamID string `json:"examId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.ExamID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Exam ID is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		// Get exam
+		exam, err := db.Exams().Get(ctx, req.ExamID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Exam not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse exam data")
+			return
+		}
+
+		if exam.IsDeleted || !exam.IsPublished {
+			utils.RespondError(w, http.StatusForbidden, "This exam is not available")
+			return
+		}
+
+		// Apply the student's accommodation, if they have one
+		accommodation, err := store.StudentAccommodation(ctx, db, userID, exam.CourseID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch accommodations")
+			return
+		}
+		*exam = withAccommodation(*exam, accommodation)
+
+		// Enforce the scheduled window
+		now := time.Now()
+		if now.Before(exam.StartTime) {
+			utils.RespondError(w, http.StatusForbidden, "Exam has not started yet")
+			return
+		}
+		if now.After(exam.EndTime) {
+			utils.RespondError(w, http.StatusForbidden, "Exam has ended")
+			return
+		}
+
+		// Verify student is enrolled in the course
+		enrollments, err := db.Enrollments().List(ctx, store.EnrollmentFilter{
+			StudentID: userID,
+			CourseID:  exam.CourseID,
+			Status:    "active",
+		})
+		if err != nil || len(enrollments) == 0 {
+			utils.RespondError(w, http.StatusForbidden, "You must be enrolled in this course")
+			return
+		}
+
+		// Get all questions for this exam
+		questions, err := db.Questions().ListByExam(ctx, req.ExamID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch questions")
+			return
+		}
+
+		questionsByID := make(map[string]models.Question)
+		for _, question := range questions {
+			questionsByID[question.ID] = question
+		}
+
+		if len(questions) == 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Exam has no questions")
+			return
+		}
+
+		// Check previous attempts
+		previous, err := db.ExamSubmissions().List(ctx, store.ExamSubmissionFilter{
+			ExamID:    req.ExamID,
+			StudentID: userID,
+		})
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to check previous attempts")
+			return
+		}
+
+		for _, sub := range previous {
+			// An attempt left open past its deadline is closed, not resumed
+			if sub.Status == "in_progress" && attemptExpired(*exam, sub, now) {
+				closeExpiredAttempt(&sub, *exam, questionsByID, submitReasonAutoSubmitted, now)
+				if err := db.ExamSubmissions().Save(ctx, &sub); err != nil {
+					utils.RespondError(w, http.StatusInternalServerError, "Failed to close expired attempt")
+					return
+				}
+			}
+
+			if sub.Status == "in_progress" {
+				// Resume existing attempt with the question order it was started with
+				ordered := make([]models.Question, 0, len(sub.QuestionIDs))
+				for _, id := range sub.QuestionIDs {
+					if q, ok := questionsByID[id]; ok {
+						ordered = append(ordered, q)
+					}
+				}
+
+				utils.RespondSuccess(w, map[string]interface{}{
+					"submission": sub,
+					"questions":  utils.StudentQuestions(ordered),
+					"deadline":   examDeadline(*exam, sub.StartedAt, sub.TimeMultiplier),
+					"resumed":    true,
+				})
+				return
+			}
+
+			// Only practice exams may be retaken
+			if exam.ExamType != "practice" {
+				utils.RespondError(w, http.StatusForbidden, "You have already attempted this exam")
+				return
+			}
+		}
+
+		// Randomize question order if enabled
+		if exam.RandomizeQuestions {
+			utils.ShuffleQuestions(&questions, utils.NewShuffleSeed())
+		}
+
+		questionIDs := make([]string, len(questions))
+		totalMarks := 0.0
+		for i, q := range questions {
+			questionIDs[i] = q.ID
+			totalMarks += q.Marks
+		}
+
+		// Get student name
+		studentName := ""
+		if user, err := db.Users().Get(ctx, userID); err == nil {
+			studentName = user.DisplayName
+		}
+
+		// Create submission
+		submission := models.ExamSubmission{
+			ExamID:      req.ExamID,
+			StudentID:   userID,
+			StudentName: studentName,
+			Answers:     make([]models.ExamSubmittedAnswer, 0),
+			QuestionIDs: questionIDs,
+			StartedAt:   now,
+			TotalMarks:  totalMarks,
+			Status:      "in_progress",
+		}
+		if accommodation != nil {
+			submission.TimeMultiplier = utils.TimeMultiplier(accommodation)
+		}
+
+		if err := db.ExamSubmissions().Create(ctx, &submission); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to start exam")
+			return
+		}
+
+		utils.RespondSuccess(w, map[string]interface{}{
+			"submission": submission,
+			"questions":  utils.StudentQuestions(questions),
+			"deadline":   examDeadline(*exam, now, submission.TimeMultiplier),
+			"resumed":    false,
+		})
+	}), "student").ServeHTTP(w, r)
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler submits exam answers, auto-grading objective questions
+func SubmitExam(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (students only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _, _ := utils.GetUserFromContext(ctx)
+
+		// Parse request body
+		var req models.SubmitExamRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.ExamID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Exam ID is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		// Find the student's in-progress attempt
+		inProgress, err := db.ExamSubmissions().List(ctx, store.ExamSubmissionFilter{
+			ExamID:    req.ExamID,
+			StudentID: userID,
+			Statuses:  []string{"in_progress"},
+		})
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch submission")
+			return
+		}
+		if len(inProgress) == 0 {
+			utils.RespondError(w, http.StatusNotFound, "No exam attempt in progress")
+			return
+		}
+
+		submission := inProgress[0]
+
+		// Get exam details
+		exam, err := db.Exams().Get(ctx, req.ExamID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Exam not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse exam data")
+			return
+		}
+
+		// The student's deadline follows their accommodated window
+		accommodation, err := store.StudentAccommodation(ctx, db, userID, exam.CourseID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch accommodations")
+			return
+		}
+		*exam = withAccommodation(*exam, accommodation)
+
+		// Get questions with answer keys
+		questions, err := examQuestions(ctx, db, req.ExamID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch questions")
+			return
+		}
+
+		// A submission after the attempt deadline closes the attempt with the
+		// answers already saved; the late answers are dropped
+		now := time.Now()
+		if attemptExpired(*exam, submission, now) {
+			closeExpiredAttempt(&submission, *exam, questions, submitReasonTimedOut, now)
+			if !saveAttempt(w, r, db, &submission, "Failed to submit exam") {
+				return
+			}
+			utils.RespondSuccess(w, map[string]interface{}{
+				"submissionId":   submission.SubmissionID,
+				"status":         submission.Status,
+				"timeTaken":      submission.TimeTaken,
+				"timedOut":       true,
+				"droppedAnswers": len(req.Answers),
+			}, "Exam time is over; answers sent after the deadline were not recorded")
+			return
+		}
+
+		// The submitted answers replace saved ones; each question counts once
+		seen := make(map[string]bool)
+		for _, a := range req.Answers {
+			question, exists := questions[a.QuestionID]
+			if !exists || seen[a.QuestionID] {
+				continue
+			}
+			seen[a.QuestionID] = true
+			submission.Answers = upsertAnswer(submission.Answers, models.ExamSubmittedAnswer{
+				QuestionID:     question.ID,
+				QuestionType:   question.Type,
+				SelectedAnswer: a.SelectedAnswer,
+			})
+		}
+
+		submission.Answers = gradeAnswers(*exam, questions, submission.Answers)
+		submission.SubmittedAt = now
+		submission.TimeTaken = int(now.Sub(submission.StartedAt).Minutes())
+		recalculateSubmission(&submission, *exam, false)
+
+		if submission.Status == "evaluated" {
+			submission.EvaluatedAt = &now
+		}
+
+		if !saveAttempt(w, r, db, &submission, "Failed to submit exam") {
+			return
+		}
+
+		response := map[string]interface{}{
+			"submissionId": submission.SubmissionID,
+			"status":       submission.Status,
+			"timeTaken":    submission.TimeTaken,
+			"timedOut":     false,
+		}
+
+		if submission.Status != "evaluated" {
+			utils.RespondSuccess(w, response, "Exam submitted successfully, awaiting evaluation")
+			return
+		}
+
+		response["marksObtained"] = submission.MarksObtained
+		response["totalMarks"] = submission.TotalMarks
+		response["percentage"] = submission.Percentage
+		response["passed"] = submission.Passed
+
+		utils.RespondSuccess(w, response, "Exam submitted successfully")
+	}), "student").ServeHTTP(w, r)
+}
+
+// saveAttempt stores an in-progress attempt unless it changed since it was
+// loaded, so a submit and an autosave never overwrite each other. On failure
+// it writes the error response and returns false.
+func saveAttempt(w http.ResponseWriter, r *http.Request, db store.Store, submission *models.ExamSubmission, failure string) bool {
+	err := db.ExamSubmissions().SaveIfVersion(r.Context(), submission, submission.Version)
+	if err == store.ErrConflict {
+		utils.RespondError(w, http.StatusConflict, "Exam progress changed, please retry")
+		return false
+	}
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, failure)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// SweepResult summarises a sweep of expired attempts
+type SweepResult struct {
+	Checked       int `json:"checked"`
+	AutoSubmitted int `json:"autoSubmitted"`
+	Failed        int `json:"failed"`
+}
+
+// SweepExpiredAttempts closes every in-progress exam attempt whose deadline
+// (plus grace) has passed, grading the answers it already holds
+func SweepExpiredAttempts(ctx context.Context, db store.Store, now time.Time) (SweepResult, error) {
+	var result SweepResult
+
+	submissions, err := db.ExamSubmissions().List(ctx, store.ExamSubmissionFilter{
+		Statuses: []string{"in_progress"},
+	})
+	if err != nil {
+		return result, err
+	}
+
+	exams := make(map[string]*models.Exam)
+	questions := make(map[string]map[string]models.Question)
+	for i := range submissions {
+		submission := &submissions[i]
+		result.Checked++
+
+		exam, ok := exams[submission.ExamID]
+		if !ok {
+			exam, err = db.Exams().Get(ctx, submission.ExamID)
+			if err != nil {
+				log.Printf("ERROR: Sweep could not load exam %s: %v", submission.ExamID, err)
+				result.Failed++
+				continue
+			}
+			exams[submission.ExamID] = exam
+		}
+		if _, ok := questions[submission.ExamID]; !ok {
+			questions[submission.ExamID], err = examQuestions(ctx, db, submission.ExamID)
+			if err != nil {
+				log.Printf("ERROR: Sweep could not load questions for exam %s: %v", submission.ExamID, err)
+				result.Failed++
+				continue
+			}
+		}
+		accommodation, err := store.StudentAccommodation(ctx, db, submission.StudentID, exam.CourseID)
+		if err != nil {
+			log.Printf("ERROR: Sweep could not load accommodations for %s: %v", submission.SubmissionID, err)
+			result.Failed++
+			continue
+		}
+		studentExam := withAccommodation(*exam, accommodation)
+		if !attemptExpired(studentExam, *submission, now) {
+			continue
+		}
+
+		closeExpiredAttempt(submission, studentExam, questions[submission.ExamID], submitReasonAutoSubmitted, now)
+		if err := db.ExamSubmissions().Save(ctx, submission); err != nil {
+			log.Printf("ERROR: Sweep could not auto-submit %s: %v", submission.SubmissionID, err)
+			result.Failed++
+			continue
+		}
+		result.AutoSubmitted++
+	}
+
+	return result, nil
+}
+
+// Handler closes expired exam attempts. It is meant for a cron job,
+// authenticated with CRON_SECRET as a bearer token, but admins may also call it.
+func SweepExpired(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Cron schedulers issue GET requests
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	utils.CronOrAdmin(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		result, err := SweepExpiredAttempts(ctx, db, time.Now())
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch in-progress attempts")
+			return
+		}
+
+		utils.RespondSuccess(w, result, "Expired attempts swept")
+	})(w, r)
+}
@@ -27,6 +27,12 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 				"/api/auth/profile",
 				"/api/auth/update",
 				"/api/auth/set-role",
+				"/api/auth/import",
+				"/api/auth/invite",
+				"/api/auth/invitations",
+				"/api/auth/revoke-invitation",
+				"/api/auth/role-requests",
+				"/api/auth/review-role-request",
 			},
 			"courses": []string{
 				"/api/courses/create",
@@ -35,17 +41,79 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 				"/api/courses/update",
 				"/api/courses/delete",
 				"/api/courses/enroll",
+				"/api/courses/join",
+				"/api/courses/create-join-code",
+				"/api/courses/join-codes",
+				"/api/courses/revoke-join-code",
+				"/api/courses/join-requests",
+				"/api/courses/review-join-request",
 				"/api/courses/my-enrollments",
+				"/api/courses/gradebook",
+				"/api/courses/gradebook-policy",
+				"/api/courses/gradebook-export",
 			},
 			"quizzes": []string{
 				"/api/quizzes/create",
 				"/api/quizzes/list",
 				"/api/quizzes/get",
+				"/api/quizzes/update",
+				"/api/quizzes/publish",
+				"/api/quizzes/delete",
 				"/api/quizzes/add-question",
+				"/api/quizzes/update-question",
+				"/api/quizzes/delete-question",
+				"/api/quizzes/reorder-questions",
 				"/api/quizzes/start",
+				"/api/quizzes/view-question",
+				"/api/quizzes/save-progress",
 				"/api/quizzes/submit",
 				"/api/quizzes/results",
+				"/api/quizzes/export",
 				"/api/quizzes/resume",
+				"/api/quizzes/grading-queue",
+				"/api/quizzes/grade",
+				"/api/quizzes/sweep-expired",
+				"/api/quizzes/replay",
+				"/api/quizzes/window-override",
+			},
+			"exams": []string{
+				"/api/exams/create",
+				"/api/exams/list",
+				"/api/exams/get",
+				"/api/exams/add-question",
+				"/api/exams/start",
+				"/api/exams/save-progress",
+				"/api/exams/submit",
+				"/api/exams/evaluate",
+				"/api/exams/results",
+				"/api/exams/sweep-expired",
+			},
+			"assignments": []string{
+				"/api/assignments/create",
+				"/api/assignments/list",
+				"/api/assignments/get",
+				"/api/assignments/publish",
+				"/api/assignments/submit",
+				"/api/assignments/submissions",
+				"/api/assignments/evaluate",
+				"/api/assignments/return",
+			},
+			"bank": []string{
+				"/api/bank/create",
+				"/api/bank/list",
+				"/api/bank/get",
+				"/api/bank/update",
+				"/api/bank/delete",
+				"/api/bank/attach",
+				"/api/bank/sync",
+			},
+			"accommodations": []string{
+				"/api/accommodations/save",
+				"/api/accommodations/list",
+				"/api/accommodations/delete",
+			},
+			"analytics": []string{
+				"/api/analytics/item-analysis",
 			},
 		},
 	}
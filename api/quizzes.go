@@ -21,16 +21,44 @@ func QuizzesRouter(w http.ResponseWriter, r *http.Request) {
 		quizHandlers.ListQuizzes(w, r)
 	case "get":
 		quizHandlers.GetQuiz(w, r)
+	case "update":
+		quizHandlers.UpdateQuiz(w, r)
+	case "publish":
+		quizHandlers.PublishQuiz(w, r)
+	case "delete":
+		quizHandlers.DeleteQuiz(w, r)
 	case "add-question":
 		quizHandlers.AddQuestion(w, r)
+	case "update-question":
+		quizHandlers.UpdateQuestion(w, r)
+	case "delete-question":
+		quizHandlers.DeleteQuestion(w, r)
+	case "reorder-questions":
+		quizHandlers.ReorderQuestions(w, r)
 	case "start":
 		quizHandlers.StartQuiz(w, r)
+	case "view-question":
+		quizHandlers.ViewQuestion(w, r)
+	case "save-progress":
+		quizHandlers.SaveProgress(w, r)
 	case "submit":
 		quizHandlers.SubmitQuiz(w, r)
 	case "results":
 		quizHandlers.GetResults(w, r)
+	case "export":
+		quizHandlers.ExportResults(w, r)
 	case "resume":
 		quizHandlers.ResumeQuiz(w, r)
+	case "grading-queue":
+		quizHandlers.GetGradingQueue(w, r)
+	case "grade":
+		quizHandlers.GradeSubmission(w, r)
+	case "sweep-expired":
+		quizHandlers.SweepExpired(w, r)
+	case "replay":
+		quizHandlers.ReplayAttempt(w, r)
+	case "window-override":
+		quizHandlers.SetWindowOverride(w, r)
 	default:
 		http.Error(w, "Not Found", http.StatusNotFound)
 	}
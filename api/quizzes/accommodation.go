@@ -0,0 +1,35 @@
+package handler
+
+import "github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+
+// withAccommodation applies an accommodation's alternative window to quiz as
+// the student's window override. An override the teacher set on the quiz
+// itself is more specific and is kept.
+func withAccommodation(quiz models.Quiz, accommodation *models.Accommodation) models.Quiz {
+	if accommodation == nil || (accommodation.StartDate == nil && accommodation.EndDate == nil) {
+		return quiz
+	}
+	if _, ok := windowOverride(quiz, accommodation.StudentID); ok {
+		return quiz
+	}
+
+	overrides := make([]models.WindowOverride, 0, len(quiz.WindowOverrides)+1)
+	overrides = append(overrides, quiz.WindowOverrides...)
+	quiz.WindowOverrides = append(overrides, models.WindowOverride{
+		StudentID: accommodation.StudentID,
+		StartDate: accommodation.StartDate,
+		EndDate:   accommodation.EndDate,
+		Reason:    accommodation.Reason,
+		GrantedBy: accommodation.GrantedBy,
+		GrantedAt: accommodation.UpdatedAt,
+	})
+	return quiz
+}
+
+// attemptLimit returns how many attempts the student may make; 0 = unlimited
+func attemptLimit(quiz models.Quiz, accommodation *models.Accommodation) int {
+	if quiz.MaxAttempts <= 0 || accommodation == nil {
+		return quiz.MaxAttempts
+	}
+	return quiz.MaxAttempts + accommodation.ExtraAttempts
+}
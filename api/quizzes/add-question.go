@@ -3,10 +3,11 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 	"time"
 
-	"cloud.google.com/go/firestore"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
 )
 
@@ -52,76 +53,21 @@ func AddQuestion(w http.ResponseWriter, r *http.Request) {
 			utils.RespondError(w, http.StatusBadRequest, "Quiz ID is required")
 			return
 		}
-		if req.Question.Text == "" {
-			utils.RespondError(w, http.StatusBadRequest, "Question text is required")
+		if err := utils.ValidateQuestion(req.Question, validQuestionTypes); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
 			return
 		}
-		if req.Question.Type == "" {
-			utils.RespondError(w, http.StatusBadRequest, "Question type is required")
-			return
-		}
-		if req.Question.Points <= 0 {
-			utils.RespondError(w, http.StatusBadRequest, "Points must be greater than 0")
-			return
-		}
-
-		// Validate question type
-		validTypes := map[string]bool{
-			"mcq":        true,
-			"true_false": true,
-			"short_answer": true,
-			"long_answer": true,
-		}
-		if !validTypes[req.Question.Type] {
-			utils.RespondError(w, http.StatusBadRequest, "Invalid question type")
-			return
-		}
-
-		// Validate MCQ and True/False questions
-		if req.Question.Type == "mcq" || req.Question.Type == "true_false" {
-			if len(req.Question.Options) == 0 {
-				utils.RespondError(w, http.StatusBadRequest, "Options are required for MCQ and True/False questions")
-				return
-			}
-
-			// Check if at least one option is marked as correct
-			hasCorrect := false
-			for _, opt := range req.Question.Options {
-				if opt.IsCorrect {
-					hasCorrect = true
-					break
-				}
-			}
-			if !hasCorrect {
-				utils.RespondError(w, http.StatusBadRequest, "At least one option must be marked as correct")
-				return
-			}
-		}
 
-		// Get Firestore client
-		firestoreClient, err := utils.GetFirestoreClient(ctx)
+		// Get data store
+		db, err := store.GetStore(ctx)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
 			return
 		}
 
-		// Verify quiz exists and user is the teacher or admin
-		quizRef := firestoreClient.Collection("quizzes").Doc(req.QuizID)
-		quizDoc, err := quizRef.Get(ctx)
-		if err != nil {
-			utils.RespondError(w, http.StatusNotFound, "Quiz not found")
-			return
-		}
-
-		var quiz models.Quiz
-		if err := quizDoc.DataTo(&quiz); err != nil {
-			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse quiz data")
-			return
-		}
-
-		// Check if user is the quiz teacher or admin
-		if role != "admin" && quiz.TeacherID != userID {
-			utils.RespondError(w, http.StatusForbidden, "You can only add questions to your own quizzes")
+		// Verify quiz exists and user teaches its course or is an admin
+		quiz, ok := OwnedQuiz(w, r, db, req.QuizID, userID, role)
+		if !ok {
 			return
 		}
 
@@ -133,29 +79,31 @@ func AddQuestion(w http.ResponseWriter, r *http.Request) {
 			Type:       req.Question.Type,
 			Options:    req.Question.Options,
 			Points:     req.Question.Points,
+			ScoringPolicy: req.Question.ScoringPolicy,
+			CorrectAnswer: req.Question.CorrectAnswer,
+			AcceptedAnswers: req.Question.AcceptedAnswers,
 			Order:      quiz.QuestionCount + 1, // Auto-increment order
 			Explanation: req.Question.Explanation,
 			ImageURL:   req.Question.ImageURL,
+			Difficulty: req.Question.Difficulty,
+			Pool:       strings.TrimSpace(req.Question.Pool),
 			CreatedAt:  now,
 			UpdatedAt:  now,
 		}
 
-		// Save question to Firestore
-		questionRef := firestoreClient.Collection("questions").NewDoc()
-		question.ID = questionRef.ID
-
-		if _, err := questionRef.Set(ctx, question); err != nil {
+		// Save question
+		if err := db.Questions().Create(ctx, &question); err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to add question")
 			return
 		}
 
-		// Update quiz question count and total marks
-		_, err = quizRef.Update(ctx, []firestore.Update{
-			{Path: "questionCount", Value: firestore.Increment(1)},
-			{Path: "totalMarks", Value: firestore.Increment(req.Question.Points)},
-			{Path: "updatedAt", Value: now},
-		})
-		if err != nil {
+		// Update quiz question count and total marks. Pool questions on a
+		// drawn quiz are not all asked, so they don't add to the total.
+		marks := req.Question.Points
+		if !CountsQuestionMarks(*quiz) {
+			marks = 0
+		}
+		if err := db.Quizzes().AddQuestionStats(ctx, req.QuizID, 1, marks); err != nil {
 		utils.RespondError(w, http.StatusInternalServerError, "Failed to update quiz")
 		return
 	}
@@ -0,0 +1,358 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils/authtest"
+)
+
+// attemptFixture is a published two-question quiz with one enrolled student
+type attemptFixture struct {
+	db        *store.MemoryStore
+	student   string
+	quiz      *models.Quiz
+	questions []models.Question
+}
+
+func newAttemptFixture(t *testing.T) *attemptFixture {
+	t.Helper()
+	ctx := context.Background()
+	mint := authtest.Install([]byte("test-secret"))
+	db := store.NewMemoryStore()
+	store.SetStore(db)
+	t.Cleanup(func() { store.SetStore(nil) })
+
+	now := time.Now()
+	quiz := &models.Quiz{
+		CourseID:     "course",
+		TeacherID:    "teacher",
+		Title:        "Quiz",
+		Duration:     10,
+		TotalMarks:   3,
+		PassingMarks: 2,
+		IsPublished:  true,
+		CreatedAt:    now,
+	}
+	if err := db.Quizzes().Create(ctx, quiz); err != nil {
+		t.Fatal(err)
+	}
+
+	f := &attemptFixture{db: db, student: mint.Student("student"), quiz: quiz}
+	for i, points := range []float64{1, 2} {
+		question := models.Question{
+			QuizID: quiz.ID,
+			Type:   "mcq",
+			Text:   "Question",
+			Points: points,
+			Order:  i + 1,
+			Options: []models.QuestionOption{
+				{ID: "A", Text: "right", IsCorrect: true},
+				{ID: "B", Text: "wrong"},
+			},
+		}
+		if err := db.Questions().Create(ctx, &question); err != nil {
+			t.Fatal(err)
+		}
+		f.questions = append(f.questions, question)
+	}
+
+	db.Courses().Save(ctx, &models.Course{CourseID: "course", TeacherID: "teacher"})
+	db.Users().Save(ctx, &models.User{UID: "student", DisplayName: "Student", Role: "student", IsActive: true})
+	db.Enrollments().Save(ctx, &models.Enrollment{EnrollmentID: "enrollment", StudentID: "student", CourseID: "course", Status: "active"})
+	return f
+}
+
+// call runs handler with a JSON body and decodes the response data
+func call(t *testing.T, handler http.HandlerFunc, token string, body interface{}) (int, map[string]interface{}) {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/", &buf)
+	req.Header.Set("Authorization", authtest.Header(token))
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	var resp struct {
+		Data  map[string]interface{} `json:"data"`
+		Error string                 `json:"error"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode %q: %v", rec.Body.String(), err)
+	}
+	if resp.Error != "" {
+		t.Logf("%d: %s", rec.Code, resp.Error)
+	}
+	return rec.Code, resp.Data
+}
+
+func (f *attemptFixture) start(t *testing.T) string {
+	t.Helper()
+	code, data := call(t, StartQuiz, f.student, map[string]string{"quizId": f.quiz.ID})
+	if code != http.StatusOK {
+		t.Fatalf("start: status %d", code)
+	}
+	submission := data["submission"].(map[string]interface{})
+	return submission["id"].(string)
+}
+
+func answer(questionID string, options ...string) models.Answer {
+	return models.Answer{QuestionID: questionID, SelectedOptions: options}
+}
+
+func TestQuizAttemptSaveAndSubmit(t *testing.T) {
+	f := newAttemptFixture(t)
+	submissionID := f.start(t)
+
+	// Starting again resumes the open attempt
+	code, data := call(t, StartQuiz, f.student, map[string]string{"quizId": f.quiz.ID})
+	if code != http.StatusOK || data["resumed"] != true {
+		t.Fatalf("second start: status %d, resumed %v", code, data["resumed"])
+	}
+
+	code, data = call(t, SaveProgress, f.student, models.SaveProgressRequest{
+		SubmissionID: submissionID,
+		Version:      0,
+		Answers:      []models.Answer{answer(f.questions[0].ID, "A"), answer("unknown", "A")},
+	})
+	if code != http.StatusOK {
+		t.Fatalf("save progress: status %d", code)
+	}
+	if data["saved"] != 1.0 || data["version"] != 1.0 || len(data["rejected"].([]interface{})) != 1 {
+		t.Errorf("save progress = %v", data)
+	}
+
+	// The submit request's answers are merged over the autosaved ones
+	code, data = call(t, SubmitQuiz, f.student, models.SubmitQuizRequest{
+		SubmissionID: submissionID,
+		QuizID:       f.quiz.ID,
+		Answers:      []models.Answer{answer(f.questions[1].ID, "B")},
+	})
+	if code != http.StatusOK {
+		t.Fatalf("submit: status %d", code)
+	}
+	if data["score"] != 1.0 || data["status"] != "evaluated" || data["passed"] != false {
+		t.Errorf("submit = %v", data)
+	}
+
+	stored, _ := f.db.Submissions().Get(context.Background(), submissionID)
+	if stored.Status != "evaluated" || len(stored.Answers) != 2 {
+		t.Errorf("stored submission: status %q, %d answers", stored.Status, len(stored.Answers))
+	}
+
+	code, _ = call(t, SubmitQuiz, f.student, models.SubmitQuizRequest{SubmissionID: submissionID, QuizID: f.quiz.ID})
+	if code != http.StatusBadRequest {
+		t.Errorf("second submit: status %d, want %d", code, http.StatusBadRequest)
+	}
+}
+
+func TestSaveProgressVersionConflict(t *testing.T) {
+	f := newAttemptFixture(t)
+	submissionID := f.start(t)
+
+	save := func(version int, option string) (int, map[string]interface{}) {
+		return call(t, SaveProgress, f.student, models.SaveProgressRequest{
+			SubmissionID: submissionID,
+			Version:      version,
+			Answers:      []models.Answer{answer(f.questions[0].ID, option)},
+		})
+	}
+
+	if code, _ := save(0, "A"); code != http.StatusOK {
+		t.Fatalf("first save: status %d", code)
+	}
+
+	// A second tab still holding version 0 gets the stored copy back
+	code, data := save(0, "B")
+	if code != http.StatusConflict {
+		t.Fatalf("stale save: status %d, want %d", code, http.StatusConflict)
+	}
+	if data["version"] != 1.0 {
+		t.Errorf("conflict version = %v, want 1", data["version"])
+	}
+
+	stored, _ := f.db.Submissions().Get(context.Background(), submissionID)
+	if got := stored.Answers[0].SelectedOptions[0]; got != "A" {
+		t.Errorf("stale save overwrote answer with %q", got)
+	}
+
+	if code, _ := save(1, "B"); code != http.StatusOK {
+		t.Errorf("retry at current version: status %d", code)
+	}
+}
+
+func TestStartQuizRequiresEnrollment(t *testing.T) {
+	f := newAttemptFixture(t)
+	outsider := authtest.NewHS256([]byte("test-secret")).Student("outsider")
+
+	code, _ := call(t, StartQuiz, outsider, map[string]string{"quizId": f.quiz.ID})
+	if code != http.StatusForbidden {
+		t.Errorf("status %d, want %d", code, http.StatusForbidden)
+	}
+}
+
+func TestViewQuestionKeepsVersion(t *testing.T) {
+	f := newAttemptFixture(t)
+	submissionID := f.start(t)
+	view := models.QuestionViewRequest{SubmissionID: submissionID, QuestionID: f.questions[0].ID}
+
+	code, data := call(t, ViewQuestion, f.student, view)
+	if code != http.StatusOK || data["version"] != 0.0 {
+		t.Fatalf("view: status %d, version %v", code, data["version"])
+	}
+	viewedAt := data["viewedAt"]
+
+	// An autosave sent at the version the client already had still applies,
+	// and does not drop the recorded view
+	code, _ = call(t, SaveProgress, f.student, models.SaveProgressRequest{
+		SubmissionID: submissionID,
+		Answers:      []models.Answer{answer(f.questions[0].ID, "A")},
+	})
+	if code != http.StatusOK {
+		t.Fatalf("save after view: status %d", code)
+	}
+
+	// Viewing again keeps the first timestamp
+	code, data = call(t, ViewQuestion, f.student, view)
+	if code != http.StatusOK || data["viewedAt"] != viewedAt {
+		t.Errorf("second view: status %d, viewedAt %v, want %v", code, data["viewedAt"], viewedAt)
+	}
+
+	stored, _ := f.db.Submissions().Get(context.Background(), submissionID)
+	if stored.Version != 1 || len(stored.QuestionViews) != 1 {
+		t.Errorf("stored version %d with %d views, want 1 and 1", stored.Version, len(stored.QuestionViews))
+	}
+}
+
+func TestViewQuestionAfterWindowCloses(t *testing.T) {
+	f := newAttemptFixture(t)
+	submissionID := f.start(t)
+
+	closed := time.Now().Add(-time.Hour)
+	quiz, _ := f.db.Quizzes().Get(context.Background(), f.quiz.ID)
+	quiz.EndDate = &closed
+	f.db.Quizzes().Save(context.Background(), quiz)
+
+	code, _ := call(t, ViewQuestion, f.student, models.QuestionViewRequest{SubmissionID: submissionID, QuestionID: f.questions[0].ID})
+	if code != http.StatusForbidden {
+		t.Errorf("status %d, want %d", code, http.StatusForbidden)
+	}
+}
+
+func TestTimedQuestionsHiddenUntilViewed(t *testing.T) {
+	f := newAttemptFixture(t)
+	ctx := context.Background()
+	quiz, _ := f.db.Quizzes().Get(ctx, f.quiz.ID)
+	quiz.TimePerQuestion = 30
+	f.db.Quizzes().Save(ctx, quiz)
+
+	hidden := func(data map[string]interface{}) {
+		t.Helper()
+		questions := data["submission"].(map[string]interface{})["questions"].([]interface{})
+		if len(questions) != len(f.questions) {
+			t.Fatalf("got %d questions, want %d", len(questions), len(f.questions))
+		}
+		for _, q := range questions {
+			q := q.(map[string]interface{})
+			if q["id"] == "" || q["text"] != "" || q["options"] != nil {
+				t.Errorf("question sent before it was viewed: %v", q)
+			}
+		}
+	}
+
+	code, data := call(t, StartQuiz, f.student, map[string]string{"quizId": f.quiz.ID})
+	if code != http.StatusOK {
+		t.Fatalf("start: status %d", code)
+	}
+	hidden(data)
+	submissionID := data["submission"].(map[string]interface{})["id"].(string)
+
+	code, data = call(t, ViewQuestion, f.student, models.QuestionViewRequest{SubmissionID: submissionID, QuestionID: f.questions[0].ID})
+	if code != http.StatusOK {
+		t.Fatalf("view: status %d", code)
+	}
+	question := data["question"].(map[string]interface{})
+	if question["text"] != "Question" || len(question["options"].([]interface{})) != 2 {
+		t.Errorf("viewed question = %v", question)
+	}
+	for _, option := range question["options"].([]interface{}) {
+		if option.(map[string]interface{})["isCorrect"] == true {
+			t.Errorf("viewed question reveals the answer: %v", option)
+		}
+	}
+
+	// Resuming does not reveal the questions either
+	_, data = call(t, StartQuiz, f.student, map[string]string{"quizId": f.quiz.ID})
+	hidden(data)
+}
+
+func TestStudentResultsHideAnswerKeys(t *testing.T) {
+	f := newAttemptFixture(t)
+	ctx := context.Background()
+	submissionID := f.start(t)
+
+	code, _ := call(t, SubmitQuiz, f.student, models.SubmitQuizRequest{
+		SubmissionID: submissionID,
+		QuizID:       f.quiz.ID,
+		Answers:      []models.Answer{answer(f.questions[0].ID, "A"), answer(f.questions[1].ID, "B")},
+	})
+	if code != http.StatusOK {
+		t.Fatalf("submit: status %d", code)
+	}
+
+	results := func() map[string]interface{} {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/?submissionId="+submissionID, nil)
+		req.Header.Set("Authorization", authtest.Header(f.student))
+		rec := httptest.NewRecorder()
+		GetResults(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("results: status %d", rec.Code)
+		}
+		var resp struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		for _, q := range resp.Data["questions"].([]interface{}) {
+			for _, option := range q.(map[string]interface{})["options"].([]interface{}) {
+				if option.(map[string]interface{})["isCorrect"] == true {
+					t.Errorf("results reveal the answer key: %v", option)
+				}
+			}
+		}
+		return resp.Data
+	}
+
+	// Without ShowResultsAfterSubmit the answers carry no grading
+	for _, a := range results()["answers"].([]interface{}) {
+		a := a.(map[string]interface{})
+		if a["isCorrect"] == true || a["pointsAwarded"] != 0.0 {
+			t.Errorf("hidden results graded an answer: %v", a)
+		}
+	}
+
+	quiz, _ := f.db.Quizzes().Get(ctx, f.quiz.ID)
+	quiz.ShowResultsAfterSubmit = true
+	f.db.Quizzes().Save(ctx, quiz)
+
+	correct := 0
+	for _, a := range results()["answers"].([]interface{}) {
+		if a.(map[string]interface{})["isCorrect"] == true {
+			correct++
+		}
+	}
+	if correct != 1 {
+		t.Errorf("shown results marked %d answers correct, want 1", correct)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
 )
 
@@ -64,6 +65,25 @@ func CreateQuiz(w http.ResponseWriter, r *http.Request) {
 			utils.RespondError(w, http.StatusBadRequest, "Duration must be greater than 0")
 			return
 		}
+		if req.NegativeMarking && req.NegativeMarkValue <= 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Negative mark value must be greater than 0")
+			return
+		}
+
+		if !ValidAttemptPolicy(req.AttemptPolicy) {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid attempt policy. Must be best, latest, or average")
+			return
+		}
+
+		if err := validateDrawRules(req.DrawRules); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid draw rules: "+err.Error())
+			return
+		}
+
+		if err := validateWindow(req.StartDate, req.EndDate); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 
 		// Validate deadline is in future (if provided)
 		if !req.Deadline.IsZero() && req.Deadline.Before(time.Now()) {
@@ -71,23 +91,20 @@ func CreateQuiz(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Get Firestore client
-		firestoreClient, err := utils.GetFirestoreClient(ctx)
+		// Get data store
+		db, err := store.GetStore(ctx)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
 			return
 		}
 
 		// Verify course exists and user is the teacher or admin
-		courseRef := firestoreClient.Collection("courses").Doc(req.CourseID)
-		courseDoc, err := courseRef.Get(ctx)
-		if err != nil {
+		course, err := db.Courses().Get(ctx, req.CourseID)
+		if err == store.ErrNotFound {
 			utils.RespondError(w, http.StatusNotFound, "Course not found")
 			return
 		}
-
-		var course models.Course
-		if err := courseDoc.DataTo(&course); err != nil {
+		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse course data")
 			return
 		}
@@ -107,13 +124,20 @@ func CreateQuiz(w http.ResponseWriter, r *http.Request) {
 			TeacherID:            userID,
 			TotalMarks:           req.TotalMarks,
 			PassingMarks:         req.PassingMarks,
+			NegativeMarking:      req.NegativeMarking,
+			NegativeMarkValue:    req.NegativeMarkValue,
+			AllowNegativeScore:   req.AllowNegativeScore,
 			Duration:             req.Duration,
 			Instructions:         req.Instructions,
 			Deadline:             req.Deadline,
+			StartDate:            req.StartDate,
+			EndDate:              req.EndDate,
+			DrawRules:            req.DrawRules,
 			ShowResultsAfterSubmit: req.ShowResultsAfterSubmit,
 			ShuffleQuestions:     req.ShuffleQuestions,
 			ShuffleOptions:       req.ShuffleOptions,
 			MaxAttempts:          req.MaxAttempts,
+			AttemptPolicy:        req.AttemptPolicy,
 			AllowReview:          req.AllowReview,
 			
 			// Cheating prevention features
@@ -131,7 +155,7 @@ func CreateQuiz(w http.ResponseWriter, r *http.Request) {
 			AllowTeacherExtendTime: req.AllowTeacherExtendTime,
 			
 			QuestionCount:        0,
-			IsPublished:          req.IsPublished,
+			IsPublished:          false, // Published through the publish endpoint once it has questions
 			CreatedAt:            now,
 			UpdatedAt:            now,
 		}
@@ -144,11 +168,8 @@ func CreateQuiz(w http.ResponseWriter, r *http.Request) {
 			quiz.MaxTabSwitches = 3 // Default to 3 tab switches
 		}
 
-		// Save quiz to Firestore
-		quizRef := firestoreClient.Collection("quizzes").NewDoc()
-		quiz.ID = quizRef.ID
-
-		if _, err := quizRef.Set(ctx, quiz); err != nil {
+		// Save quiz
+		if err := db.Quizzes().Create(ctx, &quiz); err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to create quiz")
 			return
 		}
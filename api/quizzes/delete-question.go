@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler removes a question from a quiz that no student has attempted yet
+// (teacher/admin only)
+func DeleteQuestion(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow DELETE or POST
+	if r.Method != http.MethodDelete && r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		// Parse request body
+		var req struct {
+			QuestionID string `json:"questionId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.QuestionID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Question ID is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		question, quiz, ok := ownedQuizQuestion(w, r, db, req.QuestionID, userID, role)
+		if !ok {
+			return
+		}
+
+		// The store rejects the delete once StartQuiz has marked the quiz
+		// attempted; quizzes attempted before that flag existed are caught here
+		attempted := quiz.Attempted
+		if !attempted {
+			attempted, err = QuizHasAttempts(ctx, db, quiz.ID)
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to check quiz attempts")
+				return
+			}
+		}
+		if attempted {
+			utils.RespondError(w, http.StatusConflict, "Students have already attempted this quiz; questions can no longer be removed")
+			return
+		}
+
+		// Remove the question and correct the quiz's count and total marks together
+		err = db.Questions().DeleteQuizQuestion(ctx, quiz.ID, question.ID, CountsQuestionMarks(*quiz))
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Question not found")
+			return
+		}
+		if err == store.ErrQuizAttempted {
+			utils.RespondError(w, http.StatusConflict, "Students have already attempted this quiz; questions can no longer be removed")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to delete question")
+			return
+		}
+
+		utils.RespondSuccess(w, nil, "Question deleted successfully")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
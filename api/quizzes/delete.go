@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler soft-deletes a quiz (teacher of the course or admin). Submissions
+// are kept for the record.
+func DeleteQuiz(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow DELETE or POST
+	if r.Method != http.MethodDelete && r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		// Quiz ID from the query string or the body
+		quizID := r.URL.Query().Get("id")
+		if quizID == "" {
+			var req struct {
+				QuizID string `json:"quizId"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+				return
+			}
+			quizID = req.QuizID
+		}
+		if quizID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Quiz ID is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		quiz, ok := OwnedQuiz(w, r, db, quizID, userID, role)
+		if !ok {
+			return
+		}
+
+		// Soft delete; it also comes off students' lists
+		quiz.IsDeleted = true
+		quiz.IsPublished = false
+		quiz.UpdatedAt = time.Now()
+		if err := db.Quizzes().Save(ctx, quiz); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to delete quiz")
+			return
+		}
+
+		utils.RespondSuccess(w, map[string]string{"quizId": quizID}, "Quiz deleted successfully")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+var validAttemptPolicies = map[string]bool{"best": true, "latest": true, "average": true}
+
+// ValidAttemptPolicy reports whether policy is empty or a known way of
+// combining a student's attempts in the gradebook
+func ValidAttemptPolicy(policy string) bool {
+	return policy == "" || validAttemptPolicies[policy]
+}
+
+// validateDrawRules checks the shape of a quiz's draw rules. Whether the pools
+// hold enough questions is only known once questions are added, so that is
+// checked when an attempt starts.
+func validateDrawRules(rules []models.DrawRule) error {
+	for i, rule := range rules {
+		if strings.TrimSpace(rule.Pool) == "" {
+			return fmt.Errorf("draw rule %d: pool is required", i+1)
+		}
+		if rule.Count <= 0 {
+			return fmt.Errorf("draw rule %d: count must be greater than 0", i+1)
+		}
+		if !utils.ValidDifficulty(rule.Difficulty) {
+			return fmt.Errorf("draw rule %d: difficulty must be easy, medium or hard", i+1)
+		}
+		if rule.Points < 0 {
+			return fmt.Errorf("draw rule %d: points cannot be negative", i+1)
+		}
+	}
+	return nil
+}
+
+// attemptQuestionSet builds the questions an attempt is given: drawn from the
+// pools when the quiz has draw rules, then shuffled as the quiz asks. The same
+// seed and quiz questions always give the same set in the same order.
+func attemptQuestionSet(quiz models.Quiz, questions []models.Question, seed int64) ([]models.Question, error) {
+	set := startingOrder(questions)
+
+	if len(quiz.DrawRules) > 0 {
+		drawn, err := drawQuestions(quiz.DrawRules, set, utils.DeriveSeed(seed, "draw"))
+		if err != nil {
+			return nil, err
+		}
+		set = drawn
+	}
+
+	if quiz.ShuffleQuestions || quiz.RandomizeQuestionOrder {
+		utils.ShuffleQuestions(&set, utils.DeriveSeed(seed, "questions"))
+	}
+
+	if quiz.ShuffleOptions {
+		for i := range set {
+			options := make([]models.QuestionOption, len(set[i].Options))
+			copy(options, set[i].Options)
+			utils.ShuffleOptions(&options, utils.DeriveSeed(seed, set[i].ID))
+			set[i].Options = options
+		}
+	}
+
+	return set, nil
+}
+
+// startingOrder copies questions into a fixed order before drawing and
+// shuffling; the store only guarantees ordering by Order
+func startingOrder(questions []models.Question) []models.Question {
+	set := make([]models.Question, len(questions))
+	copy(set, questions)
+	sort.SliceStable(set, func(i, j int) bool {
+		if set[i].Order != set[j].Order {
+			return set[i].Order < set[j].Order
+		}
+		return set[i].ID < set[j].ID
+	})
+	return set
+}
+
+// replayInputs records everything attemptQuestionSet reads from the quiz and
+// its questions, so an attempt can be replayed after either changes
+func replayInputs(quiz models.Quiz, questions []models.Question) *models.ReplayInputs {
+	inputs := &models.ReplayInputs{
+		DrawRules:              quiz.DrawRules,
+		ShuffleQuestions:       quiz.ShuffleQuestions,
+		ShuffleOptions:         quiz.ShuffleOptions,
+		RandomizeQuestionOrder: quiz.RandomizeQuestionOrder,
+	}
+	for _, q := range startingOrder(questions) {
+		optionIDs := make([]string, len(q.Options))
+		for i, opt := range q.Options {
+			optionIDs[i] = opt.ID
+		}
+		inputs.Questions = append(inputs.Questions, models.ReplayQuestion{
+			ID:         q.ID,
+			Pool:       q.Pool,
+			Difficulty: q.Difficulty,
+			OptionIDs:  optionIDs,
+		})
+	}
+	return inputs
+}
+
+// replayQuestionSet regenerates an attempt's question set from its recorded
+// inputs. The questions carry only their IDs, pools and option IDs.
+func replayQuestionSet(inputs models.ReplayInputs, seed int64) ([]models.Question, error) {
+	quiz := models.Quiz{
+		DrawRules:              inputs.DrawRules,
+		ShuffleQuestions:       inputs.ShuffleQuestions,
+		ShuffleOptions:         inputs.ShuffleOptions,
+		RandomizeQuestionOrder: inputs.RandomizeQuestionOrder,
+	}
+	questions := make([]models.Question, len(inputs.Questions))
+	for i, q := range inputs.Questions {
+		questions[i] = models.Question{ID: q.ID, Pool: q.Pool, Difficulty: q.Difficulty, Order: i + 1}
+		for _, id := range q.OptionIDs {
+			questions[i].Options = append(questions[i].Options, models.QuestionOption{ID: id})
+		}
+	}
+	return attemptQuestionSet(quiz, questions, seed)
+}
+
+// drawQuestions picks each rule's questions at random from the quiz's pools.
+// A question is drawn at most once even when several rules could match it.
+func drawQuestions(rules []models.DrawRule, questions []models.Question, seed int64) ([]models.Question, error) {
+	rng := rand.New(rand.NewSource(seed))
+	used := make(map[string]bool)
+	drawn := make([]models.Question, 0)
+
+	for _, rule := range rules {
+		candidates := make([]models.Question, 0)
+		for _, q := range questions {
+			if used[q.ID] || !strings.EqualFold(q.Pool, rule.Pool) {
+				continue
+			}
+			if rule.Difficulty != "" && q.Difficulty != rule.Difficulty {
+				continue
+			}
+			candidates = append(candidates, q)
+		}
+
+		if len(candidates) < rule.Count {
+			level := ""
+			if rule.Difficulty != "" {
+				level = rule.Difficulty + " "
+			}
+			return nil, fmt.Errorf("pool %q has %d %squestions, %d needed", rule.Pool, len(candidates), level, rule.Count)
+		}
+
+		for _, i := range rng.Perm(len(candidates))[:rule.Count] {
+			q := candidates[i]
+			if rule.Points > 0 {
+				q.Points = rule.Points
+				q.Marks = rule.Points
+			}
+			used[q.ID] = true
+			drawn = append(drawn, q)
+		}
+	}
+
+	return drawn, nil
+}
+
+// sumPoints totals the points available across questions
+func sumPoints(questions []models.Question) float64 {
+	total := 0.0
+	for _, q := range questions {
+		total += q.Points
+	}
+	return total
+}
+
+// attemptTotalMarks is the mark an attempt is scored out of. Attempts record
+// their own total since drawn sets can differ between students; older
+// attempts fall back to the quiz total.
+func attemptTotalMarks(quiz models.Quiz, submission models.QuizSubmission) float64 {
+	if submission.TotalMarks > 0 {
+		return submission.TotalMarks
+	}
+	return quiz.TotalMarks
+}
+
+// attemptQuestions returns the questions an attempt is graded against: the
+// set stored when it started, or the quiz's questions for older attempts
+func attemptQuestions(ctx context.Context, db store.Store, submission models.QuizSubmission) ([]models.Question, error) {
+	if len(submission.Questions) > 0 {
+		return submission.Questions, nil
+	}
+	return db.Questions().ListByQuiz(ctx, submission.QuizID)
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+)
+
+// poolQuestions returns count questions per difficulty in pool, each with
+// four options
+func poolQuestions(pool string, count int, difficulties ...string) []models.Question {
+	questions := make([]models.Question, 0)
+	for _, difficulty := range difficulties {
+		for i := 0; i < count; i++ {
+			question := mcq(1, "A")
+			question.ID = fmt.Sprintf("%s-%s-%d", pool, difficulty, i)
+			question.Pool = pool
+			question.Difficulty = difficulty
+			question.Order = len(questions) + 1
+			questions = append(questions, question)
+		}
+	}
+	return questions
+}
+
+func questionIDs(questions []models.Question) []string {
+	ids := make([]string, len(questions))
+	for i, q := range questions {
+		ids[i] = q.ID
+	}
+	return ids
+}
+
+func TestDrawQuestionsSameSeed(t *testing.T) {
+	questions := append(poolQuestions("algebra", 5, "easy", "hard"), poolQuestions("geometry", 5, "medium")...)
+	rules := []models.DrawRule{
+		{Pool: "algebra", Difficulty: "hard", Count: 2, Points: 3},
+		{Pool: "algebra", Count: 3},
+		{Pool: "Geometry", Count: 2},
+	}
+
+	first, err := drawQuestions(rules, questions, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := drawQuestions(rules, questions, 42)
+	if fmt.Sprint(questionIDs(first)) != fmt.Sprint(questionIDs(second)) {
+		t.Errorf("same seed drew %v then %v", questionIDs(first), questionIDs(second))
+	}
+
+	if len(first) != 7 {
+		t.Fatalf("drew %d questions, want 7", len(first))
+	}
+	seen := make(map[string]bool)
+	for i, q := range first {
+		if seen[q.ID] {
+			t.Errorf("question %s drawn twice", q.ID)
+		}
+		seen[q.ID] = true
+
+		switch {
+		case i < 2 && (q.Pool != "algebra" || q.Difficulty != "hard" || q.Points != 3):
+			t.Errorf("question %d = %s worth %v, want a 3 point hard algebra question", i, q.ID, q.Points)
+		case i >= 2 && i < 5 && q.Pool != "algebra":
+			t.Errorf("question %d = %s, want an algebra question", i, q.ID)
+		case i >= 5 && q.Pool != "geometry":
+			t.Errorf("question %d = %s, want a geometry question", i, q.ID)
+		}
+	}
+}
+
+func TestDrawQuestionsPoolTooSmall(t *testing.T) {
+	questions := poolQuestions("algebra", 2, "easy")
+	_, err := drawQuestions([]models.DrawRule{{Pool: "algebra", Difficulty: "easy", Count: 3}}, questions, 1)
+	if err == nil {
+		t.Error("drew 3 questions from a pool of 2")
+	}
+}
+
+func TestAttemptQuestionSetSameSeed(t *testing.T) {
+	quiz := models.Quiz{
+		ShuffleQuestions: true,
+		ShuffleOptions:   true,
+		DrawRules:        []models.DrawRule{{Pool: "algebra", Count: 4}},
+	}
+	questions := poolQuestions("algebra", 4, "easy", "hard")
+
+	// The store may list questions in any order
+	reversed := make([]models.Question, len(questions))
+	for i, q := range questions {
+		reversed[len(questions)-1-i] = q
+	}
+
+	first, err := attemptQuestionSet(quiz, questions, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := attemptQuestionSet(quiz, reversed, 7)
+	if !sameQuestionOrder(first, second) {
+		t.Errorf("same seed gave %v then %v", questionIDs(first), questionIDs(second))
+	}
+
+	// The caller's options are left in place
+	if questions[0].Options[0].ID != "A" {
+		t.Errorf("shuffling reordered the source options")
+	}
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler exports a quiz's completed attempts as CSV or XLSX, one row per
+// attempt with per-question points (teacher/admin only)
+func ExportResults(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow GET
+	if r.Method != http.MethodGet {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		quizID := r.URL.Query().Get("quizId")
+		if quizID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Quiz ID is required")
+			return
+		}
+		format, ok := utils.ExportFormat(r)
+		if !ok {
+			utils.RespondError(w, http.StatusBadRequest, "Format must be csv or xlsx")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		quiz, ok := OwnedQuiz(w, r, db, quizID, userID, role)
+		if !ok {
+			return
+		}
+
+		questions, err := db.Questions().ListByQuiz(ctx, quizID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch questions")
+			return
+		}
+
+		submissions, err := db.Submissions().List(ctx, store.SubmissionFilter{
+			QuizID:   quizID,
+			Statuses: []string{"submitted", "evaluated"},
+		})
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch results")
+			return
+		}
+
+		users := make(map[string]*models.User)
+		for _, sub := range submissions {
+			if _, seen := users[sub.StudentID]; !seen {
+				users[sub.StudentID], _ = db.Users().Get(ctx, sub.StudentID)
+			}
+		}
+
+		utils.RespondSheet(w, format, "quiz-"+quiz.ID+"-results", resultsSheet(*quiz, questions, submissions, users))
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
+
+// resultsSheet lays out one row per attempt. Question columns follow the
+// quiz's question order; a blank cell means the attempt was not given that
+// question (drawn quizzes) and 0 means it was given but scored nothing.
+func resultsSheet(quiz models.Quiz, questions []models.Question, submissions []models.QuizSubmission, users map[string]*models.User) utils.Sheet {
+	sort.SliceStable(questions, func(i, j int) bool { return questions[i].Order < questions[j].Order })
+	sort.SliceStable(submissions, func(i, j int) bool {
+		if submissions[i].StudentID != submissions[j].StudentID {
+			return submissions[i].StudentID < submissions[j].StudentID
+		}
+		return submissions[i].AttemptNumber < submissions[j].AttemptNumber
+	})
+
+	sheet := utils.Sheet{
+		Name:   quiz.Title,
+		Header: []string{"Roll Number", "Student Name", "Email", "Attempt", "Status", "Started At", "Submitted At"},
+	}
+	for i := range questions {
+		sheet.Header = append(sheet.Header, fmt.Sprintf("Q%d", i+1))
+	}
+	sheet.Header = append(sheet.Header, "Score", "Total Marks", "Percentage", "Passed")
+
+	for _, sub := range submissions {
+		var rollNumber, name, email string
+		if user := users[sub.StudentID]; user != nil {
+			rollNumber = user.Metadata.RollNumber
+			name = user.DisplayName
+			email = user.Email
+		}
+
+		row := []interface{}{rollNumber, name, email, sub.AttemptNumber, sub.Status, sub.StartedAt, sub.SubmittedAt}
+		for _, q := range questions {
+			row = append(row, questionPoints(sub, q.ID))
+		}
+		row = append(row, sub.Score, attemptTotalMarks(quiz, sub), roundPoints(sub.Percentage), sub.Passed)
+		sheet.Rows = append(sheet.Rows, row)
+	}
+	return sheet
+}
+
+// questionPoints returns the net points an attempt earned on a question, or
+// nil when the attempt was not given it
+func questionPoints(submission models.QuizSubmission, questionID string) *float64 {
+	for _, a := range submission.Answers {
+		if a.QuestionID == questionID {
+			points := roundPoints(a.PointsAwarded - a.PointsDeducted)
+			return &points
+		}
+	}
+
+	given := len(submission.Questions) == 0
+	for _, q := range submission.Questions {
+		if q.ID == questionID {
+			given = true
+			break
+		}
+	}
+	if !given {
+		return nil
+	}
+	zero := 0.0
+	return &zero
+}
@@ -2,8 +2,9 @@ package handler
 
 import (
 	"net/http"
+	"time"
 
-	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
 )
 
@@ -35,26 +36,23 @@ func GetQuiz(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Get Firestore client
-		firestoreClient, err := utils.GetFirestoreClient(ctx)
+		// Get data store
+		db, err := store.GetStore(ctx)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
 			return
 		}
 
 		// Get quiz
-		quizDoc, err := firestoreClient.Collection("quizzes").Doc(quizID).Get(ctx)
-		if err != nil {
+		quiz, err := db.Quizzes().Get(ctx, quizID)
+		if err == store.ErrNotFound || (err == nil && quiz.IsDeleted) {
 			utils.RespondError(w, http.StatusNotFound, "Quiz not found")
 			return
 		}
-
-		var quiz models.Quiz
-		if err := quizDoc.DataTo(&quiz); err != nil {
+		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse quiz data")
 			return
 		}
-		quiz.ID = quizDoc.Ref.ID
 
 		// Authorization checks
 		if role == "student" {
@@ -65,16 +63,23 @@ func GetQuiz(w http.ResponseWriter, r *http.Request) {
 			}
 
 			// Verify student is enrolled in the course
-			enrollmentQuery := firestoreClient.Collection("enrollments").
-				Where("studentId", "==", userID).
-				Where("courseId", "==", quiz.CourseID).
-				Where("status", "==", "active")
-
-			enrollDocs, err := enrollmentQuery.Documents(ctx).GetAll()
-			if err != nil || len(enrollDocs) == 0 {
+			enrollments, err := db.Enrollments().List(ctx, store.EnrollmentFilter{
+				StudentID: userID,
+				CourseID:  quiz.CourseID,
+				Status:    "active",
+			})
+			if err != nil || len(enrollments) == 0 {
 				utils.RespondError(w, http.StatusForbidden, "You must be enrolled in this course")
 				return
 			}
+
+			// Show when the quiz opens or closes for this student
+			accommodation, err := store.StudentAccommodation(ctx, db, userID, quiz.CourseID)
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch accommodations")
+				return
+			}
+			*quiz = studentView(withAccommodation(*quiz, accommodation), userID, time.Now())
 		} else if role == "teacher" {
 			// Teachers can only see their own quizzes
 			if quiz.TeacherID != userID {
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler saves manual grades for written answers and recomputes the score (teacher/admin only)
+func GradeSubmission(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate and authorize (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _, role := utils.GetUserFromContext(ctx)
+
+		// Parse request body
+		var req models.GradeQuizRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.SubmissionID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Submission ID is required")
+			return
+		}
+		if len(req.Evaluations) == 0 {
+			utils.RespondError(w, http.StatusBadRequest, "At least one evaluation is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		// Get submission
+		submission, err := db.Submissions().Get(ctx, req.SubmissionID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Submission not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse submission data")
+			return
+		}
+
+		if submission.Status != "submitted" && submission.Status != "evaluated" {
+			utils.RespondError(w, http.StatusBadRequest, "Only completed submissions can be graded")
+			return
+		}
+
+		// Get quiz and verify the caller teaches its course
+		quiz, ok := OwnedQuiz(w, r, db, submission.QuizID, userID, role)
+		if !ok {
+			return
+		}
+
+		// Get the attempt's questions for maximum points
+		questionList, err := attemptQuestions(ctx, db, *submission)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch questions")
+			return
+		}
+
+		questions := make(map[string]models.Question)
+		for _, q := range questionList {
+			questions[q.ID] = q
+		}
+
+		answerIndex := make(map[string]int)
+		for i, answer := range submission.Answers {
+			answerIndex[answer.QuestionID] = i
+		}
+
+		// Apply grades to written answers
+		for _, evaluation := range req.Evaluations {
+			i, ok := answerIndex[evaluation.QuestionID]
+			if !ok {
+				utils.RespondError(w, http.StatusBadRequest, fmt.Sprintf("No answer found for question %s", evaluation.QuestionID))
+				return
+			}
+
+			question := questions[evaluation.QuestionID]
+			if utils.IsObjectiveQuestion(question.Type) {
+				utils.RespondError(w, http.StatusBadRequest, fmt.Sprintf("Question %s is auto-graded", evaluation.QuestionID))
+				return
+			}
+			if evaluation.MarksAwarded < 0 || evaluation.MarksAwarded > question.Points {
+				utils.RespondError(w, http.StatusBadRequest, fmt.Sprintf("Points for question %s must be between 0 and %g", evaluation.QuestionID, question.Points))
+				return
+			}
+
+			submission.Answers[i].PointsAwarded = evaluation.MarksAwarded
+			submission.Answers[i].IsCorrect = evaluation.MarksAwarded == question.Points
+			submission.Answers[i].Feedback = evaluation.TeacherFeedback
+			submission.Answers[i].NeedsReview = false
+		}
+
+		// Recompute the result
+		score := calculateScore(*quiz, submission.Answers)
+		percentage := 0.0
+		if totalMarks := attemptTotalMarks(*quiz, *submission); totalMarks > 0 {
+			percentage = (score / totalMarks) * 100
+		}
+		passed := score >= quiz.PassingMarks
+
+		status := "evaluated"
+		pendingReview := pendingReviewCount(submission.Answers)
+		if pendingReview > 0 {
+			status = "submitted"
+		}
+
+		now := time.Now()
+		submission.Score = score
+		submission.Percentage = percentage
+		submission.Passed = passed
+		submission.Status = status
+		submission.EvaluatedAt = &now
+		submission.EvaluatedBy = userID
+		submission.UpdatedAt = now
+
+		// Fail rather than overwrite grades or a regrade saved meanwhile
+		err = db.Submissions().SaveIfVersion(ctx, submission, submission.Version)
+		if err == store.ErrConflict {
+			utils.RespondError(w, http.StatusConflict, "Submission changed while grading, please reload and retry")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to save grades")
+			return
+		}
+
+		// Notify the student once every answer is graded
+		if status == "evaluated" {
+			notification := models.Notification{
+				UserID:        submission.StudentID,
+				Type:          "grade_released",
+				Title:         "Quiz Graded",
+				Message:       "Your quiz has been graded: " + quiz.Title,
+				ReferenceID:   submission.QuizID,
+				ReferenceType: "quiz",
+				IsRead:        false,
+				CreatedAt:     now,
+			}
+			db.Notifications().Create(ctx, &notification)
+		}
+
+		utils.RespondSuccess(w, map[string]interface{}{
+			"submissionId":  req.SubmissionID,
+			"score":         score,
+			"percentage":    percentage,
+			"passed":        passed,
+			"status":        status,
+			"pendingReview": pendingReview,
+		}, "Grades saved successfully")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
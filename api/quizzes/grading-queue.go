@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler lists written answers awaiting manual grading for a quiz (teacher/admin only)
+func GetGradingQueue(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow GET
+	if r.Method != http.MethodGet {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate and authorize (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _, role := utils.GetUserFromContext(ctx)
+
+		quizID := r.URL.Query().Get("quizId")
+		if quizID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Quiz ID is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		// Get quiz and verify the caller teaches its course
+		if _, ok := OwnedQuiz(w, r, db, quizID, userID, role); !ok {
+			return
+		}
+
+		// Get questions so graders see the prompt and maximum points
+		questionList, err := db.Questions().ListByQuiz(ctx, quizID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch questions")
+			return
+		}
+
+		questions := make(map[string]models.Question)
+		for _, q := range questionList {
+			questions[q.ID] = q
+		}
+
+		// Submissions pending review
+		submissions, err := db.Submissions().List(ctx, store.SubmissionFilter{
+			QuizID:   quizID,
+			Statuses: []string{"submitted"},
+		})
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch submissions")
+			return
+		}
+
+		// Oldest first
+		pending := make([]map[string]interface{}, 0)
+		for i := len(submissions) - 1; i >= 0; i-- {
+			submission := submissions[i]
+
+			// Drawn attempts may carry their own points for a question
+			asked := make(map[string]models.Question)
+			for _, q := range submission.Questions {
+				asked[q.ID] = q
+			}
+
+			for _, answer := range submission.Answers {
+				if !answer.NeedsReview {
+					continue
+				}
+
+				question, ok := asked[answer.QuestionID]
+				if !ok {
+					question = questions[answer.QuestionID]
+				}
+				pending = append(pending, map[string]interface{}{
+					"submissionId": submission.ID,
+					"studentId":    submission.StudentID,
+					"studentName":  submission.StudentName,
+					"submittedAt":  submission.SubmittedAt,
+					"questionId":   answer.QuestionID,
+					"questionType": question.Type,
+					"questionText": question.Text,
+					"maxPoints":    question.Points,
+					"textAnswer":   answer.TextAnswer,
+				})
+			}
+		}
+
+		utils.RespondSuccess(w, map[string]interface{}{
+			"quizId":  quizID,
+			"pending": pending,
+			"total":   len(pending),
+		}, "Grading queue fetched successfully")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
@@ -3,10 +3,10 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"time"
 
-	"cloud.google.com/go/firestore"
-	"google.golang.org/api/iterator"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
 )
 
@@ -40,20 +40,15 @@ func ListQuizzes(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
-		// Get Firestore client
-		firestoreClient, err := utils.GetFirestoreClient(ctx)
+		// Get data store
+		db, err := store.GetStore(ctx)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
 			return
 		}
 
-		// Build query based on role
-		query := firestoreClient.Collection("quizzes").Query
-
-		// Filter by course if provided
-		if courseID != "" {
-			query = query.Where("courseId", "==", courseID)
-		}
+		// Build filter based on role
+		filter := store.QuizFilter{CourseID: courseID, Limit: limit}
 
 		// Role-based filtering
 		switch role {
@@ -62,19 +57,18 @@ func ListQuizzes(w http.ResponseWriter, r *http.Request) {
 			break
 		case "teacher":
 			// Teachers see only their quizzes
-			query = query.Where("teacherId", "==", userID)
+			filter.TeacherID = userID
 		case "student":
 			// Students see only published quizzes for courses they're enrolled in
-			query = query.Where("isPublished", "==", true)
-			
+			filter.PublishedOnly = true
+
 			// If no specific course, we need to get enrolled courses first
 			if courseID == "" {
 				// Get student's enrollments
-				enrollmentsQuery := firestoreClient.Collection("enrollments").
-					Where("studentId", "==", userID).
-					Where("status", "==", "active")
-				
-				enrollDocs, err := enrollmentsQuery.Documents(ctx).GetAll()
+				enrollments, err := db.Enrollments().List(ctx, store.EnrollmentFilter{
+					StudentID: userID,
+					Status:    "active",
+				})
 				if err != nil {
 					utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch enrollments")
 					return
@@ -82,11 +76,8 @@ func ListQuizzes(w http.ResponseWriter, r *http.Request) {
 
 				// Extract course IDs
 				courseIDs := make([]string, 0)
-				for _, doc := range enrollDocs {
-					var enrollment models.Enrollment
-					if err := doc.DataTo(&enrollment); err == nil {
-						courseIDs = append(courseIDs, enrollment.CourseID)
-					}
+				for _, enrollment := range enrollments {
+					courseIDs = append(courseIDs, enrollment.CourseID)
 				}
 
 				// If no enrollments, return empty array
@@ -96,38 +87,37 @@ func ListQuizzes(w http.ResponseWriter, r *http.Request) {
 				}
 
 				// Filter quizzes by enrolled courses
-				query = query.Where("courseId", "in", courseIDs)
+				filter.CourseIDs = courseIDs
 			}
 		default:
 			utils.RespondError(w, http.StatusForbidden, "Invalid role")
 			return
 		}
 
-		// Order by creation date (newest first)
-		query = query.OrderBy("createdAt", firestore.Desc).Limit(limit)
-
-		// Execute query
-		iter := query.Documents(ctx)
-		defer iter.Stop()
-
-		quizzes := make([]models.Quiz, 0)
-		for {
-			doc, err := iter.Next()
-			if err == iterator.Done {
-				break
-			}
-			if err != nil {
-				utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch quizzes")
-				return
-			}
+		// Execute query (newest first)
+		quizzes, err := db.Quizzes().List(ctx, filter)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch quizzes")
+			return
+		}
 
-			var quiz models.Quiz
-			if err := doc.DataTo(&quiz); err != nil {
-				continue
+		// Students see whether each quiz is upcoming, open or closed for them
+		if role == "student" {
+			now := time.Now()
+			accommodations := make(map[string]*models.Accommodation)
+			for i := range quizzes {
+				courseID := quizzes[i].CourseID
+				accommodation, seen := accommodations[courseID]
+				if !seen {
+					accommodation, err = store.StudentAccommodation(ctx, db, userID, courseID)
+					if err != nil {
+						utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch accommodations")
+						return
+					}
+					accommodations[courseID] = accommodation
+				}
+				quizzes[i] = studentView(withAccommodation(quizzes[i], accommodation), userID, now)
 			}
-
-			quiz.ID = doc.Ref.ID
-			quizzes = append(quizzes, quiz)
 		}
 
 		utils.RespondSuccess(w, quizzes, "Quizzes fetched successfully")
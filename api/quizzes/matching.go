@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+)
+
+// acceptedAnswerRules returns the question's rules, falling back to its
+// CorrectAnswer matched with whitespace and case normalised
+func acceptedAnswerRules(question models.Question) []models.AcceptedAnswer {
+	if len(question.AcceptedAnswers) > 0 {
+		return question.AcceptedAnswers
+	}
+	if strings.TrimSpace(question.CorrectAnswer) != "" {
+		return []models.AcceptedAnswer{{Value: question.CorrectAnswer, Match: models.MatchNormalized}}
+	}
+	return nil
+}
+
+// matchesAcceptedAnswer reports whether text satisfies any rule
+func matchesAcceptedAnswer(text string, rules []models.AcceptedAnswer) bool {
+	for _, rule := range rules {
+		if matchesRule(text, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesRule(text string, rule models.AcceptedAnswer) bool {
+	switch rule.Match {
+	case models.MatchCaseInsensitive:
+		return strings.EqualFold(strings.TrimSpace(text), strings.TrimSpace(rule.Value))
+	case models.MatchNormalized:
+		return normalizeAnswer(text) == normalizeAnswer(rule.Value)
+	case models.MatchNumeric:
+		got, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return false
+		}
+		want, err := strconv.ParseFloat(rule.Value, 64)
+		if err != nil {
+			return false
+		}
+		return math.Abs(got-want) <= rule.Tolerance
+	case models.MatchRegex:
+		// Rules must match the whole answer, not a fragment of it
+		re, err := regexp.Compile("^(?:" + rule.Value + ")$")
+		if err != nil {
+			return false
+		}
+		return re.MatchString(strings.TrimSpace(text))
+	}
+	return false
+}
+
+// normalizeAnswer lowercases and collapses runs of whitespace
+func normalizeAnswer(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+)
+
+func TestMatchesAcceptedAnswer(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		rules []models.AcceptedAnswer
+		want  bool
+	}{
+		{"no rules", "Paris", nil, false},
+		{"case insensitive", "  paris ", []models.AcceptedAnswer{{Value: "Paris", Match: models.MatchCaseInsensitive}}, true},
+		{"case insensitive keeps inner spacing", "new  york", []models.AcceptedAnswer{{Value: "New York", Match: models.MatchCaseInsensitive}}, false},
+		{"normalized collapses whitespace", "New\t  YORK", []models.AcceptedAnswer{{Value: "new york", Match: models.MatchNormalized}}, true},
+		{"numeric within tolerance", "3.14", []models.AcceptedAnswer{{Value: "3.1416", Match: models.MatchNumeric, Tolerance: 0.01}}, true},
+		{"numeric outside tolerance", "3.2", []models.AcceptedAnswer{{Value: "3.1416", Match: models.MatchNumeric, Tolerance: 0.01}}, false},
+		{"numeric rejects text", "pi", []models.AcceptedAnswer{{Value: "3.1416", Match: models.MatchNumeric, Tolerance: 0.01}}, false},
+		{"regex matches whole answer", "colour", []models.AcceptedAnswer{{Value: "colou?r", Match: models.MatchRegex}}, true},
+		{"regex rejects fragment", "watercolour", []models.AcceptedAnswer{{Value: "colou?r", Match: models.MatchRegex}}, false},
+		{"any rule matches", "42", []models.AcceptedAnswer{
+			{Value: "forty-two", Match: models.MatchNormalized},
+			{Value: "42", Match: models.MatchNumeric},
+		}, true},
+		{"unknown match type", "Paris", []models.AcceptedAnswer{{Value: "Paris", Match: "exact"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesAcceptedAnswer(tt.text, tt.rules); got != tt.want {
+				t.Errorf("matchesAcceptedAnswer(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateAnswerShortAnswer(t *testing.T) {
+	question := models.Question{
+		ID:              "q1",
+		Type:            "short_answer",
+		Points:          3,
+		AcceptedAnswers: []models.AcceptedAnswer{{Value: "Paris", Match: models.MatchCaseInsensitive}},
+	}
+	fallback := models.Question{ID: "q2", Type: "short_answer", Points: 3, CorrectAnswer: "New York"}
+	descriptive := models.Question{ID: "q3", Type: "descriptive", Points: 5, CorrectAnswer: "anything"}
+
+	tests := []struct {
+		name        string
+		question    models.Question
+		text        string
+		wantAwarded float64
+		wantReview  bool
+	}{
+		{"accepted answer", question, "paris", 3, false},
+		{"unmatched answer goes to review", question, "Lyon", 0, true},
+		{"blank answer scores zero", question, "   ", 0, false},
+		{"correct answer fallback", fallback, "new   york", 3, false},
+		{"descriptive always reviewed", descriptive, "anything", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluateAnswer(models.Quiz{}, tt.question, models.Answer{QuestionID: tt.question.ID, TextAnswer: tt.text})
+			if got.PointsAwarded != tt.wantAwarded || got.NeedsReview != tt.wantReview {
+				t.Errorf("got awarded=%v review=%v, want %v %v", got.PointsAwarded, got.NeedsReview, tt.wantAwarded, tt.wantReview)
+			}
+		})
+	}
+}
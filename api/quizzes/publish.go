@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler publishes or unpublishes a quiz (teacher of the course or admin)
+func PublishQuiz(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		// Parse request body
+		var req struct {
+			QuizID      string `json:"quizId"`
+			IsPublished bool   `json:"isPublished"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.QuizID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Quiz ID is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		quiz, ok := OwnedQuiz(w, r, db, req.QuizID, userID, role)
+		if !ok {
+			return
+		}
+
+		// A published quiz must be startable
+		if req.IsPublished {
+			questions, err := db.Questions().ListByQuiz(ctx, req.QuizID)
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch questions")
+				return
+			}
+			if len(questions) == 0 {
+				utils.RespondError(w, http.StatusBadRequest, "Add at least one question before publishing")
+				return
+			}
+			if quiz.PassingMarks > quiz.TotalMarks {
+				utils.RespondError(w, http.StatusBadRequest, "Passing marks cannot exceed total marks")
+				return
+			}
+			if len(quiz.DrawRules) > 0 {
+				if _, err := drawQuestions(quiz.DrawRules, questions, 0); err != nil {
+					utils.RespondError(w, http.StatusBadRequest, "Draw rules cannot be met: "+err.Error())
+					return
+				}
+			}
+		}
+
+		now := time.Now()
+		wasPublished := quiz.IsPublished
+		quiz.IsPublished = req.IsPublished
+		quiz.UpdatedAt = now
+
+		if err := db.Quizzes().Save(ctx, quiz); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to update quiz")
+			return
+		}
+
+		// Let enrolled students know about a newly published quiz
+		if req.IsPublished && !wasPublished {
+			enrollments, err := db.Enrollments().List(ctx, store.EnrollmentFilter{
+				CourseID: quiz.CourseID,
+				Status:   "active",
+			})
+			if err == nil {
+				for _, enrollment := range enrollments {
+					notification := models.Notification{
+						UserID:        enrollment.StudentID,
+						Type:          "quiz_published",
+						Title:         "New Quiz",
+						Message:       quiz.Title + " is now available",
+						ReferenceID:   quiz.ID,
+						ReferenceType: "quiz",
+						IsRead:        false,
+						CreatedAt:     now,
+					}
+					db.Notifications().Create(ctx, &notification)
+				}
+			}
+		}
+
+		message := "Quiz unpublished successfully"
+		if req.IsPublished {
+			message = "Quiz published successfully"
+		}
+		utils.RespondSuccess(w, quiz, message)
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+)
+
+// validQuestionTypes lists the question types a quiz can hold
+var validQuestionTypes = map[string]bool{
+	"mcq":          true,
+	"true_false":   true,
+	"short_answer": true,
+	"long_answer":  true,
+}
+
+// GradingChanged reports whether an edit changes how a question is drawn,
+// graded or scored, as opposed to only its wording
+func GradingChanged(before, after models.Question) bool {
+	return before.Type != after.Type ||
+		before.Points != after.Points ||
+		before.ScoringPolicy != after.ScoringPolicy ||
+		before.CorrectAnswer != after.CorrectAnswer ||
+		before.Pool != after.Pool ||
+		before.Difficulty != after.Difficulty ||
+		!reflect.DeepEqual(before.AcceptedAnswers, after.AcceptedAnswers) ||
+		!sameOptionKeys(before.Options, after.Options)
+}
+
+// sameOptionKeys reports whether two option lists have the same IDs and
+// correct answers; option text may differ
+func sameOptionKeys(a, b []models.QuestionOption) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ID != b[i].ID || a[i].IsCorrect != b[i].IsCorrect {
+			return false
+		}
+	}
+	return true
+}
+
+// QuizHasAttempts reports whether any student has started the quiz
+func QuizHasAttempts(ctx context.Context, db store.Store, quizID string) (bool, error) {
+	submissions, err := db.Submissions().List(ctx, store.SubmissionFilter{QuizID: quizID})
+	if err != nil {
+		return false, err
+	}
+	return len(submissions) > 0, nil
+}
+
+// CountsQuestionMarks reports whether a quiz's questions add to its total
+// marks. Drawn quizzes only ask some of their pool questions, so they don't.
+func CountsQuestionMarks(quiz models.Quiz) bool {
+	return len(quiz.DrawRules) == 0
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler sets the display order of a quiz's questions (teacher/admin only).
+// Attempts already started keep the order they were given.
+func ReorderQuestions(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		// Parse request body
+		var req struct {
+			QuizID      string   `json:"quizId"`
+			QuestionIDs []string `json:"questionIds"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.QuizID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Quiz ID is required")
+			return
+		}
+		if len(req.QuestionIDs) == 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Question IDs are required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		if _, ok := OwnedQuiz(w, r, db, req.QuizID, userID, role); !ok {
+			return
+		}
+
+		err = db.Questions().ReorderQuizQuestions(ctx, req.QuizID, req.QuestionIDs)
+		if err == store.ErrQuestionSet {
+			utils.RespondError(w, http.StatusBadRequest, "Question IDs must list every question in the quiz exactly once")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to reorder questions")
+			return
+		}
+
+		questions, err := db.Questions().ListByQuiz(ctx, req.QuizID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch questions")
+			return
+		}
+
+		utils.RespondSuccess(w, map[string]interface{}{
+			"questions": questions,
+		}, "Questions reordered successfully")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler regenerates the question set and order a student was given from the
+// attempt's shuffle seed, for resolving disputes (teacher/admin only)
+func ReplayAttempt(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow GET
+	if r.Method != http.MethodGet {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		submissionID := r.URL.Query().Get("submissionId")
+		if submissionID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Submission ID is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		submission, err := db.Submissions().Get(ctx, submissionID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Submission not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse submission data")
+			return
+		}
+
+		if _, ok := OwnedQuiz(w, r, db, submission.QuizID, userID, role); !ok {
+			return
+		}
+
+		if submission.ShuffleSeed == 0 || submission.ReplayInputs == nil {
+			utils.RespondError(w, http.StatusBadRequest, "This attempt was started before shuffle inputs were recorded")
+			return
+		}
+
+		// Regenerate from what the attempt was started with, so later edits
+		// to the quiz's questions or shuffle settings don't change the result
+		replayed, err := replayQuestionSet(*submission.ReplayInputs, submission.ShuffleSeed)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Attempt cannot be regenerated: "+err.Error())
+			return
+		}
+
+		// The stored set is what the student saw; a mismatch means the stored
+		// attempt was altered after it started
+		utils.RespondSuccess(w, map[string]interface{}{
+			"submissionId":  submission.ID,
+			"shuffleSeed":   submission.ShuffleSeed,
+			"questions":     withStoredBodies(replayed, submission.Questions),
+			"matchesStored": sameQuestionOrder(replayed, submission.Questions),
+		})
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
+
+// sameQuestionOrder reports whether two question sets have the same questions
+// with the same options, in the same order
+func sameQuestionOrder(a, b []models.Question) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ID != b[i].ID || len(a[i].Options) != len(b[i].Options) {
+			return false
+		}
+		for j := range a[i].Options {
+			if a[i].Options[j].ID != b[i].Options[j].ID {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// withStoredBodies fills replayed questions, which carry only IDs, with the
+// text and options stored on the attempt, keeping the replayed option order
+func withStoredBodies(replayed, stored []models.Question) []models.Question {
+	byID := make(map[string]models.Question, len(stored))
+	for _, q := range stored {
+		byID[q.ID] = q
+	}
+
+	filled := make([]models.Question, len(replayed))
+	for i, q := range replayed {
+		body, ok := byID[q.ID]
+		if !ok {
+			filled[i] = q
+			continue
+		}
+		options := make(map[string]models.QuestionOption, len(body.Options))
+		for _, opt := range body.Options {
+			options[opt.ID] = opt
+		}
+		body.Options = make([]models.QuestionOption, len(q.Options))
+		for j, opt := range q.Options {
+			if full, ok := options[opt.ID]; ok {
+				opt = full
+			}
+			body.Options[j] = opt
+		}
+		filled[i] = body
+	}
+	return filled
+}
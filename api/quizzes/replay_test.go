@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils/authtest"
+)
+
+func TestReplayAttemptMatchesStored(t *testing.T) {
+	f := newAttemptFixture(t)
+	ctx := context.Background()
+	quiz, _ := f.db.Quizzes().Get(ctx, f.quiz.ID)
+	quiz.ShuffleQuestions = true
+	quiz.ShuffleOptions = true
+	f.db.Quizzes().Save(ctx, quiz)
+
+	submissionID := f.start(t)
+	teacher := authtest.NewHS256([]byte("test-secret")).Teacher("teacher")
+
+	replay := func() map[string]interface{} {
+		req := httptest.NewRequest(http.MethodGet, "/?submissionId="+submissionID, nil)
+		req.Header.Set("Authorization", authtest.Header(teacher))
+		rec := httptest.NewRecorder()
+		ReplayAttempt(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("replay: status %d: %s", rec.Code, rec.Body.String())
+		}
+		var resp struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		return resp.Data
+	}
+
+	if data := replay(); data["matchesStored"] != true {
+		t.Errorf("replay did not match the stored attempt: %v", data)
+	}
+
+	// The replay uses what the attempt started with, not the quiz as it is now
+	quiz.ShuffleQuestions = false
+	quiz.ShuffleOptions = false
+	f.db.Quizzes().Save(ctx, quiz)
+	edited := f.questions[0]
+	edited.Options = append(edited.Options, models.QuestionOption{ID: "C", Text: "new"})
+	f.db.Questions().Save(ctx, &edited)
+	data := replay()
+	if data["matchesStored"] != true {
+		t.Errorf("replay changed after the quiz was edited: %v", data)
+	}
+	if questions := data["questions"].([]interface{}); len(questions) != 2 || questions[0].(map[string]interface{})["text"] != "Question" {
+		t.Errorf("replayed questions = %v", questions)
+	}
+}
@@ -3,9 +3,8 @@ package handler
 import (
 	"net/http"
 
-	"cloud.google.com/go/firestore"
-	"google.golang.org/api/iterator"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
 )
 
@@ -39,8 +38,8 @@ func GetResults(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Get Firestore client
-		firestoreClient, err := utils.GetFirestoreClient(ctx)
+		// Get data store
+		db, err := store.GetStore(ctx)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
 			return
@@ -48,18 +47,15 @@ func GetResults(w http.ResponseWriter, r *http.Request) {
 
 		// If specific submission requested
 		if submissionID != "" {
-			submissionDoc, err := firestoreClient.Collection("quiz_submissions").Doc(submissionID).Get(ctx)
-			if err != nil {
+			submission, err := db.Submissions().Get(ctx, submissionID)
+			if err == store.ErrNotFound {
 				utils.RespondError(w, http.StatusNotFound, "Submission not found")
 				return
 			}
-
-			var submission models.QuizSubmission
-			if err := submissionDoc.DataTo(&submission); err != nil {
+			if err != nil {
 				utils.RespondError(w, http.StatusInternalServerError, "Failed to parse submission data")
 				return
 			}
-			submission.ID = submissionDoc.Ref.ID
 
 			// Authorization check
 			if role == "student" && submission.StudentID != userID {
@@ -67,16 +63,28 @@ func GetResults(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 
+			// Students never see answer keys, and see grading only when the
+			// quiz shows results after submission
+			if role == "student" {
+				quiz, err := db.Quizzes().Get(ctx, submission.QuizID)
+				if err != nil && err != store.ErrNotFound {
+					utils.RespondError(w, http.StatusInternalServerError, "Failed to parse quiz data")
+					return
+				}
+				if quiz == nil {
+					quiz = &models.Quiz{}
+				}
+				studentResult(*quiz, submission)
+			}
+
 			if role == "teacher" {
 				// Verify teacher owns the quiz
-				quizDoc, err := firestoreClient.Collection("quizzes").Doc(submission.QuizID).Get(ctx)
-				if err != nil {
+				quiz, err := db.Quizzes().Get(ctx, submission.QuizID)
+				if err == store.ErrNotFound {
 					utils.RespondError(w, http.StatusForbidden, "Access denied")
 					return
 				}
-
-				var quiz models.Quiz
-				if err := quizDoc.DataTo(&quiz); err != nil {
+				if err != nil {
 					utils.RespondError(w, http.StatusInternalServerError, "Failed to parse quiz data")
 					return
 				}
@@ -88,13 +96,9 @@ func GetResults(w http.ResponseWriter, r *http.Request) {
 			}
 
 			// Get student details
-			studentDoc, err := firestoreClient.Collection("users").Doc(submission.StudentID).Get(ctx)
-			if err == nil {
-				var student models.User
-				if err := studentDoc.DataTo(&student); err == nil {
-					submission.StudentName = student.DisplayName
-					submission.StudentEmail = student.Email
-				}
+			if student, err := db.Users().Get(ctx, submission.StudentID); err == nil {
+				submission.StudentName = student.DisplayName
+				submission.StudentEmail = student.Email
 			}
 
 			utils.RespondSuccess(w, submission, "Results fetched successfully")
@@ -104,26 +108,25 @@ func GetResults(w http.ResponseWriter, r *http.Request) {
 		// If quiz results requested
 		if quizID != "" {
 			// Get quiz
-			quizDoc, err := firestoreClient.Collection("quizzes").Doc(quizID).Get(ctx)
-			if err != nil {
+			quiz, err := db.Quizzes().Get(ctx, quizID)
+			if err == store.ErrNotFound {
 				utils.RespondError(w, http.StatusNotFound, "Quiz not found")
 				return
 			}
-
-			var quiz models.Quiz
-			if err := quizDoc.DataTo(&quiz); err != nil {
+			if err != nil {
 				utils.RespondError(w, http.StatusInternalServerError, "Failed to parse quiz data")
 				return
 			}
 
-			// Build query based on role
-			query := firestoreClient.Collection("quiz_submissions").
-				Where("quizId", "==", quizID).
-				Where("status", "in", []string{"submitted", "evaluated"})
+			// Build filter based on role
+			filter := store.SubmissionFilter{
+				QuizID:   quizID,
+				Statuses: []string{"submitted", "evaluated"},
+			}
 
 			if role == "student" {
 				// Students see only their own submissions
-				query = query.Where("studentId", "==", userID)
+				filter.StudentID = userID
 			} else if role == "teacher" {
 				// Teachers can see all submissions for their quizzes
 				if quiz.TeacherID != userID {
@@ -134,39 +137,26 @@ func GetResults(w http.ResponseWriter, r *http.Request) {
 			// Admins see all submissions
 
 			// Execute query
-			iter := query.OrderBy("submittedAt", firestore.Desc).Documents(ctx)
-			defer iter.Stop()
-
-			submissions := make([]models.QuizSubmission, 0)
-			for {
-				doc, err := iter.Next()
-				if err == iterator.Done {
-					break
-				}
-				if err != nil {
-					utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch results")
-					return
-				}
+			submissions, err := db.Submissions().List(ctx, filter)
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch results")
+				return
+			}
 
-				var submission models.QuizSubmission
-				if err := doc.DataTo(&submission); err != nil {
-					continue
+			if role == "student" {
+				for i := range submissions {
+					studentResult(*quiz, &submissions[i])
 				}
-				submission.ID = doc.Ref.ID
-
-				// Get student details
-				if role == "teacher" || role == "admin" {
-					studentDoc, err := firestoreClient.Collection("users").Doc(submission.StudentID).Get(ctx)
-					if err == nil {
-						var student models.User
-						if err := studentDoc.DataTo(&student); err == nil {
-							submission.StudentName = student.DisplayName
-							submission.StudentEmail = student.Email
-						}
+			}
+
+			// Get student details
+			if role == "teacher" || role == "admin" {
+				for i := range submissions {
+					if student, err := db.Users().Get(ctx, submissions[i].StudentID); err == nil {
+						submissions[i].StudentName = student.DisplayName
+						submissions[i].StudentEmail = student.Email
 					}
 				}
-
-				submissions = append(submissions, submission)
 			}
 
 			// Calculate statistics for teachers/admins
@@ -185,14 +175,28 @@ func GetResults(w http.ResponseWriter, r *http.Request) {
 				}
 
 				avgScore := 0.0
+				avgPercentage := 0.0
 				if len(submissions) > 0 {
 					avgScore = totalScore / float64(len(submissions))
+					for _, sub := range submissions {
+						avgPercentage += sub.Percentage
+					}
+					avgPercentage /= float64(len(submissions))
 				}
 
 				stats["averageScore"] = avgScore
+				stats["averagePercentage"] = avgPercentage // comparable across drawn question sets
 				stats["passRate"] = float64(passed) / float64(len(submissions)) * 100
 				stats["totalPassed"] = passed
 				stats["totalFailed"] = len(submissions) - passed
+
+				autoSubmitted := 0
+				for _, sub := range submissions {
+					if sub.SubmitReason == submitReasonAutoSubmitted {
+						autoSubmitted++
+					}
+				}
+				stats["autoSubmitted"] = autoSubmitted
 			}
 
 			utils.RespondSuccess(w, map[string]interface{}{
@@ -202,3 +206,32 @@ func GetResults(w http.ResponseWriter, r *http.Request) {
 		}
 	})).ServeHTTP(w, r)
 }
+
+// studentResult strips a submission down to what its student may see. Answer
+// keys are always removed; explanations and per-answer grading are kept only
+// for a finished attempt on a quiz that shows results after submission.
+func studentResult(quiz models.Quiz, submission *models.QuizSubmission) {
+	if submission.Status == "in_progress" {
+		submission.Questions = clientQuestions(quiz, submission.Questions)
+	} else {
+		questions := utils.StudentQuestions(submission.Questions)
+		if quiz.ShowResultsAfterSubmit {
+			for i := range questions {
+				questions[i].Explanation = submission.Questions[i].Explanation
+			}
+		}
+		submission.Questions = questions
+	}
+
+	if submission.Status == "in_progress" || !quiz.ShowResultsAfterSubmit {
+		for i := range submission.Answers {
+			answer := &submission.Answers[i]
+			answer.IsCorrect = false
+			answer.PointsAwarded = 0
+			answer.PointsDeducted = 0
+			answer.CorrectPicks = 0
+			answer.WrongPicks = 0
+			answer.Feedback = ""
+		}
+	}
+}
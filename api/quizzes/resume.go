@@ -5,8 +5,8 @@ import (
 	"net/http"
 	"time"
 
-	"cloud.google.com/go/firestore"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
 )
 
@@ -53,36 +53,31 @@ func ResumeQuiz(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Get Firestore client
-		firestoreClient, err := utils.GetFirestoreClient(ctx)
+		// Get data store
+		db, err := store.GetStore(ctx)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
 			return
 		}
 
 		// Get submission
-		submissionRef := firestoreClient.Collection("quiz_submissions").Doc(req.SubmissionID)
-		submissionDoc, err := submissionRef.Get(ctx)
-		if err != nil {
+		submission, err := db.Submissions().Get(ctx, req.SubmissionID)
+		if err == store.ErrNotFound {
 			utils.RespondError(w, http.StatusNotFound, "Submission not found")
 			return
 		}
-
-		var submission models.QuizSubmission
-		if err := submissionDoc.DataTo(&submission); err != nil {
+		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse submission data")
 			return
 		}
 
 		// Get quiz
-		quizDoc, err := firestoreClient.Collection("quizzes").Doc(submission.QuizID).Get(ctx)
-		if err != nil {
+		quiz, err := db.Quizzes().Get(ctx, submission.QuizID)
+		if err == store.ErrNotFound {
 			utils.RespondError(w, http.StatusNotFound, "Quiz not found")
 			return
 		}
-
-		var quiz models.Quiz
-		if err := quizDoc.DataTo(&quiz); err != nil {
+		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse quiz data")
 			return
 		}
@@ -107,13 +102,13 @@ func ResumeQuiz(w http.ResponseWriter, r *http.Request) {
 
 		// Create resume record
 		now := time.Now()
-		updates := []firestore.Update{
-			{Path: "status", Value: "in_progress"},
-			{Path: "resumedBy", Value: userID},
-			{Path: "resumedAt", Value: now},
-			{Path: "resumeReason", Value: req.Reason},
-			{Path: "updatedAt", Value: now},
-		}
+		previousLimit := submission.TimeLimit
+		previousDeadline := attemptDeadline(*submission)
+		submission.Status = "in_progress"
+		submission.ResumedBy = userID
+		submission.ResumedAt = now
+		submission.ResumeReason = req.Reason
+		submission.UpdatedAt = now
 
 		// Extend time if requested and allowed
 		if req.ExtendTime > 0 {
@@ -121,55 +116,60 @@ func ResumeQuiz(w http.ResponseWriter, r *http.Request) {
 				utils.RespondError(w, http.StatusForbidden, "This quiz does not allow time extension")
 				return
 			}
-			updates = append(updates, firestore.Update{
-				Path:  "timeLimit",
-				Value: submission.TimeLimit + req.ExtendTime,
-			})
+			submission.TimeLimit = previousLimit + req.ExtendTime
+		}
+
+		// The resumed attempt gets back the time left when it was submitted,
+		// plus any extension, counted from now
+		if !previousDeadline.IsZero() {
+			remaining := previousDeadline.Sub(submission.SubmittedAt)
+			if remaining < 0 {
+				remaining = 0
+			}
+			submission.ExpiresAt = now.Add(remaining + time.Duration(req.ExtendTime)*time.Minute)
 		}
 
 		// Update submission
-		if _, err := submissionRef.Update(ctx, updates); err != nil {
+		if err := db.Submissions().Save(ctx, submission); err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to resume quiz")
 			return
 		}
 
 		// Log the resume action
-		logEntry := map[string]interface{}{
-			"action":       "quiz_resumed",
-			"submissionId": req.SubmissionID,
-			"quizId":       submission.QuizID,
-			"studentId":    submission.StudentID,
-			"teacherId":    userID,
-			"reason":       req.Reason,
-			"extendTime":   req.ExtendTime,
-			"timestamp":    now,
-		}
-		firestoreClient.Collection("audit_logs").NewDoc().Set(ctx, logEntry)
-
-		// Get student details for notification
-		studentDoc, err := firestoreClient.Collection("users").Doc(submission.StudentID).Get(ctx)
-		if err == nil {
-			var student models.User
-			if err := studentDoc.DataTo(&student); err == nil {
-				// Create notification for student
-				notification := models.Notification{
-					UserID:        submission.StudentID,
-					Type:          "quiz_resumed",
-					Title:         "Quiz Resumed",
-					Message:       "Your teacher has resumed your quiz: " + quiz.Title,
-					ReferenceID:   submission.QuizID,
-					ReferenceType: "quiz",
-					IsRead:        false,
-					CreatedAt:     now,
-				}
-				firestoreClient.Collection("notifications").NewDoc().Set(ctx, notification)
+		db.AuditLogs().Create(ctx, &models.AuditLog{
+			Action:     "quiz_resumed",
+			ActorID:    userID,
+			TargetType: "quiz_submission",
+			TargetID:   req.SubmissionID,
+			Details: map[string]interface{}{
+				"quizId":     submission.QuizID,
+				"studentId":  submission.StudentID,
+				"reason":     req.Reason,
+				"extendTime": req.ExtendTime,
+			},
+			Timestamp: now,
+		})
+
+		// Notify the student if their profile still exists
+		if _, err := db.Users().Get(ctx, submission.StudentID); err == nil {
+			notification := models.Notification{
+				UserID:        submission.StudentID,
+				Type:          "quiz_resumed",
+				Title:         "Quiz Resumed",
+				Message:       "Your teacher has resumed your quiz: " + quiz.Title,
+				ReferenceID:   submission.QuizID,
+				ReferenceType: "quiz",
+				IsRead:        false,
+				CreatedAt:     now,
 			}
+			db.Notifications().Create(ctx, &notification)
 		}
 
 		utils.RespondSuccess(w, map[string]interface{}{
 			"submissionId": req.SubmissionID,
 			"extendedTime": req.ExtendTime,
-			"newTimeLimit": submission.TimeLimit + req.ExtendTime,
+			"newTimeLimit": previousLimit + req.ExtendTime,
+			"expiresAt":    submission.ExpiresAt,
 		}, "Quiz resumed successfully")
 	})).ServeHTTP(w, r)
 }
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler autosaves answers onto an in-progress attempt. Each save must carry
+// the attempt version it was based on, so a stale tab cannot overwrite newer
+// answers saved from another tab.
+func SaveProgress(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (students only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		if role != "student" {
+			utils.RespondError(w, http.StatusForbidden, "Only students can answer quizzes")
+			return
+		}
+
+		// Parse request body
+		var req models.SaveProgressRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.SubmissionID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Submission ID is required")
+			return
+		}
+
+		if len(req.Answers) == 0 {
+			utils.RespondError(w, http.StatusBadRequest, "At least one answer is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		// Get submission
+		submission, err := db.Submissions().Get(ctx, req.SubmissionID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Submission not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse submission data")
+			return
+		}
+
+		if submission.StudentID != userID {
+			utils.RespondError(w, http.StatusForbidden, "You can only answer your own quiz")
+			return
+		}
+
+		if submission.Status != "in_progress" {
+			utils.RespondError(w, http.StatusBadRequest, "Quiz already submitted")
+			return
+		}
+
+		// Reject saves based on an outdated copy of the attempt
+		if req.Version != submission.Version {
+			respondVersionConflict(w, *submission)
+			return
+		}
+
+		// Get quiz
+		quiz, err := db.Quizzes().Get(ctx, submission.QuizID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Quiz not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse quiz data")
+			return
+		}
+
+		now := time.Now()
+		accommodation, err := store.StudentAccommodation(ctx, db, submission.StudentID, quiz.CourseID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch accommodations")
+			return
+		}
+		capToWindow(withAccommodation(*quiz, accommodation), submission)
+		if attemptExpired(*submission, now) {
+			utils.RespondError(w, http.StatusForbidden, "Time limit exceeded")
+			return
+		}
+
+		inAttempt := make(map[string]bool)
+		for _, q := range submission.Questions {
+			inAttempt[q.ID] = true
+		}
+
+		// Answers to unknown questions or to questions whose timer has run out
+		// are reported back rather than failing the whole save
+		saved := 0
+		rejected := make([]string, 0)
+		for _, a := range req.Answers {
+			if !inAttempt[a.QuestionID] || !answerOpen(*quiz, *submission, a.QuestionID, now) {
+				rejected = append(rejected, a.QuestionID)
+				continue
+			}
+
+			// Only the student's selection is kept; grading fields are set on submit
+			answeredAt := now
+			submission.Answers = upsertAnswer(submission.Answers, models.Answer{
+				QuestionID:      a.QuestionID,
+				SelectedOptions: a.SelectedOptions,
+				TextAnswer:      a.TextAnswer,
+				AnsweredAt:      &answeredAt,
+			})
+			saved++
+		}
+
+		if saved > 0 {
+			submission.UpdatedAt = now
+			err := db.Submissions().SaveIfVersion(ctx, submission, req.Version)
+			if err == store.ErrConflict {
+				latest, getErr := db.Submissions().Get(ctx, req.SubmissionID)
+				if getErr != nil {
+					utils.RespondError(w, http.StatusInternalServerError, "Failed to parse submission data")
+					return
+				}
+				respondVersionConflict(w, *latest)
+				return
+			}
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to save progress")
+				return
+			}
+		}
+
+		utils.RespondSuccess(w, map[string]interface{}{
+			"submissionId": submission.ID,
+			"version":      submission.Version,
+			"saved":        saved,
+			"rejected":     rejected,
+			"savedAt":      now,
+			"deadline":     attemptDeadline(*submission),
+		}, "Progress saved")
+	})).ServeHTTP(w, r)
+}
+
+// respondVersionConflict returns the stored version and answers so the client
+// can merge them and retry
+func respondVersionConflict(w http.ResponseWriter, submission models.QuizSubmission) {
+	utils.RespondJSON(w, http.StatusConflict, map[string]interface{}{
+		"message": "Quiz progress was saved from another session",
+		"version": submission.Version,
+		"answers": submission.Answers,
+	})
+}
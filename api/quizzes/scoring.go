@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// evaluateAnswer grades a single answer against the original question
+func evaluateAnswer(quiz models.Quiz, question models.Question, answer models.Answer) models.Answer {
+	evaluated := answer
+	evaluated.IsCorrect = false
+	evaluated.PointsAwarded = 0
+	evaluated.PointsDeducted = 0
+	evaluated.CorrectPicks = 0
+	evaluated.WrongPicks = 0
+	evaluated.NeedsReview = false
+
+	if !utils.IsObjectiveQuestion(question.Type) {
+		// Blank written answers score zero
+		if strings.TrimSpace(answer.TextAnswer) == "" {
+			return evaluated
+		}
+
+		// Short answers matching an accepted answer are graded automatically;
+		// anything else goes to the manual grading queue
+		if question.Type == "short_answer" && matchesAcceptedAnswer(answer.TextAnswer, acceptedAnswerRules(question)) {
+			evaluated.IsCorrect = true
+			evaluated.PointsAwarded = question.Points
+			return evaluated
+		}
+		evaluated.NeedsReview = true
+		return evaluated
+	}
+
+	// Skipped questions are never penalised
+	if len(answer.SelectedOptions) == 0 {
+		return evaluated
+	}
+
+	// Find correct option(s)
+	correctIDs := make(map[string]bool)
+	for _, opt := range question.Options {
+		if opt.IsCorrect {
+			correctIDs[opt.ID] = true
+		}
+	}
+	incorrectCount := len(question.Options) - len(correctIDs)
+
+	// Count correct and wrong picks, ignoring duplicates
+	seen := make(map[string]bool)
+	for _, selectedID := range answer.SelectedOptions {
+		if seen[selectedID] {
+			continue
+		}
+		seen[selectedID] = true
+		if correctIDs[selectedID] {
+			evaluated.CorrectPicks++
+		} else {
+			evaluated.WrongPicks++
+		}
+	}
+
+	// Exact match of the correct set
+	evaluated.IsCorrect = evaluated.WrongPicks == 0 && evaluated.CorrectPicks == len(correctIDs)
+
+	policy := question.ScoringPolicy
+	if question.Type != "mcq" || len(correctIDs) == 0 {
+		policy = models.ScoringAllOrNothing
+	}
+
+	switch policy {
+	case models.ScoringProportional:
+		// Correct picks minus wrong picks, floored at zero
+		net := evaluated.CorrectPicks - evaluated.WrongPicks
+		if net > 0 {
+			evaluated.PointsAwarded = roundPoints(question.Points * float64(net) / float64(len(correctIDs)))
+		}
+	case models.ScoringRightMinusWrong:
+		// Each correct pick earns its share, each wrong pick costs its share of the distractors
+		net := float64(evaluated.CorrectPicks) / float64(len(correctIDs))
+		if incorrectCount > 0 {
+			net -= float64(evaluated.WrongPicks) / float64(incorrectCount)
+		}
+		if net >= 0 {
+			evaluated.PointsAwarded = roundPoints(question.Points * net)
+		} else {
+			evaluated.PointsDeducted = roundPoints(question.Points * -net)
+		}
+		// The policy carries its own penalty, so quiz-level negative marking is not added
+		return evaluated
+	default:
+		if evaluated.IsCorrect {
+			evaluated.PointsAwarded = question.Points
+		}
+	}
+
+	// A correct answer to a zero-point question is not a wrong answer
+	if evaluated.PointsAwarded == 0 && !evaluated.IsCorrect && quiz.NegativeMarking {
+		evaluated.PointsDeducted = quiz.NegativeMarkValue
+	}
+
+	return evaluated
+}
+
+// roundPoints rounds fractional credit to two decimal places
+func roundPoints(points float64) float64 {
+	return math.Round(points*100) / 100
+}
+
+// calculateScore sums awarded points minus deductions, floored at zero unless
+// the quiz allows a negative total
+func calculateScore(quiz models.Quiz, answers []models.Answer) float64 {
+	total := 0.0
+	for _, answer := range answers {
+		total += answer.PointsAwarded - answer.PointsDeducted
+	}
+	if total < 0 && !quiz.AllowNegativeScore {
+		total = 0
+	}
+	return total
+}
+
+// pendingReviewCount returns how many answers still await manual grading
+func pendingReviewCount(answers []models.Answer) int {
+	pending := 0
+	for _, answer := range answers {
+		if answer.NeedsReview {
+			pending++
+		}
+	}
+	return pending
+}
+
+// gradeAttempt evaluates answers against the attempt's questions and closes
+// the submission. The submission is left "submitted" while written answers
+// await review; the number of such answers is returned.
+func gradeAttempt(quiz models.Quiz, submission *models.QuizSubmission, questions []models.Question, answers []models.Answer, now time.Time) int {
+	originalQuestions := make(map[string]models.Question)
+	for _, q := range questions {
+		originalQuestions[q.ID] = q
+	}
+
+	evaluatedAnswers := make([]models.Answer, 0)
+	for _, answer := range answers {
+		originalQ, exists := originalQuestions[answer.QuestionID]
+		if !exists {
+			continue
+		}
+		evaluatedAnswers = append(evaluatedAnswers, evaluateAnswer(quiz, originalQ, answer))
+	}
+	totalScore := calculateScore(quiz, evaluatedAnswers)
+
+	status := "evaluated"
+	pendingReview := pendingReviewCount(evaluatedAnswers)
+	if pendingReview > 0 {
+		status = "submitted"
+	}
+
+	percentage := 0.0
+	if totalMarks := attemptTotalMarks(quiz, *submission); totalMarks > 0 {
+		percentage = (totalScore / totalMarks) * 100
+	}
+
+	// Time spent never counts beyond the deadline
+	end := now
+	if deadline := attemptDeadline(*submission); !deadline.IsZero() && end.After(deadline) {
+		end = deadline
+	}
+
+	submission.Answers = evaluatedAnswers
+	submission.Status = status
+	submission.SubmitReason = ""
+	submission.SubmittedAt = now
+	submission.Score = totalScore
+	submission.Percentage = percentage
+	submission.Passed = totalScore >= quiz.PassingMarks
+	submission.TimeTaken = int(end.Sub(submission.StartedAt).Minutes())
+	submission.UpdatedAt = now
+	return pendingReview
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+)
+
+func mcq(points float64, correct ...string) models.Question {
+	question := models.Question{ID: "q1", Type: "mcq", Points: points}
+	isCorrect := make(map[string]bool)
+	for _, id := range correct {
+		isCorrect[id] = true
+	}
+	for _, id := range []string{"A", "B", "C", "D"} {
+		question.Options = append(question.Options, models.QuestionOption{ID: id, IsCorrect: isCorrect[id]})
+	}
+	return question
+}
+
+func TestEvaluateAnswerNegativeMarking(t *testing.T) {
+	penalised := models.Quiz{NegativeMarking: true, NegativeMarkValue: 0.25}
+
+	tests := []struct {
+		name         string
+		quiz         models.Quiz
+		question     models.Question
+		selected     []string
+		wantCorrect  bool
+		wantAwarded  float64
+		wantDeducted float64
+	}{
+		{"correct answer", penalised, mcq(2, "A"), []string{"A"}, true, 2, 0},
+		{"wrong answer is penalised", penalised, mcq(2, "A"), []string{"B"}, false, 0, 0.25},
+		{"wrong answer without negative marking", models.Quiz{}, mcq(2, "A"), []string{"B"}, false, 0, 0},
+		{"empty answer is never penalised", penalised, mcq(2, "A"), nil, false, 0, 0},
+		{"duplicate picks count once", penalised, mcq(2, "A"), []string{"A", "A"}, true, 2, 0},
+		{"zero-point question answered correctly", penalised, mcq(0, "A"), []string{"A"}, true, 0, 0},
+		{"zero-point question answered wrongly", penalised, mcq(0, "A"), []string{"B"}, false, 0, 0.25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluateAnswer(tt.quiz, tt.question, models.Answer{QuestionID: "q1", SelectedOptions: tt.selected})
+			if got.IsCorrect != tt.wantCorrect || got.PointsAwarded != tt.wantAwarded || got.PointsDeducted != tt.wantDeducted {
+				t.Errorf("got correct=%v awarded=%v deducted=%v, want %v %v %v",
+					got.IsCorrect, got.PointsAwarded, got.PointsDeducted, tt.wantCorrect, tt.wantAwarded, tt.wantDeducted)
+			}
+		})
+	}
+}
+
+func TestCalculateScore(t *testing.T) {
+	tests := []struct {
+		name    string
+		quiz    models.Quiz
+		answers []models.Answer
+		want    float64
+	}{
+		{"no answers", models.Quiz{}, nil, 0},
+		{"awards minus deductions", models.Quiz{}, []models.Answer{
+			{PointsAwarded: 2},
+			{PointsDeducted: 0.5},
+		}, 1.5},
+		{"negative total clamped to zero", models.Quiz{}, []models.Answer{
+			{PointsDeducted: 1},
+			{PointsDeducted: 1},
+		}, 0},
+		{"negative total allowed", models.Quiz{AllowNegativeScore: true}, []models.Answer{
+			{PointsDeducted: 1},
+			{PointsDeducted: 1},
+		}, -2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calculateScore(tt.quiz, tt.answers); got != tt.want {
+				t.Errorf("calculateScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateAnswerScoringPolicies(t *testing.T) {
+	withPolicy := func(question models.Question, policy string) models.Question {
+		question.ScoringPolicy = policy
+		return question
+	}
+	trueFalse := withPolicy(mcq(4, "A", "B"), models.ScoringProportional)
+	trueFalse.Type = "true_false"
+
+	tests := []struct {
+		name         string
+		quiz         models.Quiz
+		question     models.Question
+		selected     []string
+		wantAwarded  float64
+		wantDeducted float64
+	}{
+		{"all or nothing, partial pick", models.Quiz{}, mcq(4, "A", "B"), []string{"A"}, 0, 0},
+		{"all or nothing, exact pick", models.Quiz{}, mcq(4, "A", "B"), []string{"A", "B"}, 4, 0},
+		{"proportional, half right", models.Quiz{}, withPolicy(mcq(4, "A", "B"), models.ScoringProportional), []string{"A"}, 2, 0},
+		{"proportional, wrong pick cancels right pick", models.Quiz{}, withPolicy(mcq(4, "A", "B"), models.ScoringProportional), []string{"A", "C"}, 0, 0},
+		{"proportional, net floored at zero", models.Quiz{}, withPolicy(mcq(4, "A", "B"), models.ScoringProportional), []string{"C", "D"}, 0, 0},
+		{"proportional, rounded to two places", models.Quiz{}, withPolicy(mcq(1, "A", "B", "C"), models.ScoringProportional), []string{"A"}, 0.33, 0},
+		{"proportional, zero credit takes negative marking", models.Quiz{NegativeMarking: true, NegativeMarkValue: 1}, withPolicy(mcq(4, "A", "B"), models.ScoringProportional), []string{"C"}, 0, 1},
+		{"right minus wrong, half right", models.Quiz{}, withPolicy(mcq(4, "A", "B"), models.ScoringRightMinusWrong), []string{"A"}, 2, 0},
+		{"right minus wrong, balanced picks", models.Quiz{}, withPolicy(mcq(4, "A", "B"), models.ScoringRightMinusWrong), []string{"A", "C"}, 0, 0},
+		{"right minus wrong, all distractors", models.Quiz{}, withPolicy(mcq(4, "A", "B"), models.ScoringRightMinusWrong), []string{"C", "D"}, 0, 4},
+		{"right minus wrong ignores negative marking", models.Quiz{NegativeMarking: true, NegativeMarkValue: 1}, withPolicy(mcq(4, "A", "B"), models.ScoringRightMinusWrong), []string{"C"}, 0, 2},
+		{"right minus wrong, empty answer", models.Quiz{}, withPolicy(mcq(4, "A", "B"), models.ScoringRightMinusWrong), nil, 0, 0},
+		{"policy ignored outside mcq", models.Quiz{}, trueFalse, []string{"A"}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluateAnswer(tt.quiz, tt.question, models.Answer{QuestionID: "q1", SelectedOptions: tt.selected})
+			if got.PointsAwarded != tt.wantAwarded || got.PointsDeducted != tt.wantDeducted {
+				t.Errorf("got awarded=%v deducted=%v, want %v %v", got.PointsAwarded, got.PointsDeducted, tt.wantAwarded, tt.wantDeducted)
+			}
+		})
+	}
+}
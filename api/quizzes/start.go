@@ -2,12 +2,12 @@ package handler
 
 import (
 	"encoding/json"
+	"math"
 	"net/http"
 	"time"
 
-	"cloud.google.com/go/firestore"
-	"google.golang.org/api/iterator"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
 )
 
@@ -52,22 +52,20 @@ func StartQuiz(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Get Firestore client
-		firestoreClient, err := utils.GetFirestoreClient(ctx)
+		// Get data store
+		db, err := store.GetStore(ctx)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
 			return
 		}
 
 		// Get quiz
-		quizDoc, err := firestoreClient.Collection("quizzes").Doc(req.QuizID).Get(ctx)
-		if err != nil {
+		quiz, err := db.Quizzes().Get(ctx, req.QuizID)
+		if err == store.ErrNotFound || (err == nil && quiz.IsDeleted) {
 			utils.RespondError(w, http.StatusNotFound, "Quiz not found")
 			return
 		}
-
-		var quiz models.Quiz
-		if err := quizDoc.DataTo(&quiz); err != nil {
+		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse quiz data")
 			return
 		}
@@ -78,92 +76,100 @@ func StartQuiz(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Check deadline
-		if !quiz.Deadline.IsZero() && time.Now().After(quiz.Deadline) {
-			utils.RespondError(w, http.StatusForbidden, "Quiz deadline has passed")
+		// Apply the student's accommodation, if they have one
+		accommodation, err := store.StudentAccommodation(ctx, db, userID, quiz.CourseID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch accommodations")
 			return
 		}
+		*quiz = withAccommodation(*quiz, accommodation)
 
-		// Verify student is enrolled in the course
-		enrollmentQuery := firestoreClient.Collection("enrollments").
-			Where("studentId", "==", userID).
-			Where("courseId", "==", quiz.CourseID).
-			Where("status", "==", "active")
+		// Check the student's availability window
+		availability := quizAvailability(*quiz, userID, time.Now())
+		if availability.Status == windowUpcoming {
+			utils.RespondError(w, http.StatusForbidden, "Quiz opens at "+availability.OpensAt.Format(time.RFC3339))
+			return
+		}
+		if availability.Status == windowClosed {
+			utils.RespondError(w, http.StatusForbidden, "Quiz is closed")
+			return
+		}
 
-		enrollDocs, err := enrollmentQuery.Documents(ctx).GetAll()
-		if err != nil || len(enrollDocs) == 0 {
+		// Verify student is enrolled in the course
+		enrollments, err := db.Enrollments().List(ctx, store.EnrollmentFilter{
+			StudentID: userID,
+			CourseID:  quiz.CourseID,
+			Status:    "active",
+		})
+		if err != nil || len(enrollments) == 0 {
 			utils.RespondError(w, http.StatusForbidden, "You must be enrolled in this course")
 			return
 		}
 
 		// Check previous attempts
-		submissionsQuery := firestoreClient.Collection("quiz_submissions").
-			Where("quizId", "==", req.QuizID).
-			Where("studentId", "==", userID)
-
-		submissionDocs, err := submissionsQuery.Documents(ctx).GetAll()
+		previous, err := db.Submissions().List(ctx, store.SubmissionFilter{
+			QuizID:    req.QuizID,
+			StudentID: userID,
+		})
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to check previous attempts")
 			return
 		}
 
-		// Check for in-progress submission
-		for _, doc := range submissionDocs {
-		var sub models.QuizSubmission
-		if err := doc.DataTo(&sub); err == nil {
+		// Check for in-progress submission; expired attempts are closed with
+		// their saved answers and count as a completed attempt
+		now := time.Now()
+		for i := range previous {
+			sub := previous[i]
+			if sub.Status == "in_progress" && attemptExpired(sub, now) {
+				if err := autoSubmit(ctx, db, *quiz, &previous[i], now); err != nil {
+					utils.RespondError(w, http.StatusInternalServerError, "Failed to close expired attempt")
+					return
+				}
+				continue
+			}
 			if sub.Status == "in_progress" {
-				// Resume existing attempt
+				// Resume existing attempt with the answers saved so far
+				sub.Questions = clientQuestions(*quiz, sub.Questions)
 				utils.RespondSuccess(w, map[string]interface{}{
 					"submission": sub,
 					"resumed":    true,
+					"answers":    sub.Answers,
+					"version":    sub.Version,
+					"deadline":   attemptDeadline(sub),
 				})
 				return
-				}
 			}
 		}
 
 		// Count completed attempts
 		completedAttempts := 0
-		for _, doc := range submissionDocs {
-			var sub models.QuizSubmission
-			if err := doc.DataTo(&sub); err == nil {
-				if sub.Status == "submitted" || sub.Status == "evaluated" {
-					completedAttempts++
-				}
+		for _, sub := range previous {
+			if sub.Status == "submitted" || sub.Status == "evaluated" {
+				completedAttempts++
 			}
 		}
 
-		// Check max attempts
-		if quiz.MaxAttempts > 0 && completedAttempts >= quiz.MaxAttempts {
+		// Check max attempts, including any extra attempts granted
+		if limit := attemptLimit(*quiz, accommodation); limit > 0 && completedAttempts >= limit {
 			utils.RespondError(w, http.StatusForbidden, "Maximum attempts reached")
 			return
 		}
 
-		// Get all questions for this quiz
-		questionsQuery := firestoreClient.Collection("questions").
-			Where("quizId", "==", req.QuizID).
-			OrderBy("order", firestore.Asc)
-
-		iter := questionsQuery.Documents(ctx)
-		defer iter.Stop()
-
-		questions := make([]models.Question, 0)
-		for {
-			doc, err := iter.Next()
-			if err == iterator.Done {
-				break
-			}
-			if err != nil {
-				utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch questions")
+		// Lock question grading before reading the questions, so an edit
+		// either lands before this attempt sees them or is rejected
+		if !quiz.Attempted {
+			if err := db.Quizzes().MarkAttempted(ctx, quiz.ID); err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to start quiz")
 				return
 			}
+		}
 
-			var question models.Question
-			if err := doc.DataTo(&question); err != nil {
-				continue
-			}
-			question.ID = doc.Ref.ID
-			questions = append(questions, question)
+		// Get all questions for this quiz
+		questions, err := db.Questions().ListByQuiz(ctx, req.QuizID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch questions")
+			return
 		}
 
 		if len(questions) == 0 {
@@ -171,20 +177,22 @@ func StartQuiz(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Shuffle questions if enabled
-		if quiz.ShuffleQuestions || quiz.RandomizeQuestionOrder {
-			utils.ShuffleQuestions(&questions)
+		// Draw and shuffle this student's questions. The seed is kept on the
+		// attempt so the same set and order can be regenerated later.
+		seed := utils.NewShuffleSeed()
+		inputs := replayInputs(*quiz, questions)
+		questions, err = attemptQuestionSet(*quiz, questions, seed)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Quiz cannot be started: "+err.Error())
+			return
 		}
 
-		// Shuffle options if enabled
-		if quiz.ShuffleOptions {
-			for i := range questions {
-				utils.ShuffleOptions(&questions[i].Options)
-			}
+		totalMarks := quiz.TotalMarks
+		if len(quiz.DrawRules) > 0 {
+			totalMarks = sumPoints(questions)
 		}
 
 		// Create submission
-		now := time.Now()
 		submission := models.QuizSubmission{
 			QuizID:           req.QuizID,
 			StudentID:        userID,
@@ -192,8 +200,11 @@ func StartQuiz(w http.ResponseWriter, r *http.Request) {
 			AttemptNumber:    completedAttempts + 1,
 			Status:           "in_progress",
 			StartedAt:        now,
-			TimeLimit:        quiz.Duration,
+			TimeLimit:        int(math.Ceil(float64(quiz.Duration) * utils.TimeMultiplier(accommodation))),
 			Questions:        questions,
+			ShuffleSeed:      seed,
+			ReplayInputs:     inputs,
+			TotalMarks:       totalMarks,
 			TabSwitchCount:   0,
 			FullscreenExits:  0,
 			SuspiciousActivity: make([]string, 0),
@@ -201,39 +212,47 @@ func StartQuiz(w http.ResponseWriter, r *http.Request) {
 			UpdatedAt:        now,
 		}
 
-		// Save submission
-		submissionRef := firestoreClient.Collection("quiz_submissions").NewDoc()
-		submission.ID = submissionRef.ID
+		if accommodation != nil {
+			submission.AccommodationID = accommodation.ID
+			submission.TimeMultiplier = utils.TimeMultiplier(accommodation)
+		}
+		submission.ExpiresAt = initialDeadline(*quiz, submission)
 
-		if _, err := submissionRef.Set(ctx, submission); err != nil {
+		// Save submission
+		if err := db.Submissions().Create(ctx, &submission); err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to start quiz")
 			return
 		}
 
-		// Remove correct answers from questions before sending to client
-		clientQuestions := make([]models.Question, len(questions))
-		for i, q := range questions {
-			clientQ := q
-			// Remove correct answer info for auto-graded questions
-			if q.Type == "mcq" || q.Type == "true_false" {
-				clientOptions := make([]models.QuestionOption, len(q.Options))
-				for j, opt := range q.Options {
-					clientOptions[j] = models.QuestionOption{
-						ID:   opt.ID,
-						Text: opt.Text,
-						// IsCorrect field is intentionally omitted
-					}
-				}
-				clientQ.Options = clientOptions
-			}
-			clientQuestions[i] = clientQ
+		// Record that the attempt was started with an accommodation
+		if accommodation != nil {
+			db.AuditLogs().Create(ctx, &models.AuditLog{
+				Action:     "accommodation_applied",
+				ActorID:    userID,
+				TargetType: "quiz_submission",
+				TargetID:   submission.ID,
+				Details: map[string]interface{}{
+					"quizId":          quiz.ID,
+					"studentId":       userID,
+					"accommodationId": accommodation.ID,
+					"timeMultiplier":  submission.TimeMultiplier,
+					"attemptLimit":    attemptLimit(*quiz, accommodation),
+					"attemptNumber":   submission.AttemptNumber,
+					"expiresAt":       submission.ExpiresAt,
+				},
+				Timestamp: now,
+			})
 		}
 
-		submission.Questions = clientQuestions
+		// Remove correct answers from questions before sending to client
+		submission.Questions = clientQuestions(*quiz, questions)
 
 		utils.RespondSuccess(w, map[string]interface{}{
-			"submission": submission,
-			"resumed":    false,
+			"submission":      submission,
+			"resumed":         false,
+			"deadline":        submission.ExpiresAt,
+			"totalMarks":      submission.TotalMarks,
+			"timePerQuestion": quiz.TimePerQuestion,
 			"cheatingPrevention": map[string]interface{}{
 				"preventTabSwitch":  quiz.PreventTabSwitch,
 				"maxTabSwitches":    quiz.MaxTabSwitches,
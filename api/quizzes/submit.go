@@ -5,8 +5,8 @@ import (
 	"net/http"
 	"time"
 
-	"cloud.google.com/go/firestore"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
 	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
 )
 
@@ -49,23 +49,20 @@ func SubmitQuiz(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Get Firestore client
-		firestoreClient, err := utils.GetFirestoreClient(ctx)
+		// Get data store
+		db, err := store.GetStore(ctx)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
 			return
 		}
 
 		// Get submission
-		submissionRef := firestoreClient.Collection("quiz_submissions").Doc(req.SubmissionID)
-		submissionDoc, err := submissionRef.Get(ctx)
-		if err != nil {
+		submission, err := db.Submissions().Get(ctx, req.SubmissionID)
+		if err == store.ErrNotFound {
 			utils.RespondError(w, http.StatusNotFound, "Submission not found")
 			return
 		}
-
-		var submission models.QuizSubmission
-		if err := submissionDoc.DataTo(&submission); err != nil {
+		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse submission data")
 			return
 		}
@@ -83,100 +80,41 @@ func SubmitQuiz(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// Get quiz details
-		quizDoc, err := firestoreClient.Collection("quizzes").Doc(submission.QuizID).Get(ctx)
-		if err != nil {
+		quiz, err := db.Quizzes().Get(ctx, submission.QuizID)
+		if err == store.ErrNotFound {
 			utils.RespondError(w, http.StatusNotFound, "Quiz not found")
 			return
 		}
-
-		var quiz models.Quiz
-		if err := quizDoc.DataTo(&quiz); err != nil {
+		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse quiz data")
 			return
 		}
 
-		// Check time limit
+		// Enforce the server-side deadline: answers received after it (plus
+		// grace) are dropped and only answers recorded in time are graded. The
+		// quiz window may have been shortened since the attempt started.
 		now := time.Now()
-		timeElapsed := now.Sub(submission.StartedAt)
-		if timeElapsed > time.Duration(quiz.Duration)*time.Minute {
-			// Auto-submit if time expired
+		accommodation, err := store.StudentAccommodation(ctx, db, submission.StudentID, quiz.CourseID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch accommodations")
+			return
+		}
+		capToWindow(withAccommodation(*quiz, accommodation), submission)
+		answers, droppedAnswers := acceptedAnswers(*quiz, *submission, req.Answers, now)
+		if attemptExpired(*submission, now) {
 			req.TimedOut = true
 		}
 
-		// Get original questions with correct answers
-		originalQuestions := make(map[string]models.Question)
-		questionsQuery := firestoreClient.Collection("questions").
-			Where("quizId", "==", submission.QuizID)
-
-		questionDocs, err := questionsQuery.Documents(ctx).GetAll()
+		// Get the attempt's questions with correct answers
+		questions, err := attemptQuestions(ctx, db, *submission)
 		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch questions")
 			return
 		}
 
-		for _, doc := range questionDocs {
-			var q models.Question
-			if err := doc.DataTo(&q); err == nil {
-				q.ID = doc.Ref.ID
-				originalQuestions[q.ID] = q
-			}
-		}
-
-		// Auto-evaluate answers
-		totalScore := 0.0
-		evaluatedAnswers := make([]models.Answer, 0)
-
-		for _, answer := range req.Answers {
-			originalQ, exists := originalQuestions[answer.QuestionID]
-			if !exists {
-				continue
-			}
-
-			evaluatedAnswer := answer
-			evaluatedAnswer.PointsAwarded = 0
-
-			// Auto-grade MCQ and True/False
-			if originalQ.Type == "mcq" || originalQ.Type == "true_false" {
-				// Find correct option(s)
-				correctIDs := make(map[string]bool)
-				for _, opt := range originalQ.Options {
-					if opt.IsCorrect {
-						correctIDs[opt.ID] = true
-					}
-				}
-
-				// Check if student's answer is correct
-				allCorrect := true
-				for _, selectedID := range answer.SelectedOptions {
-					if !correctIDs[selectedID] {
-						allCorrect = false
-						break
-					}
-				}
-
-				// Also check if all correct options were selected
-				if allCorrect && len(answer.SelectedOptions) == len(correctIDs) {
-					evaluatedAnswer.IsCorrect = true
-					evaluatedAnswer.PointsAwarded = originalQ.Points
-					totalScore += originalQ.Points
-				} else {
-					evaluatedAnswer.IsCorrect = false
-				}
-			}
-			// Short and long answers need manual grading
-			// Leave them at 0 points for now
-
-			evaluatedAnswers = append(evaluatedAnswers, evaluatedAnswer)
-		}
-
-		// Calculate percentage
-		percentage := 0.0
-		if quiz.TotalMarks > 0 {
-			percentage = (totalScore / float64(quiz.TotalMarks)) * 100
-		}
-
-		// Determine pass/fail
-		passed := totalScore >= float64(quiz.PassingMarks)
+		// Auto-evaluate answers; written answers keep the submission pending
+		// until a teacher grades them
+		pendingReview := gradeAttempt(*quiz, submission, questions, answers, now)
 
 		// Check for suspicious activity
 		suspiciousFlags := make([]string, 0)
@@ -191,64 +129,41 @@ func SubmitQuiz(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// Update submission
-		updates := []firestore.Update{
-			{Path: "answers", Value: evaluatedAnswers},
-			{Path: "status", Value: "evaluated"},
-			{Path: "submittedAt", Value: now},
-			{Path: "score", Value: totalScore},
-			{Path: "percentage", Value: percentage},
-			{Path: "passed", Value: passed},
-			{Path: "timeTaken", Value: int(timeElapsed.Minutes())},
-			{Path: "tabSwitchCount", Value: req.TabSwitches},
-			{Path: "fullscreenExits", Value: req.FullscreenExits},
-			{Path: "suspiciousActivity", Value: suspiciousFlags},
-			{Path: "updatedAt", Value: now},
+		submission.TabSwitchCount = req.TabSwitches
+		submission.FullscreenExits = req.FullscreenExits
+		submission.SuspiciousActivity = suspiciousFlags
+
+		// Fail rather than lose answers autosaved while this request was in flight
+		err = db.Submissions().SaveIfVersion(ctx, submission, submission.Version)
+		if err == store.ErrConflict {
+			utils.RespondError(w, http.StatusConflict, "Quiz progress changed, please retry")
+			return
 		}
-
-		if _, err := submissionRef.Update(ctx, updates); err != nil {
+		if err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to submit quiz")
 			return
 		}
 
 		// Update student's analytics
-		analyticsQuery := firestoreClient.Collection("analytics").
-			Where("studentId", "==", userID)
+		db.Analytics().RecordQuizCompletion(ctx, userID, submission.Score)
 
-		analyticsDocs, err := analyticsQuery.Documents(ctx).GetAll()
-		
-		var analyticsRef *firestore.DocumentRef
-		if err == nil && len(analyticsDocs) > 0 {
-			// Update existing analytics
-			analyticsRef = analyticsDocs[0].Ref
-			_, err = analyticsRef.Update(ctx, []firestore.Update{
-				{Path: "quizzesCompleted", Value: firestore.Increment(1)},
-				{Path: "totalQuizScore", Value: firestore.Increment(int(totalScore))},
-			{Path: "updatedAt", Value: now},
-		})
-	} else {
-		// Create new analytics record with basic structure
-		analyticsData := map[string]interface{}{
-			"studentId":        userID,
-			"quizzesCompleted": 1,
-			"totalQuizScore":   int(totalScore),
-			"createdAt":        now,
-			"updatedAt":        now,
-		}
-		analyticsRef = firestoreClient.Collection("analytics").NewDoc()
-		_, err = analyticsRef.Set(ctx, analyticsData)
-	}		// Prepare response
+		// Prepare response
 		response := map[string]interface{}{
-			"submissionId": req.SubmissionID,
-			"score":        totalScore,
-			"totalMarks":   quiz.TotalMarks,
-			"percentage":   percentage,
-			"passed":       passed,
-			"timeTaken":    int(timeElapsed.Minutes()),
+			"submissionId":   req.SubmissionID,
+			"score":          submission.Score,
+			"totalMarks":     attemptTotalMarks(*quiz, *submission),
+			"percentage":     submission.Percentage,
+			"passed":         submission.Passed,
+			"timeTaken":      submission.TimeTaken,
+			"status":         submission.Status,
+			"pendingReview":  pendingReview,
+			"droppedAnswers": droppedAnswers,
+			"timedOut":       req.TimedOut,
 		}
 
 		// Include results if enabled
 		if quiz.ShowResultsAfterSubmit {
-			response["answers"] = evaluatedAnswers
+			response["answers"] = submission.Answers
 			response["suspiciousActivity"] = suspiciousFlags
 		}
 
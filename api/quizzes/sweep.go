@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// submitReasonAutoSubmitted marks attempts closed by the server after their deadline
+const submitReasonAutoSubmitted = "auto_submitted"
+
+// SweepResult summarises a sweep of expired attempts
+type SweepResult struct {
+	Checked       int `json:"checked"`
+	AutoSubmitted int `json:"autoSubmitted"`
+	Failed        int `json:"failed"`
+}
+
+// autoSubmit grades an expired attempt with the answers saved so far and closes it
+func autoSubmit(ctx context.Context, db store.Store, quiz models.Quiz, submission *models.QuizSubmission, now time.Time) error {
+	questions, err := attemptQuestions(ctx, db, *submission)
+	if err != nil {
+		return err
+	}
+
+	gradeAttempt(quiz, submission, questions, submission.Answers, now)
+	submission.SubmitReason = submitReasonAutoSubmitted
+	submission.SuspiciousActivity = append(submission.SuspiciousActivity, "Time limit exceeded")
+
+	if err := db.Submissions().SaveIfVersion(ctx, submission, submission.Version); err != nil {
+		return err
+	}
+
+	db.Analytics().RecordQuizCompletion(ctx, submission.StudentID, submission.Score)
+	return nil
+}
+
+// SweepExpiredAttempts auto-submits every in-progress attempt whose deadline
+// (plus grace) has passed
+func SweepExpiredAttempts(ctx context.Context, db store.Store, now time.Time) (SweepResult, error) {
+	var result SweepResult
+
+	submissions, err := db.Submissions().List(ctx, store.SubmissionFilter{
+		Statuses: []string{"in_progress"},
+	})
+	if err != nil {
+		return result, err
+	}
+
+	quizzes := make(map[string]*models.Quiz)
+	for i := range submissions {
+		submission := &submissions[i]
+		result.Checked++
+		if !attemptExpired(*submission, now) {
+			continue
+		}
+
+		quiz, ok := quizzes[submission.QuizID]
+		if !ok {
+			quiz, err = db.Quizzes().Get(ctx, submission.QuizID)
+			if err != nil {
+				log.Printf("ERROR: Sweep could not load quiz %s: %v", submission.QuizID, err)
+				result.Failed++
+				continue
+			}
+			quizzes[submission.QuizID] = quiz
+		}
+
+		if err := autoSubmit(ctx, db, *quiz, submission, now); err != nil {
+			log.Printf("ERROR: Sweep could not auto-submit %s: %v", submission.ID, err)
+			result.Failed++
+			continue
+		}
+		result.AutoSubmitted++
+	}
+
+	return result, nil
+}
+
+// Handler auto-submits expired quiz attempts. It is meant for a cron job,
+// authenticated with CRON_SECRET as a bearer token, but admins may also call it.
+func SweepExpired(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Cron schedulers issue GET requests
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	sweep := func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		result, err := SweepExpiredAttempts(ctx, db, time.Now())
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch in-progress attempts")
+			return
+		}
+
+		utils.RespondSuccess(w, result, "Expired attempts swept")
+	}
+
+	utils.CronOrAdmin(sweep)(w, r)
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// initialDeadline returns the deadline for a new attempt: the quiz duration,
+// stretched by any accommodation, but never past the student's window close
+func initialDeadline(quiz models.Quiz, submission models.QuizSubmission) time.Time {
+	deadline := submission.StartedAt.Add(utils.ScaleDuration(time.Duration(quiz.Duration)*time.Minute, submission.TimeMultiplier))
+	if _, closes := quizWindow(quiz, submission.StudentID); !closes.IsZero() && deadline.After(closes) {
+		deadline = closes
+	}
+	return deadline
+}
+
+// attemptDeadline returns the effective deadline of an attempt. Attempts
+// created before ExpiresAt was recorded fall back to StartedAt+TimeLimit; a
+// zero time means the attempt is untimed.
+func attemptDeadline(submission models.QuizSubmission) time.Time {
+	if !submission.ExpiresAt.IsZero() {
+		return submission.ExpiresAt
+	}
+	if submission.TimeLimit <= 0 {
+		return time.Time{}
+	}
+	return submission.StartedAt.Add(time.Duration(submission.TimeLimit) * time.Minute)
+}
+
+// attemptExpired reports whether now is past the attempt deadline plus grace
+func attemptExpired(submission models.QuizSubmission, now time.Time) bool {
+	deadline := attemptDeadline(submission)
+	return !deadline.IsZero() && now.After(deadline.Add(utils.GracePeriod()))
+}
+
+// questionView returns the recorded view of a question, if any
+func questionView(submission models.QuizSubmission, questionID string) (models.QuestionView, bool) {
+	for _, v := range submission.QuestionViews {
+		if v.QuestionID == questionID {
+			return v, true
+		}
+	}
+	return models.QuestionView{}, false
+}
+
+// newQuestionView starts a question's timer at now. Only the first view is
+// stored, so reloading a question cannot reset its clock.
+func newQuestionView(quiz models.Quiz, submission models.QuizSubmission, questionID string, now time.Time) models.QuestionView {
+	view := models.QuestionView{QuestionID: questionID, ViewedAt: now}
+	if quiz.TimePerQuestion > 0 {
+		view.ExpiresAt = now.Add(utils.ScaleDuration(time.Duration(quiz.TimePerQuestion)*time.Second, submission.TimeMultiplier))
+		if deadline := attemptDeadline(submission); !deadline.IsZero() && view.ExpiresAt.After(deadline) {
+			view.ExpiresAt = deadline
+		}
+	}
+	return view
+}
+
+// clientQuestions returns an attempt's questions as a student may see them
+// before viewing any: answer keys stripped and, with per-question timers, only
+// each question's ID and position. The body of a timed question is sent by
+// ViewQuestion once its timer has started.
+func clientQuestions(quiz models.Quiz, questions []models.Question) []models.Question {
+	if quiz.TimePerQuestion <= 0 {
+		return utils.StudentQuestions(questions)
+	}
+	placeholders := make([]models.Question, len(questions))
+	for i, q := range questions {
+		placeholders[i] = models.Question{ID: q.ID, QuizID: q.QuizID, Order: i + 1}
+	}
+	return placeholders
+}
+
+// answerOpen reports whether an answer to questionID received at now still
+// counts. With per-question timers the question must have been viewed and its
+// window (plus grace) must not have closed.
+func answerOpen(quiz models.Quiz, submission models.QuizSubmission, questionID string, now time.Time) bool {
+	if attemptExpired(submission, now) {
+		return false
+	}
+	if quiz.TimePerQuestion <= 0 {
+		return true
+	}
+
+	view, ok := questionView(submission, questionID)
+	if !ok {
+		return false
+	}
+	return view.ExpiresAt.IsZero() || !now.After(view.ExpiresAt.Add(utils.GracePeriod()))
+}
+
+// upsertAnswer replaces any saved answer to the same question
+func upsertAnswer(answers []models.Answer, answer models.Answer) []models.Answer {
+	for i := range answers {
+		if answers[i].QuestionID == answer.QuestionID {
+			answers[i] = answer
+			return answers
+		}
+	}
+	return append(answers, answer)
+}
+
+// acceptedAnswers merges the answers sent with a submission into those the
+// server already recorded. Answers arriving after their window closed are
+// dropped in favour of the recorded answer, if there is one.
+func acceptedAnswers(quiz models.Quiz, submission models.QuizSubmission, incoming []models.Answer, now time.Time) (accepted []models.Answer, dropped int) {
+	accepted = append(accepted, submission.Answers...)
+	for _, answer := range incoming {
+		if !answerOpen(quiz, submission, answer.QuestionID, now) {
+			dropped++
+			continue
+		}
+		receivedAt := now
+		answer.AnsweredAt = &receivedAt
+		accepted = upsertAnswer(accepted, answer)
+	}
+	return accepted, dropped
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler edits a quiz question (teacher/admin only). Once students have
+// attempted the quiz only the wording can change; attempts keep the copy of
+// each question they were given.
+func UpdateQuestion(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow PUT or POST
+	if r.Method != http.MethodPut && r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		// Parse request body
+		var req struct {
+			QuestionID string          `json:"questionId"`
+			Question   models.Question `json:"question"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.QuestionID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Question ID is required")
+			return
+		}
+		if err := utils.ValidateQuestion(req.Question, validQuestionTypes); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		question, quiz, ok := ownedQuizQuestion(w, r, db, req.QuestionID, userID, role)
+		if !ok {
+			return
+		}
+
+		// Apply the edit, keeping identity and position
+		updated := *question
+		updated.Text = req.Question.Text
+		updated.Type = req.Question.Type
+		updated.Points = req.Question.Points
+		updated.Options = req.Question.Options
+		updated.ScoringPolicy = req.Question.ScoringPolicy
+		updated.CorrectAnswer = req.Question.CorrectAnswer
+		updated.AcceptedAnswers = req.Question.AcceptedAnswers
+		updated.Explanation = req.Question.Explanation
+		updated.ImageURL = req.Question.ImageURL
+		updated.Difficulty = req.Question.Difficulty
+		updated.Pool = strings.TrimSpace(req.Question.Pool)
+		updated.UpdatedAt = time.Now()
+
+		// The store rejects grading changes once StartQuiz has marked the quiz
+		// attempted; quizzes attempted before that flag existed are caught here
+		gradingChanged := GradingChanged(*question, updated)
+		if gradingChanged && !quiz.Attempted {
+			attempted, err := QuizHasAttempts(ctx, db, quiz.ID)
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to check quiz attempts")
+				return
+			}
+			if attempted {
+				utils.RespondError(w, http.StatusConflict, "Students have already attempted this quiz; only the question wording can be changed")
+				return
+			}
+		}
+
+		// Save the question and correct the quiz's total marks together
+		err = db.Questions().UpdateQuizQuestion(ctx, &updated, CountsQuestionMarks(*quiz), gradingChanged)
+		if err == store.ErrQuizAttempted {
+			utils.RespondError(w, http.StatusConflict, "Students have already attempted this quiz; only the question wording can be changed")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to update question")
+			return
+		}
+
+		utils.RespondSuccess(w, map[string]interface{}{
+			"question": updated,
+		}, "Question updated successfully")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
+
+// ownedQuizQuestion loads a quiz question and its quiz, checking the caller
+// teaches the quiz's course as OwnedQuiz does. On failure it writes the error
+// response and returns false.
+func ownedQuizQuestion(w http.ResponseWriter, r *http.Request, db store.Store, questionID, userID, role string) (*models.Question, *models.Quiz, bool) {
+	question, err := db.Questions().Get(r.Context(), questionID)
+	if err == store.ErrNotFound || (err == nil && question.QuizID == "") {
+		utils.RespondError(w, http.StatusNotFound, "Question not found")
+		return nil, nil, false
+	}
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to parse question data")
+		return nil, nil, false
+	}
+
+	quiz, ok := OwnedQuiz(w, r, db, question.QuizID, userID, role)
+	if !ok {
+		return nil, nil, false
+	}
+	return question, quiz, true
+}
@@ -0,0 +1,285 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler updates quiz settings (teacher of the course or admin)
+func UpdateQuiz(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow PUT or POST
+	if r.Method != http.MethodPut && r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		// Parse request body
+		var req models.UpdateQuizRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.QuizID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Quiz ID is required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		quiz, ok := OwnedQuiz(w, r, db, req.QuizID, userID, role)
+		if !ok {
+			return
+		}
+
+		before := *quiz
+		applyQuizUpdate(quiz, req)
+
+		if err := validateQuizSettings(*quiz); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if req.Deadline != nil && !quiz.Deadline.IsZero() && quiz.Deadline.Before(time.Now()) {
+			utils.RespondError(w, http.StatusBadRequest, "Deadline must be in the future")
+			return
+		}
+
+		// Existing attempts were scored with the current settings
+		if scoringChanged(before, *quiz) {
+			attempted, err := QuizHasAttempts(ctx, db, quiz.ID)
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to check quiz attempts")
+				return
+			}
+			if attempted {
+				utils.RespondError(w, http.StatusConflict, "Students have already attempted this quiz; marks, scoring and draw rules can no longer be changed")
+				return
+			}
+		}
+
+		// Total marks is a counter shared with question edits, so an
+		// explicit change is applied as an increment after the save
+		marksDelta := quiz.TotalMarks - before.TotalMarks
+		quiz.UpdatedAt = time.Now()
+		if err := db.Quizzes().Save(ctx, quiz); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to update quiz")
+			return
+		}
+		if marksDelta != 0 {
+			if err := db.Quizzes().AddQuestionStats(ctx, quiz.ID, 0, marksDelta); err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to update quiz")
+				return
+			}
+			quiz.TotalMarks += marksDelta
+		}
+
+		utils.RespondSuccess(w, quiz, "Quiz updated successfully")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
+
+// applyQuizUpdate copies the fields set in req onto quiz
+func applyQuizUpdate(quiz *models.Quiz, req models.UpdateQuizRequest) {
+	if req.Title != nil {
+		quiz.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Description != nil {
+		quiz.Description = *req.Description
+	}
+	if req.Instructions != nil {
+		quiz.Instructions = *req.Instructions
+	}
+	if req.Duration != nil {
+		quiz.Duration = *req.Duration
+	}
+	if req.TotalMarks != nil {
+		quiz.TotalMarks = *req.TotalMarks
+	}
+	if req.PassingMarks != nil {
+		quiz.PassingMarks = *req.PassingMarks
+	}
+	if req.NegativeMarking != nil {
+		quiz.NegativeMarking = *req.NegativeMarking
+	}
+	if req.NegativeMarkValue != nil {
+		quiz.NegativeMarkValue = *req.NegativeMarkValue
+	}
+	if req.AllowNegativeScore != nil {
+		quiz.AllowNegativeScore = *req.AllowNegativeScore
+	}
+	if req.Deadline != nil {
+		quiz.Deadline = *req.Deadline
+	}
+	if req.StartDate != nil {
+		quiz.StartDate = optionalTime(*req.StartDate)
+	}
+	if req.EndDate != nil {
+		quiz.EndDate = optionalTime(*req.EndDate)
+	}
+	if req.DrawRules != nil {
+		quiz.DrawRules = *req.DrawRules
+	}
+	if req.ShowResultsAfterSubmit != nil {
+		quiz.ShowResultsAfterSubmit = *req.ShowResultsAfterSubmit
+	}
+	if req.ShuffleQuestions != nil {
+		quiz.ShuffleQuestions = *req.ShuffleQuestions
+	}
+	if req.ShuffleOptions != nil {
+		quiz.ShuffleOptions = *req.ShuffleOptions
+	}
+	if req.AllowReview != nil {
+		quiz.AllowReview = *req.AllowReview
+	}
+	if req.MaxAttempts != nil {
+		quiz.MaxAttempts = *req.MaxAttempts
+	}
+	if req.AttemptPolicy != nil {
+		quiz.AttemptPolicy = *req.AttemptPolicy
+	}
+	if req.PreventTabSwitch != nil {
+		quiz.PreventTabSwitch = *req.PreventTabSwitch
+	}
+	if req.MaxTabSwitches != nil {
+		quiz.MaxTabSwitches = *req.MaxTabSwitches
+	}
+	if req.RequireFullscreen != nil {
+		quiz.RequireFullscreen = *req.RequireFullscreen
+	}
+	if req.DisableCopyPaste != nil {
+		quiz.DisableCopyPaste = *req.DisableCopyPaste
+	}
+	if req.EnableProctoring != nil {
+		quiz.EnableProctoring = *req.EnableProctoring
+	}
+	if req.RandomizeQuestionOrder != nil {
+		quiz.RandomizeQuestionOrder = *req.RandomizeQuestionOrder
+	}
+	if req.TimePerQuestion != nil {
+		quiz.TimePerQuestion = *req.TimePerQuestion
+	}
+	if req.AllowTeacherResume != nil {
+		quiz.AllowTeacherResume = *req.AllowTeacherResume
+	}
+	if req.AllowTeacherExtendTime != nil {
+		quiz.AllowTeacherExtendTime = *req.AllowTeacherExtendTime
+	}
+}
+
+// validateQuizSettings applies CreateQuiz's rules to an edited quiz
+func validateQuizSettings(quiz models.Quiz) error {
+	if quiz.Title == "" {
+		return errors.New("Title is required")
+	}
+	if quiz.TotalMarks <= 0 {
+		return errors.New("Total marks must be greater than 0")
+	}
+	if quiz.PassingMarks < 0 || quiz.PassingMarks > quiz.TotalMarks {
+		return errors.New("Invalid passing marks")
+	}
+	if quiz.Duration <= 0 {
+		return errors.New("Duration must be greater than 0")
+	}
+	if quiz.NegativeMarking && quiz.NegativeMarkValue <= 0 {
+		return errors.New("Negative mark value must be greater than 0")
+	}
+	if quiz.MaxAttempts < 0 || quiz.TimePerQuestion < 0 || quiz.MaxTabSwitches < 0 {
+		return errors.New("Attempts, tab switches and time per question cannot be negative")
+	}
+	if !ValidAttemptPolicy(quiz.AttemptPolicy) {
+		return errors.New("Invalid attempt policy. Must be best, latest, or average")
+	}
+	if err := validateDrawRules(quiz.DrawRules); err != nil {
+		return errors.New("Invalid draw rules: " + err.Error())
+	}
+	return validateWindow(quiz.StartDate, quiz.EndDate)
+}
+
+// optionalTime returns nil for a zero time so updates can clear a date
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// scoringChanged reports whether an edit changes how attempts are marked
+func scoringChanged(before, after models.Quiz) bool {
+	return before.TotalMarks != after.TotalMarks ||
+		before.PassingMarks != after.PassingMarks ||
+		before.NegativeMarking != after.NegativeMarking ||
+		before.NegativeMarkValue != after.NegativeMarkValue ||
+		before.AllowNegativeScore != after.AllowNegativeScore ||
+		!reflect.DeepEqual(before.DrawRules, after.DrawRules)
+}
+
+// OwnedQuiz loads a quiz that hasn't been deleted and checks the caller
+// teaches its course, as CreateQuiz does. On failure it writes the error
+// response and returns false.
+func OwnedQuiz(w http.ResponseWriter, r *http.Request, db store.Store, quizID, userID, role string) (*models.Quiz, bool) {
+	ctx := r.Context()
+
+	quiz, err := db.Quizzes().Get(ctx, quizID)
+	if err == store.ErrNotFound || (err == nil && quiz.IsDeleted) {
+		utils.RespondError(w, http.StatusNotFound, "Quiz not found")
+		return nil, false
+	}
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to parse quiz data")
+		return nil, false
+	}
+
+	teaches, err := TeachesQuiz(ctx, db, *quiz, userID, role)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to parse course data")
+		return nil, false
+	}
+	if !teaches {
+		utils.RespondError(w, http.StatusForbidden, "You can only manage quizzes for your own courses")
+		return nil, false
+	}
+
+	return quiz, true
+}
+
+// TeachesQuiz reports whether the caller may manage quiz: an admin, or the
+// teacher of its course
+func TeachesQuiz(ctx context.Context, db store.Store, quiz models.Quiz, userID, role string) (bool, error) {
+	if role == "admin" {
+		return true, nil
+	}
+	course, err := db.Courses().Get(ctx, quiz.CourseID)
+	if err == store.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return course.TeacherID == userID, nil
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler records when a question is shown, starting its per-question timer,
+// and returns the question without its answer key
+func ViewQuestion(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (students only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		if role != "student" {
+			utils.RespondError(w, http.StatusForbidden, "Only students can view quiz questions")
+			return
+		}
+
+		// Parse request body
+		var req models.QuestionViewRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.SubmissionID == "" || req.QuestionID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Submission ID and question ID are required")
+			return
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		// Get submission
+		submission, err := db.Submissions().Get(ctx, req.SubmissionID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Submission not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse submission data")
+			return
+		}
+
+		if submission.StudentID != userID {
+			utils.RespondError(w, http.StatusForbidden, "You can only view your own quiz")
+			return
+		}
+
+		if submission.Status != "in_progress" {
+			utils.RespondError(w, http.StatusBadRequest, "Quiz already submitted")
+			return
+		}
+
+		// The question must be part of this attempt
+		var question *models.Question
+		for i := range submission.Questions {
+			if submission.Questions[i].ID == req.QuestionID {
+				question = &submission.Questions[i]
+				break
+			}
+		}
+		if question == nil {
+			utils.RespondError(w, http.StatusBadRequest, "Question is not part of this attempt")
+			return
+		}
+
+		// Get quiz
+		quiz, err := db.Quizzes().Get(ctx, submission.QuizID)
+		if err == store.ErrNotFound {
+			utils.RespondError(w, http.StatusNotFound, "Quiz not found")
+			return
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to parse quiz data")
+			return
+		}
+
+		now := time.Now()
+		accommodation, err := store.StudentAccommodation(ctx, db, submission.StudentID, quiz.CourseID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch accommodations")
+			return
+		}
+		capToWindow(withAccommodation(*quiz, accommodation), submission)
+		if attemptExpired(*submission, now) {
+			utils.RespondError(w, http.StatusForbidden, "Time limit exceeded")
+			return
+		}
+
+		// Views are stored apart from the answers, so recording one does not
+		// change the attempt version the client autosaves against
+		view, ok := questionView(*submission, req.QuestionID)
+		if !ok {
+			first, err := db.Submissions().AddQuestionView(ctx, submission.ID, newQuestionView(*quiz, *submission, req.QuestionID, now))
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to record question view")
+				return
+			}
+			view = *first
+		}
+
+		// The question's body is only sent once its timer is running
+		utils.RespondSuccess(w, map[string]interface{}{
+			"question":   utils.StudentQuestions([]models.Question{*question})[0],
+			"questionId": view.QuestionID,
+			"viewedAt":   view.ViewedAt,
+			"expiresAt":  view.ExpiresAt,
+			"deadline":   attemptDeadline(*submission),
+			"serverTime": now,
+			"version":    submission.Version,
+		})
+	})).ServeHTTP(w, r)
+}
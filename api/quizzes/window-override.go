@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// Handler sets or removes one student's availability window on a quiz, e.g.
+// to let a student with an accommodation sit it outside the class window
+func SetWindowOverride(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	utils.EnableCORS(w, r)
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only allow POST
+	if r.Method != http.MethodPost {
+		utils.RespondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Authenticate (teacher/admin only)
+	utils.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := ctx.Value("uid").(string)
+		role := ctx.Value("role").(string)
+
+		// Parse request body
+		var req models.WindowOverrideRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.QuizID == "" || req.StudentID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "Quiz ID and student ID are required")
+			return
+		}
+		if !req.Remove {
+			if req.StartDate == nil && req.EndDate == nil {
+				utils.RespondError(w, http.StatusBadRequest, "Start date or end date is required")
+				return
+			}
+			if err := validateWindow(req.StartDate, req.EndDate); err != nil {
+				utils.RespondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		// Get data store
+		db, err := store.GetStore(ctx)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to initialize Firestore")
+			return
+		}
+
+		quiz, ok := OwnedQuiz(w, r, db, req.QuizID, userID, role)
+		if !ok {
+			return
+		}
+
+		// Drop any existing override for the student
+		overrides := make([]models.WindowOverride, 0, len(quiz.WindowOverrides))
+		for _, o := range quiz.WindowOverrides {
+			if o.StudentID != req.StudentID {
+				overrides = append(overrides, o)
+			}
+		}
+
+		if !req.Remove {
+			enrollments, err := db.Enrollments().List(ctx, store.EnrollmentFilter{
+				StudentID: req.StudentID,
+				CourseID:  quiz.CourseID,
+				Status:    "active",
+			})
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch enrollments")
+				return
+			}
+			if len(enrollments) == 0 {
+				utils.RespondError(w, http.StatusBadRequest, "Student is not enrolled in this course")
+				return
+			}
+
+			overrides = append(overrides, models.WindowOverride{
+				StudentID: req.StudentID,
+				StartDate: req.StartDate,
+				EndDate:   req.EndDate,
+				Reason:    req.Reason,
+				GrantedBy: userID,
+				GrantedAt: time.Now(),
+			})
+		}
+
+		quiz.WindowOverrides = overrides
+		quiz.UpdatedAt = time.Now()
+		if err := db.Quizzes().Save(ctx, quiz); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to update quiz")
+			return
+		}
+
+		utils.RespondSuccess(w, map[string]interface{}{
+			"quizId":       quiz.ID,
+			"studentId":    req.StudentID,
+			"availability": quizAvailability(*quiz, req.StudentID, time.Now()),
+			"overrides":    quiz.WindowOverrides,
+		}, "Window override saved")
+	}), "teacher", "admin").ServeHTTP(w, r)
+}
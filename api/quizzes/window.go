@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+)
+
+// Availability states shown to students
+const (
+	windowUpcoming = "upcoming"
+	windowOpen     = "open"
+	windowClosed   = "closed"
+)
+
+// windowOverride returns a student's override on the quiz, if any
+func windowOverride(quiz models.Quiz, studentID string) (models.WindowOverride, bool) {
+	for _, o := range quiz.WindowOverrides {
+		if o.StudentID == studentID {
+			return o, true
+		}
+	}
+	return models.WindowOverride{}, false
+}
+
+// quizWindow returns when the quiz opens and closes for a student. The quiz
+// closes at the earlier of EndDate and Deadline unless the student has an
+// override end. A zero time means no limit.
+func quizWindow(quiz models.Quiz, studentID string) (opens, closes time.Time) {
+	if quiz.StartDate != nil {
+		opens = *quiz.StartDate
+	}
+	closes = quiz.Deadline
+	if quiz.EndDate != nil && (closes.IsZero() || quiz.EndDate.Before(closes)) {
+		closes = *quiz.EndDate
+	}
+
+	if o, ok := windowOverride(quiz, studentID); ok {
+		if o.StartDate != nil {
+			opens = *o.StartDate
+		}
+		if o.EndDate != nil {
+			closes = *o.EndDate
+		}
+	}
+	return opens, closes
+}
+
+// ClosesAt returns when the quiz closes for a student, with their window
+// override or accommodation applied. A zero time means it never closes.
+func ClosesAt(quiz models.Quiz, accommodation *models.Accommodation, studentID string) time.Time {
+	_, closes := quizWindow(withAccommodation(quiz, accommodation), studentID)
+	return closes
+}
+
+// quizAvailability describes a student's window at now
+func quizAvailability(quiz models.Quiz, studentID string, now time.Time) models.QuizAvailability {
+	opens, closes := quizWindow(quiz, studentID)
+
+	availability := models.QuizAvailability{Status: windowOpen}
+	if !opens.IsZero() {
+		availability.OpensAt = &opens
+	}
+	if !closes.IsZero() {
+		availability.ClosesAt = &closes
+	}
+
+	switch {
+	case !opens.IsZero() && now.Before(opens):
+		availability.Status = windowUpcoming
+	case !closes.IsZero() && now.After(closes):
+		availability.Status = windowClosed
+	}
+	return availability
+}
+
+// studentView prepares a quiz for a student: their availability is filled in
+// and other students' overrides are removed
+func studentView(quiz models.Quiz, studentID string, now time.Time) models.Quiz {
+	availability := quizAvailability(quiz, studentID, now)
+	quiz.Availability = &availability
+	quiz.WindowOverrides = nil
+	return quiz
+}
+
+// capToWindow brings an attempt's deadline in to the student's window close
+// when the window now ends first, e.g. after the teacher shortened it
+func capToWindow(quiz models.Quiz, submission *models.QuizSubmission) {
+	_, closes := quizWindow(quiz, submission.StudentID)
+	if closes.IsZero() {
+		return
+	}
+	if deadline := attemptDeadline(*submission); deadline.IsZero() || closes.Before(deadline) {
+		submission.ExpiresAt = closes
+	}
+}
+
+// validateWindow checks that a window ends after it starts
+func validateWindow(start, end *time.Time) error {
+	if start != nil && end != nil && !end.After(*start) {
+		return errors.New("End date must be after start date")
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// runBootstrapAdmin gives an existing account the admin role and exits. It
+// is how the first admin is made, since registration never creates admins.
+func runBootstrapAdmin(args []string) error {
+	fs := flag.NewFlagSet("bootstrap-admin", flag.ExitOnError)
+	email := fs.String("email", "", "email of the registered account to make an admin")
+	backend := fs.String("store", envOr("LMS_STORE", "firestore"), "data store backend: firestore or memory (env LMS_STORE)")
+	timeout := fs.Duration("timeout", time.Minute, "maximum time the command may run")
+	fs.Parse(args)
+
+	if *email == "" {
+		return errors.New("-email is required")
+	}
+
+	if err := configureStore(*backend); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	authClient, err := utils.GetAuthClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize auth client: %v", err)
+	}
+	db, err := store.GetStore(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Firestore: %v", err)
+	}
+
+	account, err := authClient.GetUserByEmail(ctx, *email)
+	if err != nil {
+		return fmt.Errorf("no account for %s: %v", *email, err)
+	}
+
+	if err := authClient.SetCustomUserClaims(ctx, account.UID, map[string]interface{}{"role": "admin"}); err != nil {
+		return fmt.Errorf("failed to set role: %v", err)
+	}
+
+	// Accounts created outside the API may not have a profile yet
+	now := utils.GetCurrentTimestamp()
+	user, err := db.Users().Get(ctx, account.UID)
+	if err == store.ErrNotFound {
+		user = &models.User{
+			UID:         account.UID,
+			Email:       account.Email,
+			DisplayName: account.DisplayName,
+			IsActive:    true,
+			CreatedAt:   now,
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to fetch profile: %v", err)
+	}
+	user.Role = "admin"
+	user.UpdatedAt = now
+	if err := db.Users().Save(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user role in database: %v", err)
+	}
+
+	db.AuditLogs().Create(ctx, &models.AuditLog{
+		Action:     "admin_bootstrapped",
+		ActorID:    "cli",
+		TargetType: "user",
+		TargetID:   account.UID,
+		Timestamp:  now,
+	})
+	log.Printf("%s (%s) is now an admin; they get the role on their next sign-in", *email, account.UID)
+	return nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	authHandlers "github.com/Ravikiran27/GOLANG_SmartEdu-LMS/api/auth"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// runImportUsers creates users from a roster CSV and exits, printing one
+// line per row that was not created and a summary
+func runImportUsers(args []string) error {
+	fs := flag.NewFlagSet("import-users", flag.ExitOnError)
+	file := fs.String("file", "", "roster CSV with email, displayName, role, department, rollNumber and employeeId columns")
+	dryRun := fs.Bool("dry-run", false, "validate the roster without creating users")
+	courses := fs.String("courses", "", "comma-separated course IDs to enroll imported students in")
+	backend := fs.String("store", envOr("LMS_STORE", "firestore"), "data store backend: firestore or memory (env LMS_STORE)")
+	timeout := fs.Duration("timeout", 30*time.Minute, "maximum time the import may run")
+	fs.Parse(args)
+
+	if *file == "" {
+		return errors.New("-file is required")
+	}
+	f, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	rows, err := authHandlers.ParseRoster(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	if err := configureStore(*backend); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	db, err := store.GetStore(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Firestore: %v", err)
+	}
+
+	var courseIDs []string
+	if *courses != "" {
+		courseIDs = strings.Split(*courses, ",")
+	}
+	enrollIn, err := authHandlers.RosterCourses(ctx, db, courseIDs)
+	if err != nil {
+		return err
+	}
+
+	report, err := authHandlers.ImportRoster(ctx, db, utils.GetAccountImporter(), rows, enrollIn, *dryRun, "cli")
+	if err != nil {
+		return fmt.Errorf("import failed: %v", err)
+	}
+
+	for _, row := range report.Rows {
+		if len(row.Errors) > 0 {
+			log.Printf("line %d %s: %s: %s", row.Line, row.Email, row.Status, strings.Join(row.Errors, "; "))
+		}
+	}
+	if *dryRun {
+		log.Printf("Dry run complete: %d rows, %d valid, %d already registered, %d invalid", report.Total, report.Valid, report.Existing, report.Invalid)
+	} else {
+		log.Printf("Import complete: %d rows, %d created, %d already registered, %d invalid, %d failed, %d enrollments", report.Total, report.Created, report.Existing, report.Invalid, report.Failed, report.Enrolled)
+	}
+
+	if report.Invalid > 0 || report.Failed > 0 {
+		return fmt.Errorf("%d rows were not imported", report.Invalid+report.Failed)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+// Command server runs the LMS API as a standalone HTTP server for deployments
+// outside Vercel. All API routers are mounted on a single mux alongside
+// /healthz and /readyz probes.
+//
+// `server sweep` instead auto-submits expired quiz and exam attempts once and exits,
+// for use from cron.
+//
+// `server import-users -file roster.csv` creates users from a roster CSV,
+// with -dry-run to only validate and -courses to enroll the new students.
+//
+// `server bootstrap-admin -email x` makes an existing account an admin; it is
+// the only way to create the first admin.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	api "github.com/Ravikiran27/GOLANG_SmartEdu-LMS/api"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// config holds the server settings; every flag falls back to an env var
+type config struct {
+	addr            string
+	storeBackend    string
+	readTimeout     time.Duration
+	writeTimeout    time.Duration
+	shutdownTimeout time.Duration
+}
+
+func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "sweep":
+			runCommand(runSweep, os.Args[2:])
+			return
+		case "import-users":
+			runCommand(runImportUsers, os.Args[2:])
+			return
+		case "bootstrap-admin":
+			runCommand(runBootstrapAdmin, os.Args[2:])
+			return
+		}
+	}
+
+	cfg := parseConfig(os.Args[1:])
+
+	if err := configureStore(cfg.storeBackend); err != nil {
+		log.Fatalf("ERROR: %v", err)
+	}
+	if err := utils.InitTokenVerifier(); err != nil {
+		log.Fatalf("ERROR: %v", err)
+	}
+
+	srv := &http.Server{
+		Addr:         cfg.addr,
+		Handler:      newMux(),
+		ReadTimeout:  cfg.readTimeout,
+		WriteTimeout: cfg.writeTimeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Printf("LMS API listening on %s (store: %s)", cfg.addr, cfg.storeBackend)
+		errCh <- srv.ListenAndServe()
+	}()
+
+	failed := false
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("ERROR: Server failed: %v", err)
+			failed = true
+		}
+	case <-ctx.Done():
+		log.Println("Shutting down...")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.shutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("ERROR: Graceful shutdown failed: %v", err)
+	}
+	if err := utils.CloseFirestore(); err != nil {
+		log.Printf("ERROR: Failed to close Firestore: %v", err)
+	}
+	log.Println("Server stopped")
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// runCommand runs a subcommand, closes Firestore and exits non-zero if the
+// command failed
+func runCommand(run func(args []string) error, args []string) {
+	err := run(args)
+	if closeErr := utils.CloseFirestore(); closeErr != nil {
+		log.Printf("ERROR: Failed to close Firestore: %v", closeErr)
+	}
+	if err != nil {
+		log.Printf("ERROR: %v", err)
+		os.Exit(1)
+	}
+}
+
+func parseConfig(args []string) config {
+	fs := flag.NewFlagSet("server", flag.ExitOnError)
+
+	var cfg config
+	fs.StringVar(&cfg.addr, "addr", defaultAddr(), "listen address (env ADDR, or PORT)")
+	fs.StringVar(&cfg.storeBackend, "store", envOr("LMS_STORE", "firestore"), "data store backend: firestore or memory (env LMS_STORE)")
+	fs.DurationVar(&cfg.readTimeout, "read-timeout", envDuration("READ_TIMEOUT", 15*time.Second), "HTTP read timeout (env READ_TIMEOUT)")
+	fs.DurationVar(&cfg.writeTimeout, "write-timeout", envDuration("WRITE_TIMEOUT", 30*time.Second), "HTTP write timeout (env WRITE_TIMEOUT)")
+	fs.DurationVar(&cfg.shutdownTimeout, "shutdown-timeout", envDuration("SHUTDOWN_TIMEOUT", 15*time.Second), "time allowed for in-flight requests on shutdown (env SHUTDOWN_TIMEOUT)")
+	fs.Parse(args)
+
+	return cfg
+}
+
+// configureStore selects the data store; Firestore is initialized lazily by
+// the first request or readiness probe
+func configureStore(backend string) error {
+	switch backend {
+	case "firestore":
+		return nil
+	case "memory":
+		log.Println("Using in-memory store; data is lost on restart")
+		store.SetStore(store.NewMemoryStore())
+		return nil
+	default:
+		return errors.New("unknown store backend: " + backend)
+	}
+}
+
+func newMux() *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/auth/", api.AuthRouter)
+	mux.HandleFunc("/api/courses/", api.CoursesRouter)
+	mux.HandleFunc("/api/quizzes/", api.QuizzesRouter)
+	mux.HandleFunc("/api/exams/", api.ExamsRouter)
+	mux.HandleFunc("/api/assignments/", api.AssignmentsRouter)
+	mux.HandleFunc("/api/bank/", api.BankRouter)
+	mux.HandleFunc("/api/accommodations/", api.AccommodationsRouter)
+	mux.HandleFunc("/api/analytics/", api.AnalyticsRouter)
+	mux.HandleFunc("/api", api.Handler)
+	mux.HandleFunc("/api/", api.Handler)
+
+	mux.HandleFunc("/healthz", healthz)
+	mux.HandleFunc("/readyz", readyz)
+
+	return mux
+}
+
+// healthz reports that the process is up
+func healthz(w http.ResponseWriter, r *http.Request) {
+	writeStatus(w, http.StatusOK, map[string]interface{}{"status": "ok"})
+}
+
+// readyProbeUID names a user document that never exists; reading it is the
+// cheapest round trip that proves the store answers queries
+const readyProbeUID = "__readyz__"
+
+// readyz reports whether the data store can serve requests
+func readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	err := checkStore(ctx)
+	if err != nil {
+		writeStatus(w, http.StatusServiceUnavailable, map[string]interface{}{
+			"status": "unavailable",
+			"error":  err.Error(),
+		})
+		return
+	}
+	writeStatus(w, http.StatusOK, map[string]interface{}{"status": "ready"})
+}
+
+// checkStore initializes the store and performs one read against it
+func checkStore(ctx context.Context) error {
+	db, err := store.GetStore(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := db.Users().Get(ctx, readyProbeUID); err != nil && err != store.ErrNotFound {
+		return err
+	}
+	return nil
+}
+
+func writeStatus(w http.ResponseWriter, status int, body map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func defaultAddr() string {
+	if addr := os.Getenv("ADDR"); addr != "" {
+		return addr
+	}
+	if port := os.Getenv("PORT"); port != "" {
+		return ":" + port
+	}
+	return ":8080"
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func envDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("ERROR: Invalid %s %q, using %s", key, v, fallback)
+		return fallback
+	}
+	return d
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+)
+
+// unreachableStore fails every user read, like a Firestore outage
+type unreachableStore struct{ store.Store }
+
+func (unreachableStore) Users() store.UserRepository { return unreachableUsers{} }
+
+type unreachableUsers struct{ store.UserRepository }
+
+func (unreachableUsers) Get(ctx context.Context, uid string) (*models.User, error) {
+	return nil, errors.New("deadline exceeded")
+}
+
+func TestReadyz(t *testing.T) {
+	tests := []struct {
+		name string
+		db   store.Store
+		want int
+	}{
+		{"store answers", store.NewMemoryStore(), http.StatusOK},
+		{"store unreachable", unreachableStore{store.NewMemoryStore()}, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store.SetStore(tt.db)
+			t.Cleanup(func() { store.SetStore(nil) })
+
+			rec := httptest.NewRecorder()
+			readyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			if rec.Code != tt.want {
+				t.Errorf("status %d, want %d: %s", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	examHandlers "github.com/Ravikiran27/GOLANG_SmartEdu-LMS/api/exams"
+	quizHandlers "github.com/Ravikiran27/GOLANG_SmartEdu-LMS/api/quizzes"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/store"
+)
+
+// runSweep auto-submits expired in-progress quiz and exam attempts and exits
+func runSweep(args []string) error {
+	fs := flag.NewFlagSet("sweep", flag.ExitOnError)
+	backend := fs.String("store", envOr("LMS_STORE", "firestore"), "data store backend: firestore or memory (env LMS_STORE)")
+	timeout := fs.Duration("timeout", 5*time.Minute, "maximum time the sweep may run")
+	fs.Parse(args)
+
+	if err := configureStore(*backend); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	db, err := store.GetStore(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Firestore: %v", err)
+	}
+
+	result, err := quizHandlers.SweepExpiredAttempts(ctx, db, time.Now())
+	if err != nil {
+		return fmt.Errorf("sweep failed: %v", err)
+	}
+	log.Printf("Sweep complete: %d in progress, %d auto-submitted, %d failed", result.Checked, result.AutoSubmitted, result.Failed)
+
+	examResult, err := examHandlers.SweepExpiredAttempts(ctx, db, time.Now())
+	if err != nil {
+		return fmt.Errorf("exam sweep failed: %v", err)
+	}
+	log.Printf("Exam sweep complete: %d in progress, %d auto-submitted, %d failed", examResult.Checked, examResult.AutoSubmitted, examResult.Failed)
+	return nil
+}
@@ -11,14 +11,16 @@ export async function POST(request: NextRequest) {
     const body: CreateUserRequest = await request.json();
 
     // Validate required fields
-    if (!body.email || !body.password || !body.displayName || !body.role) {
-      return errorResponse('Email, password, displayName, and role are required', 400);
+    if (!body.email || !body.password || !body.displayName) {
+      return errorResponse('Email, password, and displayName are required', 400);
     }
 
-    // Validate role
-    if (!['admin', 'teacher', 'student'].includes(body.role)) {
-      return errorResponse('Invalid role. Must be admin, teacher, or student', 400);
+    // Public sign-up only creates students; teachers register through the Go
+    // API with an invitation code or approval, and admins are never self-registered
+    if (body.role && body.role !== 'student') {
+      return errorResponse('Only student accounts can be self-registered', 403);
     }
+    const role = 'student';
 
     // Create user in Firebase Auth
     const firebaseUser = await auth.createUser({
@@ -30,7 +32,7 @@ export async function POST(request: NextRequest) {
     });
 
     // Set custom claims for role
-    await auth.setCustomUserClaims(firebaseUser.uid, { role: body.role });
+    await auth.setCustomUserClaims(firebaseUser.uid, { role });
 
     // Create user document in Firestore
     const now = new Date();
@@ -38,7 +40,7 @@ export async function POST(request: NextRequest) {
       uid: firebaseUser.uid,
       email: body.email,
       displayName: body.displayName,
-      role: body.role,
+      role,
       photoURL: '',
       isActive: true,
       createdAt: now,
@@ -56,7 +58,7 @@ export async function POST(request: NextRequest) {
     return createdResponse({
       uid: firebaseUser.uid,
       email: body.email,
-      role: body.role,
+      role,
     }, 'User registered successfully');
 
   } catch (error: any) {
@@ -82,10 +82,15 @@ export const api = {
       }),
     delete: (id: string) =>
       apiRequest(`/api/courses/delete?id=${id}`, { method: 'DELETE' }),
-    enroll: (courseId: string) =>
+    enroll: (courseId: string, joinCode?: string) =>
       apiRequest('/api/courses/enroll', {
         method: 'POST',
-        body: JSON.stringify({ courseId }),
+        body: JSON.stringify({ courseId, joinCode }),
+      }),
+    join: (code: string) =>
+      apiRequest('/api/courses/join', {
+        method: 'POST',
+        body: JSON.stringify({ code }),
       }),
     myEnrollments: () => apiRequest('/api/courses/my-enrollments'),
   },
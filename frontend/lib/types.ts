@@ -24,10 +24,11 @@ export interface CreateUserRequest {
   email: string;
   password: string;
   displayName: string;
-  role: 'admin' | 'teacher' | 'student';
+  role?: 'teacher' | 'student';
   department?: string;
   rollNumber?: string;
   employeeId?: string;
+  inviteCode?: string;
 }
 
 export interface UpdateUserRequest {
@@ -52,11 +53,39 @@ export interface Course {
   materials: CourseMaterial[];
   enrollmentCount: number;
   isPublished: boolean;
+  enrollmentMode?: EnrollmentMode;
   createdAt: Date;
   updatedAt: Date;
   isDeleted: boolean;
 }
 
+export type EnrollmentMode = 'open' | 'invite_code' | 'approval_required' | 'closed';
+
+export interface JoinCode {
+  code: string;
+  courseId: string;
+  maxUses: number;
+  uses: number;
+  expiresAt?: Date;
+  revoked: boolean;
+  createdBy: string;
+  createdAt: Date;
+}
+
+export interface JoinRequest {
+  courseId: string;
+  courseTitle: string;
+  studentId: string;
+  studentName: string;
+  studentEmail: string;
+  message?: string;
+  status: 'pending' | 'approved' | 'rejected';
+  reviewedBy?: string;
+  reviewNote?: string;
+  reviewedAt?: Date;
+  createdAt: Date;
+}
+
 export interface CourseMaterial {
   id: string;
   name: string;
@@ -73,6 +102,7 @@ export interface CreateCourseRequest {
   category: string;
   difficulty: string;
   thumbnail?: string;
+  enrollmentMode?: EnrollmentMode;
 }
 
 export interface UpdateCourseRequest {
@@ -84,6 +114,7 @@ export interface UpdateCourseRequest {
   thumbnail?: string;
   materials?: CourseMaterial[];
   isPublished?: boolean;
+  enrollmentMode?: EnrollmentMode;
 }
 
 export interface Enrollment {
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Accommodation is a student's documented adjustment for timed quizzes and
+// exams. A course-scoped record takes precedence over the student's global one.
+type Accommodation struct {
+	ID             string     `firestore:"id" json:"id"`
+	StudentID      string     `firestore:"studentId" json:"studentId"`
+	CourseID       string     `firestore:"courseId" json:"courseId"`                       // empty = every course
+	TimeMultiplier float64    `firestore:"timeMultiplier" json:"timeMultiplier"`           // 1.5 = 50% more time; 0 or 1 = none
+	ExtraAttempts  int        `firestore:"extraAttempts" json:"extraAttempts"`             // added to a quiz's attempt limit
+	StartDate      *time.Time `firestore:"startDate,omitempty" json:"startDate,omitempty"` // alternative window
+	EndDate        *time.Time `firestore:"endDate,omitempty" json:"endDate,omitempty"`
+	Reason         string     `firestore:"reason,omitempty" json:"reason,omitempty"`
+	GrantedBy      string     `firestore:"grantedBy" json:"grantedBy"`
+	CreatedAt      time.Time  `firestore:"createdAt" json:"createdAt"`
+	UpdatedAt      time.Time  `firestore:"updatedAt" json:"updatedAt"`
+}
+
+// AccommodationRequest grants or replaces a student's accommodation
+type AccommodationRequest struct {
+	StudentID      string     `json:"studentId" validate:"required"`
+	CourseID       string     `json:"courseId,omitempty"` // omit for a global accommodation (admin only)
+	TimeMultiplier float64    `json:"timeMultiplier,omitempty"`
+	ExtraAttempts  int        `json:"extraAttempts,omitempty"`
+	StartDate      *time.Time `json:"startDate,omitempty"`
+	EndDate        *time.Time `json:"endDate,omitempty"`
+	Reason         string     `json:"reason,omitempty"`
+}
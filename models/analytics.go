@@ -54,11 +54,49 @@ type ActivityLog struct {
 	Score     float64   `json:"score,omitempty"`
 }
 
+// ItemAnalysis holds per-question statistics for a quiz, computed from each
+// student's first completed attempt
+type ItemAnalysis struct {
+	QuizID           string          `json:"quizId"`
+	AttemptsAnalyzed int             `json:"attemptsAnalyzed"`
+	GroupSize        int             `json:"groupSize"` // students in each of the top and bottom 27% groups
+	Items            []ItemStatistic `json:"items"`
+}
+
+// ItemStatistic describes how students did on one question
+type ItemStatistic struct {
+	QuestionID          string            `json:"questionId"`
+	Text                string            `json:"text"`
+	Type                string            `json:"type"`
+	Pool                string            `json:"pool,omitempty"`
+	Attempts            int               `json:"attempts"` // attempts that were given the question
+	Correct             int               `json:"correct"`
+	Omitted             int               `json:"omitted"`
+	PendingReview       int               `json:"pendingReview"` // left out of the indices until graded
+	DifficultyIndex     *float64          `json:"difficultyIndex"`     // percent correct; higher is easier
+	DiscriminationIndex *float64          `json:"discriminationIndex"` // top group minus bottom group proportion correct, -1 to 1
+	AverageTimeSeconds  *float64          `json:"averageTimeSeconds"`  // first view to answer, needs view-question
+	TimedResponses      int               `json:"timedResponses"`
+	Options             []OptionStatistic `json:"options,omitempty"`
+}
+
+// OptionStatistic counts how often an option was chosen, overall and in the
+// top and bottom groups
+type OptionStatistic struct {
+	OptionID   string  `json:"optionId"`
+	Text       string  `json:"text"`
+	IsCorrect  bool    `json:"isCorrect"`
+	Count      int     `json:"count"`
+	Percentage float64 `json:"percentage"` // of attempts given the question
+	UpperCount int     `json:"upperCount"`
+	LowerCount int     `json:"lowerCount"`
+}
+
 // Notification represents a notification
 type Notification struct {
 	NotificationID string    `firestore:"notificationId" json:"notificationId"`
 	UserID         string    `firestore:"userId" json:"userId"`
-	Type           string    `firestore:"type" json:"type"` // course_update | quiz_published | assignment_due | grade_released
+	Type           string    `firestore:"type" json:"type"` // course_update | quiz_published | assignment_due | grade_released | role_request | enrollment_request
 	Title          string    `firestore:"title" json:"title"`
 	Message        string    `firestore:"message" json:"message"`
 	ReferenceID    string    `firestore:"referenceId" json:"referenceId"`
@@ -66,3 +104,14 @@ type Notification struct {
 	IsRead         bool      `firestore:"isRead" json:"isRead"`
 	CreatedAt      time.Time `firestore:"createdAt" json:"createdAt"`
 }
+
+// AuditLog records a privileged action for later review
+type AuditLog struct {
+	LogID      string                 `firestore:"logId" json:"logId"`
+	Action     string                 `firestore:"action" json:"action"` // quiz_resumed | ...
+	ActorID    string                 `firestore:"actorId" json:"actorId"`
+	TargetType string                 `firestore:"targetType" json:"targetType"` // quiz_submission | user | course ...
+	TargetID   string                 `firestore:"targetId" json:"targetId"`
+	Details    map[string]interface{} `firestore:"details,omitempty" json:"details,omitempty"`
+	Timestamp  time.Time              `firestore:"timestamp" json:"timestamp"`
+}
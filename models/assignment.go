@@ -54,6 +54,7 @@ type AssignmentSubmission struct {
 	IsLateSubmission bool                          `firestore:"isLateSubmission" json:"isLateSubmission"`
 	DaysLate         int                           `firestore:"daysLate" json:"daysLate"`
 	MarksAwarded     float64                       `firestore:"marksAwarded" json:"marksAwarded"`
+	LatePenaltyApplied float64                     `firestore:"latePenaltyApplied" json:"latePenaltyApplied"` // marks deducted for late submission
 	Feedback         string                        `firestore:"feedback,omitempty" json:"feedback,omitempty"`
 	Status           string                        `firestore:"status" json:"status"` // submitted | evaluated | returned
 	EvaluatedAt      *time.Time                    `firestore:"evaluatedAt,omitempty" json:"evaluatedAt,omitempty"`
@@ -75,6 +76,12 @@ type SubmitAssignmentRequest struct {
 	Attachments    []AssignmentSubmissionFile   `json:"attachments"`
 }
 
+// ReturnAssignmentRequest represents returning a submission for revision
+type ReturnAssignmentRequest struct {
+	SubmissionID string `json:"submissionId" validate:"required"`
+	Feedback     string `json:"feedback" validate:"required"`
+}
+
 // EvaluateAssignmentRequest represents assignment evaluation by teacher
 type EvaluateAssignmentRequest struct {
 	SubmissionID string  `json:"submissionId" validate:"required"`
@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// BankQuestion is a reusable question in a teacher's question bank. Quizzes
+// and exams attach it by reference; every edit creates a new version.
+type BankQuestion struct {
+	ID               string           `firestore:"id" json:"id"`
+	OwnerID          string           `firestore:"ownerId" json:"ownerId"`
+	Department       string           `firestore:"department,omitempty" json:"department,omitempty"`
+	Shared           bool             `firestore:"shared" json:"shared"` // visible to teachers in the same department
+	Type             string           `firestore:"type" json:"type"`     // mcq | true_false | short_answer | descriptive | long_answer
+	Text             string           `firestore:"text" json:"text"`
+	ImageURL         string           `firestore:"imageUrl,omitempty" json:"imageUrl,omitempty"`
+	Points           float64          `firestore:"points" json:"points"`
+	Options          []QuestionOption `firestore:"options,omitempty" json:"options,omitempty"`
+	CorrectAnswer    string           `firestore:"correctAnswer,omitempty" json:"correctAnswer,omitempty"`
+	AcceptedAnswers  []AcceptedAnswer `firestore:"acceptedAnswers,omitempty" json:"acceptedAnswers,omitempty"`
+	ScoringPolicy    string           `firestore:"scoringPolicy,omitempty" json:"scoringPolicy,omitempty"`
+	Explanation      string           `firestore:"explanation,omitempty" json:"explanation,omitempty"`
+	Topics           []string         `firestore:"topics" json:"topics"`
+	Difficulty       string           `firestore:"difficulty,omitempty" json:"difficulty,omitempty"` // easy | medium | hard
+	LearningOutcomes []string         `firestore:"learningOutcomes" json:"learningOutcomes"`
+	Version          int              `firestore:"version" json:"version"`
+	CreatedAt        time.Time        `firestore:"createdAt" json:"createdAt"`
+	UpdatedAt        time.Time        `firestore:"updatedAt" json:"updatedAt"`
+	IsDeleted        bool             `firestore:"isDeleted" json:"isDeleted"`
+}
+
+// BankQuestionVersion is an immutable snapshot of a bank question
+type BankQuestionVersion struct {
+	BankQuestionID string       `firestore:"bankQuestionId" json:"bankQuestionId"`
+	Version        int          `firestore:"version" json:"version"`
+	Question       BankQuestion `firestore:"question" json:"question"`
+	EditedBy       string       `firestore:"editedBy" json:"editedBy"`
+	CreatedAt      time.Time    `firestore:"createdAt" json:"createdAt"`
+}
+
+// BankQuestionRequest creates or edits a bank question
+type BankQuestionRequest struct {
+	Type             string           `json:"type" validate:"required"`
+	Text             string           `json:"text" validate:"required"`
+	ImageURL         string           `json:"imageUrl,omitempty"`
+	Points           float64          `json:"points" validate:"required"`
+	Options          []QuestionOption `json:"options,omitempty"`
+	CorrectAnswer    string           `json:"correctAnswer,omitempty"`
+	AcceptedAnswers  []AcceptedAnswer `json:"acceptedAnswers,omitempty"`
+	ScoringPolicy    string           `json:"scoringPolicy,omitempty"`
+	Explanation      string           `json:"explanation,omitempty"`
+	Topics           []string         `json:"topics"`
+	Difficulty       string           `json:"difficulty,omitempty"`
+	LearningOutcomes []string         `json:"learningOutcomes"`
+	Shared           bool             `json:"shared"`
+}
+
+// UpdateBankQuestionRequest edits a bank question; Version must be the
+// version the edit was based on
+type UpdateBankQuestionRequest struct {
+	BankQuestionID string `json:"bankQuestionId" validate:"required"`
+	Version        int    `json:"version" validate:"required"`
+	BankQuestionRequest
+}
+
+// AttachBankQuestionRequest attaches a bank question to a quiz or exam
+type AttachBankQuestionRequest struct {
+	BankQuestionID string `json:"bankQuestionId" validate:"required"`
+	QuizID         string `json:"quizId,omitempty"`
+	ExamID         string `json:"examId,omitempty"`
+	Version        int    `json:"version,omitempty"` // 0 = latest
+	Pool           string `json:"pool,omitempty"`    // quiz draw pool
+}
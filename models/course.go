@@ -16,6 +16,8 @@ type Course struct {
 	Materials       []CourseMaterial `firestore:"materials" json:"materials"`
 	EnrollmentCount int              `firestore:"enrollmentCount" json:"enrollmentCount"`
 	IsPublished     bool             `firestore:"isPublished" json:"isPublished"`
+	EnrollmentMode  string           `firestore:"enrollmentMode,omitempty" json:"enrollmentMode,omitempty"` // open | invite_code | approval_required | closed; empty = open
+	Gradebook       *GradebookPolicy `firestore:"gradebook,omitempty" json:"gradebook,omitempty"`           // nil = default weights and letters
 	CreatedAt       time.Time        `firestore:"createdAt" json:"createdAt"`
 	UpdatedAt       time.Time        `firestore:"updatedAt" json:"updatedAt"`
 	IsDeleted       bool             `firestore:"isDeleted" json:"isDeleted"`
@@ -33,24 +35,26 @@ type CourseMaterial struct {
 
 // CreateCourseRequest represents course creation request
 type CreateCourseRequest struct {
-	Title       string `json:"title" validate:"required"`
-	Description string `json:"description" validate:"required"`
-	Syllabus    string `json:"syllabus"`
-	Category    string `json:"category" validate:"required"`
-	Difficulty  string `json:"difficulty" validate:"required,oneof=beginner intermediate advanced"`
-	Thumbnail   string `json:"thumbnail,omitempty"`
+	Title          string `json:"title" validate:"required"`
+	Description    string `json:"description" validate:"required"`
+	Syllabus       string `json:"syllabus"`
+	Category       string `json:"category" validate:"required"`
+	Difficulty     string `json:"difficulty" validate:"required,oneof=beginner intermediate advanced"`
+	Thumbnail      string `json:"thumbnail,omitempty"`
+	EnrollmentMode string `json:"enrollmentMode,omitempty"`
 }
 
 // UpdateCourseRequest represents course update request
 type UpdateCourseRequest struct {
-	Title       string           `json:"title,omitempty"`
-	Description string           `json:"description,omitempty"`
-	Syllabus    string           `json:"syllabus,omitempty"`
-	Category    string           `json:"category,omitempty"`
-	Difficulty  string           `json:"difficulty,omitempty"`
-	Thumbnail   string           `json:"thumbnail,omitempty"`
-	Materials   []CourseMaterial `json:"materials,omitempty"`
-	IsPublished bool             `json:"isPublished,omitempty"`
+	Title          string           `json:"title,omitempty"`
+	Description    string           `json:"description,omitempty"`
+	Syllabus       string           `json:"syllabus,omitempty"`
+	Category       string           `json:"category,omitempty"`
+	Difficulty     string           `json:"difficulty,omitempty"`
+	Thumbnail      string           `json:"thumbnail,omitempty"`
+	Materials      []CourseMaterial `json:"materials,omitempty"`
+	IsPublished    bool             `json:"isPublished,omitempty"`
+	EnrollmentMode string           `json:"enrollmentMode,omitempty"`
 }
 
 // Enrollment represents a course enrollment
@@ -70,4 +74,6 @@ type Enrollment struct {
 // EnrollmentRequest represents enrollment creation
 type EnrollmentRequest struct {
 	CourseID string `json:"courseId" validate:"required"`
+	JoinCode string `json:"joinCode,omitempty"`
+	Message  string `json:"message,omitempty"` // shown to the teacher with an approval request
 }
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// Course enrollment modes
+const (
+	EnrollmentOpen       = "open"
+	EnrollmentInviteCode = "invite_code"
+	EnrollmentApproval   = "approval_required"
+	EnrollmentClosed     = "closed"
+)
+
+// JoinCode lets students enroll in a course that is not open. The code can
+// be typed in or shared as a join link.
+type JoinCode struct {
+	Code      string     `firestore:"code" json:"code"`
+	CourseID  string     `firestore:"courseId" json:"courseId"`
+	MaxUses   int        `firestore:"maxUses" json:"maxUses"` // 0 means unlimited
+	Uses      int        `firestore:"uses" json:"uses"`
+	ExpiresAt *time.Time `firestore:"expiresAt,omitempty" json:"expiresAt,omitempty"`
+	Revoked   bool       `firestore:"revoked" json:"revoked"`
+	CreatedBy string     `firestore:"createdBy" json:"createdBy"`
+	CreatedAt time.Time  `firestore:"createdAt" json:"createdAt"`
+}
+
+// JoinCodeRequest creates a join code for a course
+type JoinCodeRequest struct {
+	CourseID  string     `json:"courseId"`
+	MaxUses   int        `json:"maxUses,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// JoinRequest is a student waiting for a teacher to let them into a course
+// that requires approval
+type JoinRequest struct {
+	CourseID     string     `firestore:"courseId" json:"courseId"`
+	CourseTitle  string     `firestore:"courseTitle" json:"courseTitle"`
+	StudentID    string     `firestore:"studentId" json:"studentId"`
+	StudentName  string     `firestore:"studentName" json:"studentName"`
+	StudentEmail string     `firestore:"studentEmail" json:"studentEmail"`
+	Message      string     `firestore:"message,omitempty" json:"message,omitempty"`
+	Status       string     `firestore:"status" json:"status"` // pending | approved | rejected
+	ReviewedBy   string     `firestore:"reviewedBy,omitempty" json:"reviewedBy,omitempty"`
+	ReviewNote   string     `firestore:"reviewNote,omitempty" json:"reviewNote,omitempty"`
+	ReviewedAt   *time.Time `firestore:"reviewedAt,omitempty" json:"reviewedAt,omitempty"`
+	CreatedAt    time.Time  `firestore:"createdAt" json:"createdAt"`
+}
+
+// ReviewJoinRequest approves or rejects a pending join request
+type ReviewJoinRequest struct {
+	CourseID  string `json:"courseId"`
+	StudentID string `json:"studentId"`
+	Approve   bool   `json:"approve"`
+	Note      string `json:"note,omitempty"`
+}
@@ -43,6 +43,8 @@ type CreateExamRequest struct {
 	StartTime          time.Time `json:"startTime" validate:"required"`
 	EndTime            time.Time `json:"endTime" validate:"required"`
 	Instructions       string    `json:"instructions"`
+	RequiresManualEvaluation bool `json:"requiresManualEvaluation"`
+	IsPublished        bool      `json:"isPublished"`
 }
 
 // ExamSubmission represents an exam submission
@@ -52,13 +54,18 @@ type ExamSubmission struct {
 	StudentID      string                `firestore:"studentId" json:"studentId"`
 	StudentName    string                `firestore:"studentName" json:"studentName"`
 	Answers        []ExamSubmittedAnswer `firestore:"answers" json:"answers"`
+	QuestionIDs    []string              `firestore:"questionIds" json:"questionIds"` // order shown to the student
 	StartedAt      time.Time             `firestore:"startedAt" json:"startedAt"`
 	SubmittedAt    time.Time             `firestore:"submittedAt" json:"submittedAt"`
 	TimeTaken      int                   `firestore:"timeTaken" json:"timeTaken"` // minutes
 	TotalMarks     float64               `firestore:"totalMarks" json:"totalMarks"`
 	MarksObtained  float64               `firestore:"marksObtained" json:"marksObtained"`
 	Percentage     float64               `firestore:"percentage" json:"percentage"`
+	Passed         bool                  `firestore:"passed" json:"passed"`
 	Status         string                `firestore:"status" json:"status"` // in_progress | submitted | partially_evaluated | evaluated
+	SubmitReason   string                `firestore:"submitReason,omitempty" json:"submitReason,omitempty"` // empty when submitted in time | timed_out | auto_submitted
+	TimeMultiplier float64               `firestore:"timeMultiplier,omitempty" json:"timeMultiplier,omitempty"` // accommodation applied at start
+	Version        int                   `firestore:"version" json:"version"` // bumped on every versioned save, for optimistic concurrency
 	EvaluatedAt    *time.Time            `firestore:"evaluatedAt,omitempty" json:"evaluatedAt,omitempty"`
 	EvaluatedBy    string                `firestore:"evaluatedBy,omitempty" json:"evaluatedBy,omitempty"`
 	TeacherComments string               `firestore:"teacherComments,omitempty" json:"teacherComments,omitempty"`
@@ -72,6 +79,7 @@ type ExamSubmittedAnswer struct {
 	IsCorrect       *bool   `firestore:"isCorrect,omitempty" json:"isCorrect,omitempty"`
 	MarksAwarded    float64 `firestore:"marksAwarded" json:"marksAwarded"`
 	TeacherFeedback string  `firestore:"teacherFeedback,omitempty" json:"teacherFeedback,omitempty"`
+	IsEvaluated     bool    `firestore:"isEvaluated" json:"isEvaluated"` // false while awaiting manual evaluation
 }
 
 // SubmitExamRequest represents exam submission
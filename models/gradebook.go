@@ -0,0 +1,90 @@
+package models
+
+import "time"
+
+// GradebookPolicy configures how a course's overall grade is computed
+type GradebookPolicy struct {
+	Categories    []GradeCategory `firestore:"categories" json:"categories"`
+	AttemptPolicy string          `firestore:"attemptPolicy" json:"attemptPolicy"` // best | latest | average, for quizzes without their own
+	LetterGrades  []LetterGrade   `firestore:"letterGrades" json:"letterGrades"`   // highest minPercentage first
+}
+
+// GradeCategory weights one kind of assessment in the overall grade
+type GradeCategory struct {
+	Type       string  `firestore:"type" json:"type"`             // quizzes | assignments | exams
+	Weight     float64 `firestore:"weight" json:"weight"`         // percent; weights sum to 100
+	DropLowest int     `firestore:"dropLowest" json:"dropLowest"` // lowest scores ignored, at least one is always kept
+}
+
+// LetterGrade is the letter given at or above a percentage
+type LetterGrade struct {
+	Letter        string  `firestore:"letter" json:"letter"`
+	MinPercentage float64 `firestore:"minPercentage" json:"minPercentage"`
+}
+
+// Gradebook is a course's grades for every enrolled student, or for one
+type Gradebook struct {
+	CourseID string          `json:"courseId"`
+	Policy   GradebookPolicy `json:"policy"`
+	Items    []GradebookItem `json:"items"`
+	Students []StudentGrade  `json:"students"`
+	Class    *ClassSummary   `json:"class,omitempty"` // omitted from a single student's view
+}
+
+// GradebookItem is one graded quiz, exam or assignment in the course
+type GradebookItem struct {
+	ID            string     `json:"id"`
+	Type          string     `json:"type"` // quiz | exam | assignment
+	Title         string     `json:"title"`
+	TotalMarks    float64    `json:"totalMarks"`
+	DueAt         *time.Time `json:"dueAt,omitempty"`
+	AttemptPolicy string     `json:"attemptPolicy,omitempty"` // quizzes only
+}
+
+// GradeEntry is a student's result on one item
+type GradeEntry struct {
+	ItemID     string   `json:"itemId"`
+	Status     string   `json:"status"`               // graded | pending | missing | upcoming
+	Percentage *float64 `json:"percentage,omitempty"` // missing items count as 0
+	Attempts   int      `json:"attempts,omitempty"`
+	Dropped    bool     `json:"dropped,omitempty"`
+}
+
+// CategoryGrade is a student's average in one category
+type CategoryGrade struct {
+	Type       string   `json:"type"`
+	Weight     float64  `json:"weight"`
+	Percentage *float64 `json:"percentage"` // nil until something in the category counts
+	Counted    int      `json:"counted"`
+	Dropped    int      `json:"dropped"`
+}
+
+// StudentGrade is one row of the gradebook
+type StudentGrade struct {
+	StudentID   string          `json:"studentId"`
+	StudentName string          `json:"studentName"`
+	Email       string          `json:"email"`
+	RollNumber  string          `json:"rollNumber,omitempty"`
+	Entries     []GradeEntry    `json:"entries"`
+	Categories  []CategoryGrade `json:"categories"`
+	Percentage  *float64        `json:"percentage"`
+	Letter      string          `json:"letter"`
+}
+
+// ClassSummary describes the whole class
+type ClassSummary struct {
+	Students           int            `json:"students"`
+	AveragePercentage  *float64       `json:"averagePercentage"`
+	MedianPercentage   *float64       `json:"medianPercentage"`
+	LetterDistribution map[string]int `json:"letterDistribution"`
+	Items              []ItemSummary  `json:"items"`
+}
+
+// ItemSummary is the class average on one item
+type ItemSummary struct {
+	ItemID            string   `json:"itemId"`
+	Graded            int      `json:"graded"`
+	Missing           int      `json:"missing"`
+	Pending           int      `json:"pending"`
+	AveragePercentage *float64 `json:"averagePercentage"`
+}
@@ -16,8 +16,10 @@ type Quiz struct {
 	PassingMarks       float64   `firestore:"passingMarks" json:"passingMarks"`
 	NegativeMarking    bool      `firestore:"negativeMarking" json:"negativeMarking"`
 	NegativeMarkValue  float64   `firestore:"negativeMarkValue" json:"negativeMarkValue"`
+	AllowNegativeScore bool      `firestore:"allowNegativeScore" json:"allowNegativeScore"` // false = total floored at zero
 	QuestionsCount     int       `firestore:"questionsCount" json:"questionsCount"`
 	QuestionCount      int       `firestore:"questionCount" json:"questionCount"`
+	Attempted          bool      `firestore:"attempted" json:"attempted"` // a student has started it; question grading is locked
 	RandomizeQuestions bool      `firestore:"randomizeQuestions" json:"randomizeQuestions"`
 	ShowResults        bool      `firestore:"showResults" json:"showResults"`
 	ShowResultsAfterSubmit bool  `firestore:"showResultsAfterSubmit" json:"showResultsAfterSubmit"`
@@ -26,10 +28,14 @@ type Quiz struct {
 	AllowReview        bool      `firestore:"allowReview" json:"allowReview"`
 	AllowedAttempts    int       `firestore:"allowedAttempts" json:"allowedAttempts"` // 0 = unlimited
 	MaxAttempts        int       `firestore:"maxAttempts" json:"maxAttempts"`
+	AttemptPolicy      string    `firestore:"attemptPolicy,omitempty" json:"attemptPolicy,omitempty"` // best | latest | average, for the gradebook; empty = course default
 	Instructions       string    `firestore:"instructions" json:"instructions"`
 	Deadline           time.Time `firestore:"deadline" json:"deadline"`
 	StartDate          *time.Time `firestore:"startDate,omitempty" json:"startDate,omitempty"`
 	EndDate            *time.Time `firestore:"endDate,omitempty" json:"endDate,omitempty"`
+	DrawRules          []DrawRule `firestore:"drawRules,omitempty" json:"drawRules,omitempty"` // empty = every question, in order
+	WindowOverrides    []WindowOverride `firestore:"windowOverrides,omitempty" json:"windowOverrides,omitempty"` // per-student StartDate/EndDate
+	Availability       *QuizAvailability `firestore:"-" json:"availability,omitempty"` // computed for the requesting student
 	
 	// Cheating prevention features
 	PreventTabSwitch       bool `firestore:"preventTabSwitch" json:"preventTabSwitch"`
@@ -65,8 +71,14 @@ type Question struct {
 	Points       float64         `firestore:"points" json:"points"`
 	Options      []QuestionOption `firestore:"options,omitempty" json:"options,omitempty"`
 	CorrectAnswer string         `firestore:"correctAnswer,omitempty" json:"correctAnswer,omitempty"`
+	AcceptedAnswers []AcceptedAnswer `firestore:"acceptedAnswers,omitempty" json:"acceptedAnswers,omitempty"` // short_answer auto-grading rules
+	ScoringPolicy string         `firestore:"scoringPolicy,omitempty" json:"scoringPolicy,omitempty"` // all_or_nothing (default) | proportional | right_minus_wrong
 	Explanation  string          `firestore:"explanation,omitempty" json:"explanation,omitempty"`
 	Order        int             `firestore:"order" json:"order"`
+	Difficulty   string          `firestore:"difficulty,omitempty" json:"difficulty,omitempty"` // easy | medium | hard
+	Pool         string          `firestore:"pool,omitempty" json:"pool,omitempty"`             // draw pool the question belongs to
+	BankQuestionID string        `firestore:"bankQuestionId,omitempty" json:"bankQuestionId,omitempty"` // set when attached from the question bank
+	BankVersion  int             `firestore:"bankVersion,omitempty" json:"bankVersion,omitempty"`       // bank version this copy was taken from
 	CreatedAt    time.Time       `firestore:"createdAt" json:"createdAt"`
 	UpdatedAt    time.Time       `firestore:"updatedAt" json:"updatedAt"`
 }
@@ -78,6 +90,65 @@ type QuestionOption struct {
 	IsCorrect bool   `firestore:"isCorrect" json:"isCorrect"`
 }
 
+// DrawRule picks Count random questions of a difficulty from a quiz pool when
+// an attempt starts, e.g. 5 "easy" questions from pool "algebra"
+type DrawRule struct {
+	Pool       string  `firestore:"pool" json:"pool"`
+	Difficulty string  `firestore:"difficulty,omitempty" json:"difficulty,omitempty"` // empty = any difficulty
+	Count      int     `firestore:"count" json:"count"`
+	Points     float64 `firestore:"points,omitempty" json:"points,omitempty"` // overrides each drawn question's points
+}
+
+// WindowOverride gives one student a different availability window. Nil
+// dates fall back to the quiz's own; an EndDate also replaces the Deadline.
+type WindowOverride struct {
+	StudentID string     `firestore:"studentId" json:"studentId"`
+	StartDate *time.Time `firestore:"startDate,omitempty" json:"startDate,omitempty"`
+	EndDate   *time.Time `firestore:"endDate,omitempty" json:"endDate,omitempty"`
+	Reason    string     `firestore:"reason,omitempty" json:"reason,omitempty"`
+	GrantedBy string     `firestore:"grantedBy" json:"grantedBy"`
+	GrantedAt time.Time  `firestore:"grantedAt" json:"grantedAt"`
+}
+
+// QuizAvailability tells a student whether a quiz can be started now
+type QuizAvailability struct {
+	Status   string     `json:"status"` // upcoming | open | closed
+	OpensAt  *time.Time `json:"opensAt,omitempty"`
+	ClosesAt *time.Time `json:"closesAt,omitempty"`
+}
+
+// WindowOverrideRequest sets or removes a student's window override
+type WindowOverrideRequest struct {
+	QuizID    string     `json:"quizId" validate:"required"`
+	StudentID string     `json:"studentId" validate:"required"`
+	StartDate *time.Time `json:"startDate,omitempty"`
+	EndDate   *time.Time `json:"endDate,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+	Remove    bool       `json:"remove,omitempty"`
+}
+
+// Scoring policies for multiple-select MCQs
+const (
+	ScoringAllOrNothing    = "all_or_nothing"
+	ScoringProportional    = "proportional"
+	ScoringRightMinusWrong = "right_minus_wrong"
+)
+
+// Match rules for short_answer accepted answers
+const (
+	MatchCaseInsensitive = "case_insensitive"
+	MatchNormalized      = "normalized"
+	MatchNumeric         = "numeric"
+	MatchRegex           = "regex"
+)
+
+// AcceptedAnswer is a rule a short answer is matched against
+type AcceptedAnswer struct {
+	Value     string  `firestore:"value" json:"value"`
+	Match     string  `firestore:"match" json:"match"` // case_insensitive | normalized | numeric | regex
+	Tolerance float64 `firestore:"tolerance,omitempty" json:"tolerance,omitempty"` // numeric only, absolute
+}
+
 // CreateQuizRequest represents quiz creation
 type CreateQuizRequest struct {
 	CourseID           string     `json:"courseId" validate:"required"`
@@ -88,6 +159,7 @@ type CreateQuizRequest struct {
 	PassingMarks       float64    `json:"passingMarks" validate:"required"`
 	NegativeMarking    bool       `json:"negativeMarking"`
 	NegativeMarkValue  float64    `json:"negativeMarkValue"`
+	AllowNegativeScore bool       `json:"allowNegativeScore"`
 	Instructions       string     `json:"instructions"`
 	Deadline           time.Time  `json:"deadline"`
 	RandomizeQuestions bool       `json:"randomizeQuestions"`
@@ -98,8 +170,10 @@ type CreateQuizRequest struct {
 	AllowReview        bool       `json:"allowReview"`
 	AllowedAttempts    int        `json:"allowedAttempts"`
 	MaxAttempts        int        `json:"maxAttempts"`
+	AttemptPolicy      string     `json:"attemptPolicy,omitempty"`
 	StartDate          *time.Time `json:"startDate,omitempty"`
 	EndDate            *time.Time `json:"endDate,omitempty"`
+	DrawRules          []DrawRule `json:"drawRules,omitempty"`
 	
 	// Cheating prevention
 	PreventTabSwitch       bool `json:"preventTabSwitch"`
@@ -113,8 +187,44 @@ type CreateQuizRequest struct {
 	// Teacher permissions
 	AllowTeacherResume     bool `json:"allowTeacherResume"`
 	AllowTeacherExtendTime bool `json:"allowTeacherExtendTime"`
-	
-	IsPublished        bool `json:"isPublished"`
+}
+
+// UpdateQuizRequest changes quiz settings; fields left out are unchanged.
+// Publishing is done separately through the publish endpoint.
+type UpdateQuizRequest struct {
+	QuizID             string      `json:"quizId" validate:"required"`
+	Title              *string     `json:"title,omitempty"`
+	Description        *string     `json:"description,omitempty"`
+	Instructions       *string     `json:"instructions,omitempty"`
+	Duration           *int        `json:"duration,omitempty"`
+	TotalMarks         *float64    `json:"totalMarks,omitempty"`
+	PassingMarks       *float64    `json:"passingMarks,omitempty"`
+	NegativeMarking    *bool       `json:"negativeMarking,omitempty"`
+	NegativeMarkValue  *float64    `json:"negativeMarkValue,omitempty"`
+	AllowNegativeScore *bool       `json:"allowNegativeScore,omitempty"`
+	Deadline           *time.Time  `json:"deadline,omitempty"`
+	StartDate          *time.Time  `json:"startDate,omitempty"` // zero time clears it
+	EndDate            *time.Time  `json:"endDate,omitempty"`   // zero time clears it
+	DrawRules          *[]DrawRule `json:"drawRules,omitempty"`
+	ShowResultsAfterSubmit *bool   `json:"showResultsAfterSubmit,omitempty"`
+	ShuffleQuestions   *bool       `json:"shuffleQuestions,omitempty"`
+	ShuffleOptions     *bool       `json:"shuffleOptions,omitempty"`
+	AllowReview        *bool       `json:"allowReview,omitempty"`
+	MaxAttempts        *int        `json:"maxAttempts,omitempty"`
+	AttemptPolicy      *string     `json:"attemptPolicy,omitempty"`
+
+	// Cheating prevention
+	PreventTabSwitch       *bool `json:"preventTabSwitch,omitempty"`
+	MaxTabSwitches         *int  `json:"maxTabSwitches,omitempty"`
+	RequireFullscreen      *bool `json:"requireFullscreen,omitempty"`
+	DisableCopyPaste       *bool `json:"disableCopyPaste,omitempty"`
+	EnableProctoring       *bool `json:"enableProctoring,omitempty"`
+	RandomizeQuestionOrder *bool `json:"randomizeQuestionOrder,omitempty"`
+	TimePerQuestion        *int  `json:"timePerQuestion,omitempty"`
+
+	// Teacher permissions
+	AllowTeacherResume     *bool `json:"allowTeacherResume,omitempty"`
+	AllowTeacherExtendTime *bool `json:"allowTeacherExtendTime,omitempty"`
 }
 
 // CreateQuestionRequest represents question creation
@@ -130,6 +240,24 @@ type CreateQuestionRequest struct {
 	Explanation  string           `json:"explanation,omitempty"`
 }
 
+// ReplayInputs records what an attempt's question set was drawn and shuffled
+// from, so it can be replayed after the quiz's questions or settings change
+type ReplayInputs struct {
+	Questions              []ReplayQuestion `firestore:"questions" json:"questions"` // in their starting order
+	DrawRules              []DrawRule       `firestore:"drawRules,omitempty" json:"drawRules,omitempty"`
+	ShuffleQuestions       bool             `firestore:"shuffleQuestions" json:"shuffleQuestions"`
+	ShuffleOptions         bool             `firestore:"shuffleOptions" json:"shuffleOptions"`
+	RandomizeQuestionOrder bool             `firestore:"randomizeQuestionOrder" json:"randomizeQuestionOrder"`
+}
+
+// ReplayQuestion is a quiz question as the draw and shuffle saw it
+type ReplayQuestion struct {
+	ID         string   `firestore:"id" json:"id"`
+	Pool       string   `firestore:"pool,omitempty" json:"pool,omitempty"`
+	Difficulty string   `firestore:"difficulty,omitempty" json:"difficulty,omitempty"`
+	OptionIDs  []string `firestore:"optionIds" json:"optionIds"` // before shuffling
+}
+
 // QuizSubmission represents a quiz submission
 type QuizSubmission struct {
 	ID            string           `firestore:"id" json:"id"`
@@ -141,17 +269,25 @@ type QuizSubmission struct {
 	CourseID      string           `firestore:"courseId" json:"courseId"`
 	AttemptNumber int              `firestore:"attemptNumber" json:"attemptNumber"`
 	Answers       []Answer         `firestore:"answers" json:"answers"`
-	Questions     []Question       `firestore:"questions" json:"questions"`
+	Questions     []Question       `firestore:"questions" json:"questions"` // the questions this attempt was given, drawn or fixed
+	ShuffleSeed   int64            `firestore:"shuffleSeed" json:"shuffleSeed,string"` // reproduces the draw and shuffle order
+	ReplayInputs  *ReplayInputs    `firestore:"replayInputs,omitempty" json:"replayInputs,omitempty"` // what the seed was applied to
 	StartedAt     time.Time        `firestore:"startedAt" json:"startedAt"`
 	SubmittedAt   time.Time        `firestore:"submittedAt" json:"submittedAt"`
 	TimeTaken     int              `firestore:"timeTaken" json:"timeTaken"` // minutes
 	TimeLimit     int              `firestore:"timeLimit" json:"timeLimit"` // minutes
-	TotalMarks    float64          `firestore:"totalMarks" json:"totalMarks"`
+	ExpiresAt     time.Time        `firestore:"expiresAt" json:"expiresAt"` // server-side deadline, extensions included
+	TimeMultiplier float64         `firestore:"timeMultiplier,omitempty" json:"timeMultiplier,omitempty"` // accommodation applied at start
+	AccommodationID string         `firestore:"accommodationId,omitempty" json:"accommodationId,omitempty"`
+	QuestionViews []QuestionView   `firestore:"questionViews,omitempty" json:"questionViews,omitempty"`
+	TotalMarks    float64          `firestore:"totalMarks" json:"totalMarks"` // marks available in this attempt
 	MarksObtained float64          `firestore:"marksObtained" json:"marksObtained"`
 	Score         float64          `firestore:"score" json:"score"`
 	Percentage    float64          `firestore:"percentage" json:"percentage"`
 	Passed        bool             `firestore:"passed" json:"passed"`
 	Status        string           `firestore:"status" json:"status"` // in_progress | submitted | evaluated
+	SubmitReason  string           `firestore:"submitReason,omitempty" json:"submitReason,omitempty"` // empty when the student submitted | auto_submitted
+	Version       int              `firestore:"version" json:"version"` // bumped on every versioned save, for optimistic concurrency
 	EvaluatedAt   *time.Time       `firestore:"evaluatedAt,omitempty" json:"evaluatedAt,omitempty"`
 	EvaluatedBy   string           `firestore:"evaluatedBy,omitempty" json:"evaluatedBy,omitempty"`
 	
@@ -176,6 +312,19 @@ type Answer struct {
 	TextAnswer      string   `firestore:"textAnswer" json:"textAnswer"`
 	IsCorrect       bool     `firestore:"isCorrect" json:"isCorrect"`
 	PointsAwarded   float64  `firestore:"pointsAwarded" json:"pointsAwarded"`
+	PointsDeducted  float64  `firestore:"pointsDeducted" json:"pointsDeducted"` // negative marking
+	CorrectPicks    int      `firestore:"correctPicks" json:"correctPicks"`
+	WrongPicks      int      `firestore:"wrongPicks" json:"wrongPicks"`
+	NeedsReview     bool     `firestore:"needsReview" json:"needsReview"` // awaiting manual grading
+	Feedback        string   `firestore:"feedback,omitempty" json:"feedback,omitempty"`
+	AnsweredAt      *time.Time `firestore:"answeredAt,omitempty" json:"answeredAt,omitempty"` // set by the server when the answer is received
+}
+
+// QuestionView records when the server first showed a question in an attempt
+type QuestionView struct {
+	QuestionID string    `firestore:"questionId" json:"questionId"`
+	ViewedAt   time.Time `firestore:"viewedAt" json:"viewedAt"`
+	ExpiresAt  time.Time `firestore:"expiresAt" json:"expiresAt"` // zero when the quiz has no per-question timer
 }
 
 // SubmittedAnswer represents a single answer in a submission
@@ -196,6 +345,26 @@ type SubmitQuizRequest struct {
 	TimedOut        bool     `json:"timedOut"`
 }
 
+// QuestionViewRequest marks a question as shown to the student
+type QuestionViewRequest struct {
+	SubmissionID string `json:"submissionId" validate:"required"`
+	QuestionID   string `json:"questionId" validate:"required"`
+}
+
+// SaveProgressRequest upserts answers onto an in-progress attempt. Version
+// must match the attempt's current version or the save is rejected.
+type SaveProgressRequest struct {
+	SubmissionID string   `json:"submissionId" validate:"required"`
+	Version      int      `json:"version"`
+	Answers      []Answer `json:"answers" validate:"required"`
+}
+
+// GradeQuizRequest represents manual grading of pending answers by a teacher
+type GradeQuizRequest struct {
+	SubmissionID string             `json:"submissionId" validate:"required"`
+	Evaluations  []AnswerEvaluation `json:"evaluations" validate:"required"`
+}
+
 // SubmitQuizAnswerRequest represents individual answer
 type SubmitQuizAnswerRequest struct {
 	QuestionID     string `json:"questionId" validate:"required"`
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Invitation lets someone register with a role other than student, or from
+// an email domain outside the self-signup list
+type Invitation struct {
+	Code      string     `firestore:"code" json:"code"`
+	Role      string     `firestore:"role" json:"role"`                       // teacher | student
+	Email     string     `firestore:"email,omitempty" json:"email,omitempty"` // only this address may use it
+	MaxUses   int        `firestore:"maxUses" json:"maxUses"`                 // 0 means unlimited
+	Uses      int        `firestore:"uses" json:"uses"`
+	ExpiresAt *time.Time `firestore:"expiresAt,omitempty" json:"expiresAt,omitempty"`
+	Revoked   bool       `firestore:"revoked" json:"revoked"`
+	CreatedBy string     `firestore:"createdBy" json:"createdBy"`
+	CreatedAt time.Time  `firestore:"createdAt" json:"createdAt"`
+}
+
+// InvitationRequest creates an invitation code
+type InvitationRequest struct {
+	Role      string     `json:"role"`
+	Email     string     `json:"email,omitempty"`
+	MaxUses   int        `json:"maxUses,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// RoleRequest is a self-registered user waiting for a teacher account. The
+// user is a student until an admin approves it.
+type RoleRequest struct {
+	UserID      string     `firestore:"userId" json:"userId"`
+	Email       string     `firestore:"email" json:"email"`
+	DisplayName string     `firestore:"displayName" json:"displayName"`
+	Role        string     `firestore:"role" json:"role"`     // teacher
+	Status      string     `firestore:"status" json:"status"` // pending | approved | rejected
+	Department  string     `firestore:"department,omitempty" json:"department,omitempty"`
+	ReviewedBy  string     `firestore:"reviewedBy,omitempty" json:"reviewedBy,omitempty"`
+	ReviewNote  string     `firestore:"reviewNote,omitempty" json:"reviewNote,omitempty"`
+	ReviewedAt  *time.Time `firestore:"reviewedAt,omitempty" json:"reviewedAt,omitempty"`
+	CreatedAt   time.Time  `firestore:"createdAt" json:"createdAt"`
+}
+
+// ReviewRoleRequest approves or rejects a pending role request
+type ReviewRoleRequest struct {
+	UserID  string `json:"userId"`
+	Approve bool   `json:"approve"`
+	Note    string `json:"note,omitempty"`
+}
@@ -0,0 +1,37 @@
+package models
+
+// RosterRow is one user read from a roster CSV
+type RosterRow struct {
+	Line        int    `json:"line"` // line in the file, the header is line 1
+	Email       string `json:"email"`
+	DisplayName string `json:"displayName"`
+	Role        string `json:"role"` // defaults to student
+	Department  string `json:"department,omitempty"`
+	RollNumber  string `json:"rollNumber,omitempty"`
+	EmployeeID  string `json:"employeeId,omitempty"`
+}
+
+// RosterRowResult reports what happened to one roster row
+type RosterRowResult struct {
+	Line     int      `json:"line"`
+	Email    string   `json:"email"`
+	Role     string   `json:"role"`
+	Status   string   `json:"status"` // valid | created | exists | invalid | failed
+	UID      string   `json:"uid,omitempty"`
+	Enrolled []string `json:"enrolled,omitempty"` // course IDs
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// RosterImport is the report for a roster import or dry run
+type RosterImport struct {
+	DryRun    bool              `json:"dryRun"`
+	CourseIDs []string          `json:"courseIds"`
+	Total     int               `json:"total"`
+	Valid     int               `json:"valid"`
+	Created   int               `json:"created"`
+	Existing  int               `json:"existing"`
+	Invalid   int               `json:"invalid"`
+	Failed    int               `json:"failed"`
+	Enrolled  int               `json:"enrolled"` // enrollments created
+	Rows      []RosterRowResult `json:"rows"`
+}
@@ -28,10 +28,11 @@ type CreateUserRequest struct {
 	Email       string `json:"email" validate:"required,email"`
 	Password    string `json:"password" validate:"required,min=6"`
 	DisplayName string `json:"displayName" validate:"required"`
-	Role        string `json:"role" validate:"required,oneof=admin teacher student"`
+	Role        string `json:"role,omitempty" validate:"omitempty,oneof=teacher student"` // defaults to student
 	Department  string `json:"department,omitempty"`
 	RollNumber  string `json:"rollNumber,omitempty"`
 	EmployeeID  string `json:"employeeId,omitempty"`
+	InviteCode  string `json:"inviteCode,omitempty"` // required for a teacher account without approval
 }
 
 // UpdateUserRequest represents user update request
@@ -0,0 +1,1245 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FirestoreStore implements Store on top of Cloud Firestore
+type FirestoreStore struct {
+	client *firestore.Client
+}
+
+// NewFirestoreStore wraps an initialized Firestore client
+func NewFirestoreStore(client *firestore.Client) *FirestoreStore {
+	return &FirestoreStore{client: client}
+}
+
+func (s *FirestoreStore) Users() UserRepository             { return firestoreUsers{s.client} }
+func (s *FirestoreStore) Courses() CourseRepository         { return firestoreCourses{s.client} }
+func (s *FirestoreStore) Enrollments() EnrollmentRepository { return firestoreEnrollments{s.client} }
+func (s *FirestoreStore) Quizzes() QuizRepository           { return firestoreQuizzes{s.client} }
+func (s *FirestoreStore) Questions() QuestionRepository     { return firestoreQuestions{s.client} }
+func (s *FirestoreStore) Submissions() SubmissionRepository { return firestoreSubmissions{s.client} }
+func (s *FirestoreStore) Exams() ExamRepository             { return firestoreExams{s.client} }
+func (s *FirestoreStore) ExamSubmissions() ExamSubmissionRepository {
+	return firestoreExamSubmissions{s.client}
+}
+func (s *FirestoreStore) Assignments() AssignmentRepository { return firestoreAssignments{s.client} }
+func (s *FirestoreStore) AssignmentSubmissions() AssignmentSubmissionRepository {
+	return firestoreAssignmentSubmissions{s.client}
+}
+func (s *FirestoreStore) Notifications() NotificationRepository {
+	return firestoreNotifications{s.client}
+}
+func (s *FirestoreStore) AuditLogs() AuditLogRepository  { return firestoreAuditLogs{s.client} }
+func (s *FirestoreStore) Analytics() AnalyticsRepository { return firestoreAnalytics{s.client} }
+func (s *FirestoreStore) QuestionBank() BankRepository   { return firestoreBank{s.client} }
+func (s *FirestoreStore) Accommodations() AccommodationRepository {
+	return firestoreAccommodations{s.client}
+}
+func (s *FirestoreStore) Invitations() InvitationRepository { return firestoreInvitations{s.client} }
+func (s *FirestoreStore) RoleRequests() RoleRequestRepository {
+	return firestoreRoleRequests{s.client}
+}
+func (s *FirestoreStore) JoinCodes() JoinCodeRepository { return firestoreJoinCodes{s.client} }
+func (s *FirestoreStore) JoinRequests() JoinRequestRepository {
+	return firestoreJoinRequests{s.client}
+}
+
+// getDoc reads a document into v, mapping a missing document to ErrNotFound
+func getDoc(ctx context.Context, ref *firestore.DocumentRef, v interface{}) error {
+	doc, err := ref.Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return doc.DataTo(v)
+}
+
+// getAll runs a query and decodes every document, skipping ones that fail to parse
+func getAll[T any](ctx context.Context, query firestore.Query, setID func(*T, string)) ([]T, error) {
+	docs, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]T, 0, len(docs))
+	for _, doc := range docs {
+		var v T
+		if err := doc.DataTo(&v); err != nil {
+			continue
+		}
+		setID(&v, doc.Ref.ID)
+		results = append(results, v)
+	}
+	return results, nil
+}
+
+// Users
+
+type firestoreUsers struct{ client *firestore.Client }
+
+func (r firestoreUsers) Get(ctx context.Context, uid string) (*models.User, error) {
+	var user models.User
+	if err := getDoc(ctx, r.client.Collection("users").Doc(uid), &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r firestoreUsers) Save(ctx context.Context, user *models.User) error {
+	_, err := r.client.Collection("users").Doc(user.UID).Set(ctx, user)
+	return err
+}
+
+func (r firestoreUsers) Delete(ctx context.Context, uid string) error {
+	_, err := r.client.Collection("users").Doc(uid).Delete(ctx)
+	return err
+}
+
+// Courses
+
+type firestoreCourses struct{ client *firestore.Client }
+
+func (r firestoreCourses) Get(ctx context.Context, courseID string) (*models.Course, error) {
+	var course models.Course
+	if err := getDoc(ctx, r.client.Collection("courses").Doc(courseID), &course); err != nil {
+		return nil, err
+	}
+	return &course, nil
+}
+
+func (r firestoreCourses) Save(ctx context.Context, course *models.Course) error {
+	_, err := r.client.Collection("courses").Doc(course.CourseID).Set(ctx, course)
+	return err
+}
+
+func (r firestoreCourses) List(ctx context.Context, filter CourseFilter) ([]models.Course, error) {
+	query := r.client.Collection("courses").Where("isDeleted", "==", false)
+	if filter.TeacherID != "" {
+		query = query.Where("teacherId", "==", filter.TeacherID)
+	}
+	if filter.PublishedOnly {
+		query = query.Where("isPublished", "==", true)
+	}
+	query = query.OrderBy("createdAt", firestore.Desc)
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	return getAll(ctx, query, func(c *models.Course, id string) { c.CourseID = id })
+}
+
+func (r firestoreCourses) AddEnrollments(ctx context.Context, courseID string, delta int) error {
+	_, err := r.client.Collection("courses").Doc(courseID).Update(ctx, []firestore.Update{
+		{Path: "enrollmentCount", Value: firestore.Increment(delta)},
+	})
+	return err
+}
+
+// Enrollments
+
+type firestoreEnrollments struct{ client *firestore.Client }
+
+func (r firestoreEnrollments) Save(ctx context.Context, enrollment *models.Enrollment) error {
+	_, err := r.client.Collection("enrollments").Doc(enrollment.EnrollmentID).Set(ctx, enrollment)
+	return err
+}
+
+func (r firestoreEnrollments) Delete(ctx context.Context, enrollmentID string) error {
+	_, err := r.client.Collection("enrollments").Doc(enrollmentID).Delete(ctx)
+	return err
+}
+
+func (r firestoreEnrollments) List(ctx context.Context, filter EnrollmentFilter) ([]models.Enrollment, error) {
+	query := r.client.Collection("enrollments").Query
+	if filter.StudentID != "" {
+		query = query.Where("studentId", "==", filter.StudentID)
+	}
+	if filter.CourseID != "" {
+		query = query.Where("courseId", "==", filter.CourseID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status", "==", filter.Status)
+	}
+	enrollments, err := getAll(ctx, query, func(e *models.Enrollment, id string) { e.EnrollmentID = id })
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(enrollments, func(i, j int) bool {
+		return enrollments[i].EnrolledAt.After(enrollments[j].EnrolledAt)
+	})
+	return enrollments, nil
+}
+
+// Quizzes
+
+type firestoreQuizzes struct{ client *firestore.Client }
+
+func (r firestoreQuizzes) Get(ctx context.Context, quizID string) (*models.Quiz, error) {
+	var quiz models.Quiz
+	if err := getDoc(ctx, r.client.Collection("quizzes").Doc(quizID), &quiz); err != nil {
+		return nil, err
+	}
+	quiz.ID = quizID
+	return &quiz, nil
+}
+
+func (r firestoreQuizzes) Create(ctx context.Context, quiz *models.Quiz) error {
+	ref := r.client.Collection("quizzes").NewDoc()
+	quiz.ID = ref.ID
+	_, err := ref.Set(ctx, quiz)
+	return err
+}
+
+func (r firestoreQuizzes) Save(ctx context.Context, quiz *models.Quiz) error {
+	ref := r.client.Collection("quizzes").Doc(quiz.ID)
+	next := *quiz
+
+	// Counters are re-read inside the transaction so concurrent
+	// AddQuestionStats increments are never overwritten
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var current models.Quiz
+		if err := doc.DataTo(&current); err != nil {
+			return err
+		}
+		next.QuestionCount = current.QuestionCount
+		next.TotalMarks = current.TotalMarks
+		next.Attempted = current.Attempted
+		return tx.Set(ref, &next)
+	})
+	if err != nil {
+		return err
+	}
+	quiz.QuestionCount = next.QuestionCount
+	quiz.TotalMarks = next.TotalMarks
+	quiz.Attempted = next.Attempted
+	return nil
+}
+
+func (r firestoreQuizzes) List(ctx context.Context, filter QuizFilter) ([]models.Quiz, error) {
+	query := r.client.Collection("quizzes").Where("isDeleted", "==", false)
+	if filter.CourseID != "" {
+		query = query.Where("courseId", "==", filter.CourseID)
+	}
+	if len(filter.CourseIDs) > 0 {
+		query = query.Where("courseId", "in", filter.CourseIDs)
+	}
+	if filter.TeacherID != "" {
+		query = query.Where("teacherId", "==", filter.TeacherID)
+	}
+	if filter.PublishedOnly {
+		query = query.Where("isPublished", "==", true)
+	}
+	query = query.OrderBy("createdAt", firestore.Desc)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	return getAll(ctx, query, func(q *models.Quiz, id string) { q.ID = id })
+}
+
+func (r firestoreQuizzes) AddQuestionStats(ctx context.Context, quizID string, questions int, marks float64) error {
+	_, err := r.client.Collection("quizzes").Doc(quizID).Update(ctx, []firestore.Update{
+		{Path: "questionCount", Value: firestore.Increment(questions)},
+		{Path: "totalMarks", Value: firestore.Increment(marks)},
+		{Path: "updatedAt", Value: time.Now()},
+	})
+	return err
+}
+
+func (r firestoreQuizzes) MarkAttempted(ctx context.Context, quizID string) error {
+	_, err := r.client.Collection("quizzes").Doc(quizID).Update(ctx, []firestore.Update{
+		{Path: "attempted", Value: true},
+	})
+	if status.Code(err) == codes.NotFound {
+		return ErrNotFound
+	}
+	return err
+}
+
+// Questions
+
+type firestoreQuestions struct{ client *firestore.Client }
+
+func (r firestoreQuestions) Get(ctx context.Context, questionID string) (*models.Question, error) {
+	var question models.Question
+	if err := getDoc(ctx, r.client.Collection("questions").Doc(questionID), &question); err != nil {
+		return nil, err
+	}
+	question.ID = questionID
+	return &question, nil
+}
+
+func (r firestoreQuestions) Create(ctx context.Context, question *models.Question) error {
+	ref := r.client.Collection("questions").NewDoc()
+	question.ID = ref.ID
+	question.QuestionID = ref.ID
+	_, err := ref.Set(ctx, question)
+	return err
+}
+
+func (r firestoreQuestions) Save(ctx context.Context, question *models.Question) error {
+	_, err := r.client.Collection("questions").Doc(question.ID).Set(ctx, question)
+	return err
+}
+
+func (r firestoreQuestions) ListByQuiz(ctx context.Context, quizID string) ([]models.Question, error) {
+	return r.list(ctx, r.client.Collection("questions").Where("quizId", "==", quizID))
+}
+
+func (r firestoreQuestions) ListByExam(ctx context.Context, examID string) ([]models.Question, error) {
+	return r.list(ctx, r.client.Collection("questions").Where("examId", "==", examID))
+}
+
+func (r firestoreQuestions) ListByBankQuestion(ctx context.Context, bankQuestionID string) ([]models.Question, error) {
+	return r.list(ctx, r.client.Collection("questions").Where("bankQuestionId", "==", bankQuestionID))
+}
+
+func (r firestoreQuestions) UpdateQuizQuestion(ctx context.Context, question *models.Question, countMarks, gradingChanged bool) error {
+	quizRef := r.client.Collection("quizzes").Doc(question.QuizID)
+	ref := r.client.Collection("questions").Doc(question.ID)
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Reading the quiz makes a concurrent MarkAttempted retry this
+		if attempted, err := quizAttempted(tx, quizRef); err != nil {
+			return err
+		} else if gradingChanged && attempted {
+			return ErrQuizAttempted
+		}
+
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		var current models.Question
+		if err := doc.DataTo(&current); err != nil {
+			return err
+		}
+
+		if err := tx.Set(ref, question); err != nil {
+			return err
+		}
+		updates := []firestore.Update{{Path: "updatedAt", Value: time.Now()}}
+		if countMarks && question.Points != current.Points {
+			updates = append(updates, firestore.Update{Path: "totalMarks", Value: firestore.Increment(question.Points - current.Points)})
+		}
+		return tx.Update(quizRef, updates)
+	})
+}
+
+func (r firestoreQuestions) DeleteQuizQuestion(ctx context.Context, quizID, questionID string, countMarks bool) error {
+	quizRef := r.client.Collection("quizzes").Doc(quizID)
+	query := r.client.Collection("questions").Where("quizId", "==", quizID)
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if attempted, err := quizAttempted(tx, quizRef); err != nil {
+			return err
+		} else if attempted {
+			return ErrQuizAttempted
+		}
+
+		docs, err := tx.Documents(query).GetAll()
+		if err != nil {
+			return err
+		}
+
+		var deleted *models.Question
+		remaining := make([]models.Question, 0, len(docs))
+		for _, doc := range docs {
+			var q models.Question
+			if err := doc.DataTo(&q); err != nil {
+				return err
+			}
+			q.ID = doc.Ref.ID
+			if q.ID == questionID {
+				deleted = &q
+				continue
+			}
+			remaining = append(remaining, q)
+		}
+		if deleted == nil {
+			return ErrNotFound
+		}
+
+		if err := tx.Delete(r.client.Collection("questions").Doc(questionID)); err != nil {
+			return err
+		}
+
+		// Close the gap so new questions keep getting unique positions
+		sort.SliceStable(remaining, func(i, j int) bool { return remaining[i].Order < remaining[j].Order })
+		for i, q := range remaining {
+			if q.Order == i+1 {
+				continue
+			}
+			if err := tx.Update(r.client.Collection("questions").Doc(q.ID), []firestore.Update{{Path: "order", Value: i + 1}}); err != nil {
+				return err
+			}
+		}
+
+		updates := []firestore.Update{
+			{Path: "questionCount", Value: firestore.Increment(-1)},
+			{Path: "updatedAt", Value: time.Now()},
+		}
+		if countMarks {
+			updates = append(updates, firestore.Update{Path: "totalMarks", Value: firestore.Increment(-deleted.Points)})
+		}
+		return tx.Update(quizRef, updates)
+	})
+}
+
+// quizAttempted reads a quiz's Attempted flag inside a transaction
+func quizAttempted(tx *firestore.Transaction, quizRef *firestore.DocumentRef) (bool, error) {
+	doc, err := tx.Get(quizRef)
+	if status.Code(err) == codes.NotFound {
+		return false, ErrNotFound
+	}
+	if err != nil {
+		return false, err
+	}
+	var quiz models.Quiz
+	if err := doc.DataTo(&quiz); err != nil {
+		return false, err
+	}
+	return quiz.Attempted, nil
+}
+
+func (r firestoreQuestions) ReorderQuizQuestions(ctx context.Context, quizID string, questionIDs []string) error {
+	query := r.client.Collection("questions").Where("quizId", "==", quizID)
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		docs, err := tx.Documents(query).GetAll()
+		if err != nil {
+			return err
+		}
+
+		questions := make([]models.Question, len(docs))
+		for i, doc := range docs {
+			questions[i].ID = doc.Ref.ID
+		}
+
+		orders, err := reorderedQuestions(questions, questionIDs)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for id, order := range orders {
+			ref := r.client.Collection("questions").Doc(id)
+			if err := tx.Update(ref, []firestore.Update{{Path: "order", Value: order}, {Path: "updatedAt", Value: now}}); err != nil {
+				return err
+			}
+		}
+		return tx.Update(r.client.Collection("quizzes").Doc(quizID), []firestore.Update{{Path: "updatedAt", Value: now}})
+	})
+}
+
+func (r firestoreQuestions) list(ctx context.Context, query firestore.Query) ([]models.Question, error) {
+	questions, err := getAll(ctx, query, func(q *models.Question, id string) { q.ID = id })
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(questions, func(i, j int) bool { return questions[i].Order < questions[j].Order })
+	return questions, nil
+}
+
+// Quiz submissions
+
+type firestoreSubmissions struct{ client *firestore.Client }
+
+func (r firestoreSubmissions) Get(ctx context.Context, submissionID string) (*models.QuizSubmission, error) {
+	var submission models.QuizSubmission
+	if err := getDoc(ctx, r.client.Collection("quiz_submissions").Doc(submissionID), &submission); err != nil {
+		return nil, err
+	}
+	submission.ID = submissionID
+	return &submission, nil
+}
+
+func (r firestoreSubmissions) Create(ctx context.Context, submission *models.QuizSubmission) error {
+	ref := r.client.Collection("quiz_submissions").NewDoc()
+	submission.ID = ref.ID
+	submission.SubmissionID = ref.ID
+	_, err := ref.Set(ctx, submission)
+	return err
+}
+
+func (r firestoreSubmissions) Save(ctx context.Context, submission *models.QuizSubmission) error {
+	_, err := r.client.Collection("quiz_submissions").Doc(submission.ID).Set(ctx, submission)
+	return err
+}
+
+func (r firestoreSubmissions) SaveIfVersion(ctx context.Context, submission *models.QuizSubmission, version int) error {
+	ref := r.client.Collection("quiz_submissions").Doc(submission.ID)
+	next := *submission
+	next.Version = version + 1
+
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var current models.QuizSubmission
+		if err := doc.DataTo(&current); err != nil {
+			return err
+		}
+		if current.Version != version {
+			return ErrConflict
+		}
+		next.QuestionViews = current.QuestionViews
+		return tx.Set(ref, &next)
+	})
+	if err != nil {
+		return err
+	}
+	submission.Version = next.Version
+	submission.QuestionViews = next.QuestionViews
+	return nil
+}
+
+func (r firestoreSubmissions) AddQuestionView(ctx context.Context, submissionID string, view models.QuestionView) (*models.QuestionView, error) {
+	ref := r.client.Collection("quiz_submissions").Doc(submissionID)
+
+	var first models.QuestionView
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var current models.QuizSubmission
+		if err := doc.DataTo(&current); err != nil {
+			return err
+		}
+		viewCount := len(current.QuestionViews)
+		first = addQuestionView(&current, view)
+		if len(current.QuestionViews) == viewCount {
+			return nil
+		}
+		return tx.Update(ref, []firestore.Update{
+			{Path: "questionViews", Value: current.QuestionViews},
+			{Path: "updatedAt", Value: view.ViewedAt},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &first, nil
+}
+
+func (r firestoreSubmissions) List(ctx context.Context, filter SubmissionFilter) ([]models.QuizSubmission, error) {
+	query := r.client.Collection("quiz_submissions").Query
+	if filter.QuizID != "" {
+		query = query.Where("quizId", "==", filter.QuizID)
+	}
+	if filter.StudentID != "" {
+		query = query.Where("studentId", "==", filter.StudentID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status", "in", filter.Statuses)
+	}
+	submissions, err := getAll(ctx, query, func(s *models.QuizSubmission, id string) { s.ID = id })
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(submissions, func(i, j int) bool {
+		return submissions[i].SubmittedAt.After(submissions[j].SubmittedAt)
+	})
+	return submissions, nil
+}
+
+// Exams
+
+type firestoreExams struct{ client *firestore.Client }
+
+func (r firestoreExams) Get(ctx context.Context, examID string) (*models.Exam, error) {
+	var exam models.Exam
+	if err := getDoc(ctx, r.client.Collection("exams").Doc(examID), &exam); err != nil {
+		return nil, err
+	}
+	exam.ExamID = examID
+	return &exam, nil
+}
+
+func (r firestoreExams) Create(ctx context.Context, exam *models.Exam) error {
+	ref := r.client.Collection("exams").NewDoc()
+	exam.ExamID = ref.ID
+	_, err := ref.Set(ctx, exam)
+	return err
+}
+
+func (r firestoreExams) Save(ctx context.Context, exam *models.Exam) error {
+	_, err := r.client.Collection("exams").Doc(exam.ExamID).Set(ctx, exam)
+	return err
+}
+
+func (r firestoreExams) List(ctx context.Context, filter ExamFilter) ([]models.Exam, error) {
+	query := r.client.Collection("exams").Where("isDeleted", "==", false)
+	if filter.CourseID != "" {
+		query = query.Where("courseId", "==", filter.CourseID)
+	}
+	if len(filter.CourseIDs) > 0 {
+		query = query.Where("courseId", "in", filter.CourseIDs)
+	}
+	if filter.TeacherID != "" {
+		query = query.Where("teacherId", "==", filter.TeacherID)
+	}
+	if filter.ExamType != "" {
+		query = query.Where("examType", "==", filter.ExamType)
+	}
+	if filter.PublishedOnly {
+		query = query.Where("isPublished", "==", true)
+	}
+	query = query.OrderBy("startTime", firestore.Asc)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	return getAll(ctx, query, func(e *models.Exam, id string) { e.ExamID = id })
+}
+
+func (r firestoreExams) AddQuestions(ctx context.Context, examID string, delta int) error {
+	_, err := r.client.Collection("exams").Doc(examID).Update(ctx, []firestore.Update{
+		{Path: "questionsCount", Value: firestore.Increment(delta)},
+		{Path: "updatedAt", Value: time.Now()},
+	})
+	return err
+}
+
+// Exam submissions
+
+type firestoreExamSubmissions struct{ client *firestore.Client }
+
+func (r firestoreExamSubmissions) Get(ctx context.Context, submissionID string) (*models.ExamSubmission, error) {
+	var submission models.ExamSubmission
+	if err := getDoc(ctx, r.client.Collection("exam_submissions").Doc(submissionID), &submission); err != nil {
+		return nil, err
+	}
+	submission.SubmissionID = submissionID
+	return &submission, nil
+}
+
+func (r firestoreExamSubmissions) Create(ctx context.Context, submission *models.ExamSubmission) error {
+	ref := r.client.Collection("exam_submissions").NewDoc()
+	submission.SubmissionID = ref.ID
+	_, err := ref.Set(ctx, submission)
+	return err
+}
+
+func (r firestoreExamSubmissions) Save(ctx context.Context, submission *models.ExamSubmission) error {
+	_, err := r.client.Collection("exam_submissions").Doc(submission.SubmissionID).Set(ctx, submission)
+	return err
+}
+
+func (r firestoreExamSubmissions) SaveIfVersion(ctx context.Context, submission *models.ExamSubmission, version int) error {
+	ref := r.client.Collection("exam_submissions").Doc(submission.SubmissionID)
+	next := *submission
+	next.Version = version + 1
+
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var current models.ExamSubmission
+		if err := doc.DataTo(&current); err != nil {
+			return err
+		}
+		if current.Version != version {
+			return ErrConflict
+		}
+		return tx.Set(ref, &next)
+	})
+	if err != nil {
+		return err
+	}
+	submission.Version = next.Version
+	return nil
+}
+
+func (r firestoreExamSubmissions) List(ctx context.Context, filter ExamSubmissionFilter) ([]models.ExamSubmission, error) {
+	query := r.client.Collection("exam_submissions").Query
+	if filter.ExamID != "" {
+		query = query.Where("examId", "==", filter.ExamID)
+	}
+	if filter.StudentID != "" {
+		query = query.Where("studentId", "==", filter.StudentID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status", "in", filter.Statuses)
+	}
+	submissions, err := getAll(ctx, query, func(s *models.ExamSubmission, id string) { s.SubmissionID = id })
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(submissions, func(i, j int) bool {
+		return submissions[i].SubmittedAt.After(submissions[j].SubmittedAt)
+	})
+	return submissions, nil
+}
+
+// Assignments
+
+type firestoreAssignments struct{ client *firestore.Client }
+
+func (r firestoreAssignments) Get(ctx context.Context, assignmentID string) (*models.Assignment, error) {
+	var assignment models.Assignment
+	if err := getDoc(ctx, r.client.Collection("assignments").Doc(assignmentID), &assignment); err != nil {
+		return nil, err
+	}
+	assignment.AssignmentID = assignmentID
+	return &assignment, nil
+}
+
+func (r firestoreAssignments) Create(ctx context.Context, assignment *models.Assignment) error {
+	ref := r.client.Collection("assignments").NewDoc()
+	assignment.AssignmentID = ref.ID
+	_, err := ref.Set(ctx, assignment)
+	return err
+}
+
+func (r firestoreAssignments) Save(ctx context.Context, assignment *models.Assignment) error {
+	_, err := r.client.Collection("assignments").Doc(assignment.AssignmentID).Set(ctx, assignment)
+	return err
+}
+
+func (r firestoreAssignments) List(ctx context.Context, filter AssignmentFilter) ([]models.Assignment, error) {
+	query := r.client.Collection("assignments").Where("isDeleted", "==", false)
+	if filter.CourseID != "" {
+		query = query.Where("courseId", "==", filter.CourseID)
+	}
+	if len(filter.CourseIDs) > 0 {
+		query = query.Where("courseId", "in", filter.CourseIDs)
+	}
+	if filter.TeacherID != "" {
+		query = query.Where("teacherId", "==", filter.TeacherID)
+	}
+	if filter.PublishedOnly {
+		query = query.Where("isPublished", "==", true)
+	}
+	query = query.OrderBy("dueDate", firestore.Asc)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	return getAll(ctx, query, func(a *models.Assignment, id string) { a.AssignmentID = id })
+}
+
+// Assignment submissions
+
+type firestoreAssignmentSubmissions struct{ client *firestore.Client }
+
+func (r firestoreAssignmentSubmissions) Get(ctx context.Context, submissionID string) (*models.AssignmentSubmission, error) {
+	var submission models.AssignmentSubmission
+	if err := getDoc(ctx, r.client.Collection("assignment_submissions").Doc(submissionID), &submission); err != nil {
+		return nil, err
+	}
+	submission.SubmissionID = submissionID
+	return &submission, nil
+}
+
+func (r firestoreAssignmentSubmissions) Create(ctx context.Context, submission *models.AssignmentSubmission) error {
+	ref := r.client.Collection("assignment_submissions").NewDoc()
+	submission.SubmissionID = ref.ID
+	_, err := ref.Set(ctx, submission)
+	return err
+}
+
+func (r firestoreAssignmentSubmissions) Save(ctx context.Context, submission *models.AssignmentSubmission) error {
+	_, err := r.client.Collection("assignment_submissions").Doc(submission.SubmissionID).Set(ctx, submission)
+	return err
+}
+
+func (r firestoreAssignmentSubmissions) List(ctx context.Context, filter AssignmentSubmissionFilter) ([]models.AssignmentSubmission, error) {
+	query := r.client.Collection("assignment_submissions").Query
+	if filter.AssignmentID != "" {
+		query = query.Where("assignmentId", "==", filter.AssignmentID)
+	}
+	if filter.StudentID != "" {
+		query = query.Where("studentId", "==", filter.StudentID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status", "in", filter.Statuses)
+	}
+	submissions, err := getAll(ctx, query, func(s *models.AssignmentSubmission, id string) { s.SubmissionID = id })
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(submissions, func(i, j int) bool {
+		return submissions[i].SubmittedAt.After(submissions[j].SubmittedAt)
+	})
+	return submissions, nil
+}
+
+// Notifications
+
+type firestoreNotifications struct{ client *firestore.Client }
+
+func (r firestoreNotifications) Create(ctx context.Context, notification *models.Notification) error {
+	ref := r.client.Collection("notifications").NewDoc()
+	notification.NotificationID = ref.ID
+	_, err := ref.Set(ctx, notification)
+	return err
+}
+
+// Audit logs
+
+type firestoreAuditLogs struct{ client *firestore.Client }
+
+func (r firestoreAuditLogs) Create(ctx context.Context, entry *models.AuditLog) error {
+	ref := r.client.Collection("audit_logs").NewDoc()
+	entry.LogID = ref.ID
+	_, err := ref.Set(ctx, entry)
+	return err
+}
+
+// Accommodations
+
+type firestoreAccommodations struct{ client *firestore.Client }
+
+func (r firestoreAccommodations) Get(ctx context.Context, accommodationID string) (*models.Accommodation, error) {
+	var accommodation models.Accommodation
+	if err := getDoc(ctx, r.client.Collection("accommodations").Doc(accommodationID), &accommodation); err != nil {
+		return nil, err
+	}
+	accommodation.ID = accommodationID
+	return &accommodation, nil
+}
+
+func (r firestoreAccommodations) Save(ctx context.Context, accommodation *models.Accommodation) error {
+	_, err := r.client.Collection("accommodations").Doc(accommodation.ID).Set(ctx, accommodation)
+	return err
+}
+
+func (r firestoreAccommodations) Delete(ctx context.Context, accommodationID string) error {
+	_, err := r.client.Collection("accommodations").Doc(accommodationID).Delete(ctx)
+	return err
+}
+
+func (r firestoreAccommodations) List(ctx context.Context, studentID string) ([]models.Accommodation, error) {
+	query := r.client.Collection("accommodations").Query
+	if studentID != "" {
+		query = query.Where("studentId", "==", studentID)
+	}
+	accommodations, err := getAll(ctx, query, func(a *models.Accommodation, id string) { a.ID = id })
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(accommodations, func(i, j int) bool {
+		return accommodations[i].UpdatedAt.After(accommodations[j].UpdatedAt)
+	})
+	return accommodations, nil
+}
+
+// Invitations
+
+type firestoreInvitations struct{ client *firestore.Client }
+
+func (r firestoreInvitations) Get(ctx context.Context, code string) (*models.Invitation, error) {
+	var invitation models.Invitation
+	if err := getDoc(ctx, r.client.Collection("invitations").Doc(code), &invitation); err != nil {
+		return nil, err
+	}
+	invitation.Code = code
+	return &invitation, nil
+}
+
+func (r firestoreInvitations) Save(ctx context.Context, invitation *models.Invitation) error {
+	_, err := r.client.Collection("invitations").Doc(invitation.Code).Set(ctx, invitation)
+	return err
+}
+
+func (r firestoreInvitations) List(ctx context.Context) ([]models.Invitation, error) {
+	query := r.client.Collection("invitations").OrderBy("createdAt", firestore.Desc)
+	return getAll(ctx, query, func(i *models.Invitation, id string) { i.Code = id })
+}
+
+func (r firestoreInvitations) Redeem(ctx context.Context, code, email string, now time.Time) (*models.Invitation, error) {
+	ref := r.client.Collection("invitations").Doc(code)
+
+	var invitation models.Invitation
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := doc.DataTo(&invitation); err != nil {
+			return err
+		}
+		if !InvitationUsable(invitation, email, now) {
+			return ErrInvitationUnusable
+		}
+		invitation.Uses++
+		return tx.Update(ref, []firestore.Update{{Path: "uses", Value: firestore.Increment(1)}})
+	})
+	if err != nil {
+		return nil, err
+	}
+	invitation.Code = code
+	return &invitation, nil
+}
+
+// Role requests
+
+type firestoreRoleRequests struct{ client *firestore.Client }
+
+func (r firestoreRoleRequests) Get(ctx context.Context, userID string) (*models.RoleRequest, error) {
+	var request models.RoleRequest
+	if err := getDoc(ctx, r.client.Collection("role_requests").Doc(userID), &request); err != nil {
+		return nil, err
+	}
+	request.UserID = userID
+	return &request, nil
+}
+
+func (r firestoreRoleRequests) Save(ctx context.Context, request *models.RoleRequest) error {
+	_, err := r.client.Collection("role_requests").Doc(request.UserID).Set(ctx, request)
+	return err
+}
+
+func (r firestoreRoleRequests) List(ctx context.Context, requestStatus string) ([]models.RoleRequest, error) {
+	query := r.client.Collection("role_requests").Query
+	if requestStatus != "" {
+		query = query.Where("status", "==", requestStatus)
+	}
+	requests, err := getAll(ctx, query, func(req *models.RoleRequest, id string) { req.UserID = id })
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(requests, func(i, j int) bool {
+		return requests[i].CreatedAt.Before(requests[j].CreatedAt)
+	})
+	return requests, nil
+}
+
+// Join codes
+
+type firestoreJoinCodes struct{ client *firestore.Client }
+
+func (r firestoreJoinCodes) Get(ctx context.Context, code string) (*models.JoinCode, error) {
+	var joinCode models.JoinCode
+	if err := getDoc(ctx, r.client.Collection("join_codes").Doc(code), &joinCode); err != nil {
+		return nil, err
+	}
+	joinCode.Code = code
+	return &joinCode, nil
+}
+
+func (r firestoreJoinCodes) Save(ctx context.Context, code *models.JoinCode) error {
+	_, err := r.client.Collection("join_codes").Doc(code.Code).Set(ctx, code)
+	return err
+}
+
+func (r firestoreJoinCodes) List(ctx context.Context, courseID string) ([]models.JoinCode, error) {
+	query := r.client.Collection("join_codes").Where("courseId", "==", courseID)
+	joinCodes, err := getAll(ctx, query, func(c *models.JoinCode, id string) { c.Code = id })
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(joinCodes, func(i, j int) bool {
+		return joinCodes[i].CreatedAt.After(joinCodes[j].CreatedAt)
+	})
+	return joinCodes, nil
+}
+
+func (r firestoreJoinCodes) Redeem(ctx context.Context, code, courseID string, now time.Time) (*models.JoinCode, error) {
+	ref := r.client.Collection("join_codes").Doc(code)
+
+	var joinCode models.JoinCode
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := doc.DataTo(&joinCode); err != nil {
+			return err
+		}
+		if !JoinCodeUsable(joinCode, courseID, now) {
+			return ErrJoinCodeUnusable
+		}
+		joinCode.Uses++
+		return tx.Update(ref, []firestore.Update{{Path: "uses", Value: firestore.Increment(1)}})
+	})
+	if err != nil {
+		return nil, err
+	}
+	joinCode.Code = code
+	return &joinCode, nil
+}
+
+// Join requests
+
+type firestoreJoinRequests struct{ client *firestore.Client }
+
+func (r firestoreJoinRequests) Get(ctx context.Context, courseID, studentID string) (*models.JoinRequest, error) {
+	var request models.JoinRequest
+	if err := getDoc(ctx, r.client.Collection("join_requests").Doc(joinRequestID(courseID, studentID)), &request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r firestoreJoinRequests) Save(ctx context.Context, request *models.JoinRequest) error {
+	_, err := r.client.Collection("join_requests").Doc(joinRequestID(request.CourseID, request.StudentID)).Set(ctx, request)
+	return err
+}
+
+func (r firestoreJoinRequests) Review(ctx context.Context, request *models.JoinRequest, enrollment *models.Enrollment) (bool, error) {
+	ref := r.client.Collection("join_requests").Doc(joinRequestID(request.CourseID, request.StudentID))
+	enrolled := false
+
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		enrolled = false
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		var current models.JoinRequest
+		if err := doc.DataTo(&current); err != nil {
+			return err
+		}
+		if current.Status != "pending" {
+			return ErrRequestReviewed
+		}
+
+		if enrollment != nil {
+			// The student may have joined with a code in the meantime
+			existing, err := tx.Documents(r.client.Collection("enrollments").
+				Where("studentId", "==", request.StudentID).
+				Where("courseId", "==", request.CourseID).
+				Limit(1)).GetAll()
+			if err != nil {
+				return err
+			}
+			if len(existing) == 0 {
+				if err := tx.Set(r.client.Collection("enrollments").Doc(enrollment.EnrollmentID), enrollment); err != nil {
+					return err
+				}
+				if err := tx.Update(r.client.Collection("courses").Doc(request.CourseID), []firestore.Update{
+					{Path: "enrollmentCount", Value: firestore.Increment(1)},
+				}); err != nil {
+					return err
+				}
+				enrolled = true
+			}
+		}
+		return tx.Set(ref, request)
+	})
+	if err != nil {
+		return false, err
+	}
+	return enrolled, nil
+}
+
+func (r firestoreJoinRequests) List(ctx context.Context, filter JoinRequestFilter) ([]models.JoinRequest, error) {
+	query := r.client.Collection("join_requests").Query
+	if filter.CourseID != "" {
+		query = query.Where("courseId", "==", filter.CourseID)
+	}
+	if filter.StudentID != "" {
+		query = query.Where("studentId", "==", filter.StudentID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status", "==", filter.Status)
+	}
+	requests, err := getAll(ctx, query, func(*models.JoinRequest, string) {})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(requests, func(i, j int) bool {
+		return requests[i].CreatedAt.Before(requests[j].CreatedAt)
+	})
+	return requests, nil
+}
+
+// Analytics
+
+type firestoreAnalytics struct{ client *firestore.Client }
+
+func (r firestoreAnalytics) RecordQuizCompletion(ctx context.Context, studentID string, score float64) error {
+	now := time.Now()
+	docs, err := r.client.Collection("analytics").
+		Where("studentId", "==", studentID).
+		Limit(1).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return err
+	}
+
+	if len(docs) > 0 {
+		_, err = docs[0].Ref.Update(ctx, []firestore.Update{
+			{Path: "quizzesCompleted", Value: firestore.Increment(1)},
+			{Path: "totalQuizScore", Value: firestore.Increment(int(score))},
+			{Path: "updatedAt", Value: now},
+		})
+		return err
+	}
+
+	_, err = r.client.Collection("analytics").NewDoc().Set(ctx, map[string]interface{}{
+		"studentId":        studentID,
+		"quizzesCompleted": 1,
+		"totalQuizScore":   int(score),
+		"createdAt":        now,
+		"updatedAt":        now,
+	})
+	return err
+}
+
+// Question bank
+
+type firestoreBank struct{ client *firestore.Client }
+
+func (r firestoreBank) ref(bankQuestionID string) *firestore.DocumentRef {
+	return r.client.Collection("question_bank").Doc(bankQuestionID)
+}
+
+func (r firestoreBank) versionRef(bankQuestionID string, version int) *firestore.DocumentRef {
+	return r.ref(bankQuestionID).Collection("versions").Doc(strconv.Itoa(version))
+}
+
+func (r firestoreBank) Get(ctx context.Context, bankQuestionID string) (*models.BankQuestion, error) {
+	var question models.BankQuestion
+	if err := getDoc(ctx, r.ref(bankQuestionID), &question); err != nil {
+		return nil, err
+	}
+	question.ID = bankQuestionID
+	return &question, nil
+}
+
+func (r firestoreBank) Create(ctx context.Context, question *models.BankQuestion, editorID string) error {
+	ref := r.client.Collection("question_bank").NewDoc()
+	question.ID = ref.ID
+	question.Version = 1
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if err := tx.Set(ref, question); err != nil {
+			return err
+		}
+		return tx.Set(r.versionRef(question.ID, 1), bankVersion(*question, editorID))
+	})
+}
+
+func (r firestoreBank) Revise(ctx context.Context, question *models.BankQuestion, version int, editorID string) error {
+	ref := r.ref(question.ID)
+	next := *question
+	next.Version = version + 1
+
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var current models.BankQuestion
+		if err := doc.DataTo(&current); err != nil {
+			return err
+		}
+		if current.Version != version {
+			return ErrConflict
+		}
+		if err := tx.Set(ref, &next); err != nil {
+			return err
+		}
+		return tx.Set(r.versionRef(next.ID, next.Version), bankVersion(next, editorID))
+	})
+	if err != nil {
+		return err
+	}
+	question.Version = next.Version
+	return nil
+}
+
+func (r firestoreBank) GetVersion(ctx context.Context, bankQuestionID string, version int) (*models.BankQuestionVersion, error) {
+	var snapshot models.BankQuestionVersion
+	if err := getDoc(ctx, r.versionRef(bankQuestionID, version), &snapshot); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+func (r firestoreBank) ListVersions(ctx context.Context, bankQuestionID string) ([]models.BankQuestionVersion, error) {
+	query := r.ref(bankQuestionID).Collection("versions").OrderBy("version", firestore.Asc)
+	return getAll(ctx, query, func(v *models.BankQuestionVersion, id string) {})
+}
+
+func (r firestoreBank) List(ctx context.Context, filter BankFilter) ([]models.BankQuestion, error) {
+	base := r.client.Collection("question_bank").Where("isDeleted", "==", false)
+
+	// Owned and department-shared questions need separate queries
+	var queries []firestore.Query
+	if filter.OwnerID != "" {
+		queries = append(queries, base.Where("ownerId", "==", filter.OwnerID))
+	}
+	if filter.SharedDepartment != "" {
+		queries = append(queries, base.Where("department", "==", filter.SharedDepartment).Where("shared", "==", true))
+	}
+	if len(queries) == 0 {
+		queries = append(queries, base)
+	}
+
+	seen := make(map[string]bool)
+	questions := make([]models.BankQuestion, 0)
+	for _, query := range queries {
+		if filter.Topic != "" {
+			query = query.Where("topics", "array-contains", filter.Topic)
+		}
+		results, err := getAll(ctx, query, func(q *models.BankQuestion, id string) { q.ID = id })
+		if err != nil {
+			return nil, err
+		}
+		for _, q := range results {
+			if !seen[q.ID] && filter.matches(q) {
+				seen[q.ID] = true
+				questions = append(questions, q)
+			}
+		}
+	}
+
+	sort.SliceStable(questions, func(i, j int) bool {
+		return questions[i].UpdatedAt.After(questions[j].UpdatedAt)
+	})
+	return page(questions, 0, filter.Limit), nil
+}
@@ -0,0 +1,982 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/google/uuid"
+)
+
+// MemoryStore implements Store in process memory for local development and tests
+type MemoryStore struct {
+	users                 *table[models.User]
+	courses               *table[models.Course]
+	enrollments           *table[models.Enrollment]
+	quizzes               *table[models.Quiz]
+	questions             *table[models.Question]
+	submissions           *table[models.QuizSubmission]
+	exams                 *table[models.Exam]
+	examSubmissions       *table[models.ExamSubmission]
+	assignments           *table[models.Assignment]
+	assignmentSubmissions *table[models.AssignmentSubmission]
+	notifications         *table[models.Notification]
+	auditLogs             *table[models.AuditLog]
+	analytics             *table[studentTotals]
+	bank                  *table[models.BankQuestion]
+	bankVersions          *table[models.BankQuestionVersion]
+	accommodations        *table[models.Accommodation]
+	invitations           *table[models.Invitation]
+	roleRequests          *table[models.RoleRequest]
+	joinCodes             *table[models.JoinCode]
+	joinRequests          *table[models.JoinRequest]
+
+	// quizEdits serialises edits that touch a quiz and its questions together
+	quizEdits sync.Mutex
+	// joinReviews serialises join request reviews with the enrollments they create
+	joinReviews sync.Mutex
+}
+
+// studentTotals mirrors the running totals kept in the analytics collection
+type studentTotals struct {
+	StudentID        string    `json:"studentId"`
+	QuizzesCompleted int       `json:"quizzesCompleted"`
+	TotalQuizScore   int       `json:"totalQuizScore"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// NewMemoryStore returns an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		users:                 newTable[models.User](),
+		courses:               newTable[models.Course](),
+		enrollments:           newTable[models.Enrollment](),
+		quizzes:               newTable[models.Quiz](),
+		questions:             newTable[models.Question](),
+		submissions:           newTable[models.QuizSubmission](),
+		exams:                 newTable[models.Exam](),
+		examSubmissions:       newTable[models.ExamSubmission](),
+		assignments:           newTable[models.Assignment](),
+		assignmentSubmissions: newTable[models.AssignmentSubmission](),
+		notifications:         newTable[models.Notification](),
+		auditLogs:             newTable[models.AuditLog](),
+		analytics:             newTable[studentTotals](),
+		bank:                  newTable[models.BankQuestion](),
+		bankVersions:          newTable[models.BankQuestionVersion](),
+		accommodations:        newTable[models.Accommodation](),
+		invitations:           newTable[models.Invitation](),
+		roleRequests:          newTable[models.RoleRequest](),
+		joinCodes:             newTable[models.JoinCode](),
+		joinRequests:          newTable[models.JoinRequest](),
+	}
+}
+
+func (s *MemoryStore) Users() UserRepository             { return memoryUsers{s} }
+func (s *MemoryStore) Courses() CourseRepository         { return memoryCourses{s} }
+func (s *MemoryStore) Enrollments() EnrollmentRepository { return memoryEnrollments{s} }
+func (s *MemoryStore) Quizzes() QuizRepository           { return memoryQuizzes{s} }
+func (s *MemoryStore) Questions() QuestionRepository     { return memoryQuestions{s} }
+func (s *MemoryStore) Submissions() SubmissionRepository { return memorySubmissions{s} }
+func (s *MemoryStore) Exams() ExamRepository             { return memoryExams{s} }
+func (s *MemoryStore) ExamSubmissions() ExamSubmissionRepository {
+	return memoryExamSubmissions{s}
+}
+func (s *MemoryStore) Assignments() AssignmentRepository { return memoryAssignments{s} }
+func (s *MemoryStore) AssignmentSubmissions() AssignmentSubmissionRepository {
+	return memoryAssignmentSubmissions{s}
+}
+func (s *MemoryStore) Notifications() NotificationRepository { return memoryNotifications{s} }
+func (s *MemoryStore) AuditLogs() AuditLogRepository         { return memoryAuditLogs{s} }
+func (s *MemoryStore) Analytics() AnalyticsRepository        { return memoryAnalytics{s} }
+func (s *MemoryStore) QuestionBank() BankRepository          { return memoryBank{s} }
+func (s *MemoryStore) Accommodations() AccommodationRepository {
+	return memoryAccommodations{s}
+}
+func (s *MemoryStore) Invitations() InvitationRepository { return memoryInvitations{s} }
+func (s *MemoryStore) RoleRequests() RoleRequestRepository {
+	return memoryRoleRequests{s}
+}
+func (s *MemoryStore) JoinCodes() JoinCodeRepository       { return memoryJoinCodes{s} }
+func (s *MemoryStore) JoinRequests() JoinRequestRepository { return memoryJoinRequests{s} }
+
+// table is a mutex-guarded map of documents. Values are deep-copied on the
+// way in and out so callers can never alias stored state, as with Firestore.
+type table[T any] struct {
+	mu   sync.RWMutex
+	rows map[string]T
+}
+
+func newTable[T any]() *table[T] {
+	return &table[T]{rows: make(map[string]T)}
+}
+
+func (t *table[T]) get(id string) (*T, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	v, ok := t.rows[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	c := clone(v)
+	return &c, nil
+}
+
+func (t *table[T]) put(id string, v T) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rows[id] = clone(v)
+}
+
+// update applies fn to a stored value under the write lock
+func (t *table[T]) update(id string, fn func(*T)) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	v, ok := t.rows[id]
+	if !ok {
+		return ErrNotFound
+	}
+	fn(&v)
+	t.rows[id] = v
+	return nil
+}
+
+// replaceIf stores v only if check accepts the current stored value
+func (t *table[T]) replaceIf(id string, v T, check func(current T) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	current, ok := t.rows[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if err := check(current); err != nil {
+		return err
+	}
+	t.rows[id] = clone(v)
+	return nil
+}
+
+// apply runs fn on a stored value under the write lock and keeps the change
+// only if fn succeeds
+func (t *table[T]) apply(id string, fn func(*T) error) (*T, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	v, ok := t.rows[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if err := fn(&v); err != nil {
+		return nil, err
+	}
+	t.rows[id] = v
+	c := clone(v)
+	return &c, nil
+}
+
+// upsert applies fn to a stored value, or to a zero value if none exists
+func (t *table[T]) upsert(id string, fn func(v *T, exists bool)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	v, ok := t.rows[id]
+	fn(&v, ok)
+	t.rows[id] = v
+}
+
+func (t *table[T]) remove(id string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.rows[id]; !ok {
+		return ErrNotFound
+	}
+	delete(t.rows, id)
+	return nil
+}
+
+func (t *table[T]) filter(keep func(T) bool) []T {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	results := make([]T, 0)
+	for _, v := range t.rows {
+		if keep(v) {
+			results = append(results, clone(v))
+		}
+	}
+	return results
+}
+
+func clone[T any](v T) T {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic("store: cannot copy value: " + err.Error())
+	}
+	var c T
+	if err := json.Unmarshal(data, &c); err != nil {
+		panic("store: cannot copy value: " + err.Error())
+	}
+	return c
+}
+
+func newID() string {
+	return uuid.New().String()
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// page applies offset and limit to an already sorted slice
+func page[T any](items []T, offset, limit int) []T {
+	if offset >= len(items) {
+		return items[:0]
+	}
+	items = items[offset:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+// Users
+
+type memoryUsers struct{ s *MemoryStore }
+
+func (r memoryUsers) Get(ctx context.Context, uid string) (*models.User, error) {
+	return r.s.users.get(uid)
+}
+
+func (r memoryUsers) Save(ctx context.Context, user *models.User) error {
+	r.s.users.put(user.UID, *user)
+	return nil
+}
+
+func (r memoryUsers) Delete(ctx context.Context, uid string) error {
+	return r.s.users.remove(uid)
+}
+
+// Courses
+
+type memoryCourses struct{ s *MemoryStore }
+
+func (r memoryCourses) Get(ctx context.Context, courseID string) (*models.Course, error) {
+	return r.s.courses.get(courseID)
+}
+
+func (r memoryCourses) Save(ctx context.Context, course *models.Course) error {
+	r.s.courses.put(course.CourseID, *course)
+	return nil
+}
+
+func (r memoryCourses) List(ctx context.Context, filter CourseFilter) ([]models.Course, error) {
+	courses := r.s.courses.filter(func(c models.Course) bool {
+		return !c.IsDeleted &&
+			(filter.TeacherID == "" || c.TeacherID == filter.TeacherID) &&
+			(!filter.PublishedOnly || c.IsPublished)
+	})
+	sort.SliceStable(courses, func(i, j int) bool { return courses[i].CreatedAt.After(courses[j].CreatedAt) })
+	return page(courses, filter.Offset, filter.Limit), nil
+}
+
+func (r memoryCourses) AddEnrollments(ctx context.Context, courseID string, delta int) error {
+	return r.s.courses.update(courseID, func(c *models.Course) { c.EnrollmentCount += delta })
+}
+
+// Enrollments
+
+type memoryEnrollments struct{ s *MemoryStore }
+
+func (r memoryEnrollments) Save(ctx context.Context, enrollment *models.Enrollment) error {
+	r.s.enrollments.put(enrollment.EnrollmentID, *enrollment)
+	return nil
+}
+
+func (r memoryEnrollments) Delete(ctx context.Context, enrollmentID string) error {
+	return r.s.enrollments.remove(enrollmentID)
+}
+
+func (r memoryEnrollments) List(ctx context.Context, filter EnrollmentFilter) ([]models.Enrollment, error) {
+	enrollments := r.s.enrollments.filter(func(e models.Enrollment) bool {
+		return (filter.StudentID == "" || e.StudentID == filter.StudentID) &&
+			(filter.CourseID == "" || e.CourseID == filter.CourseID) &&
+			(filter.Status == "" || e.Status == filter.Status)
+	})
+	sort.SliceStable(enrollments, func(i, j int) bool {
+		return enrollments[i].EnrolledAt.After(enrollments[j].EnrolledAt)
+	})
+	return enrollments, nil
+}
+
+// Quizzes
+
+type memoryQuizzes struct{ s *MemoryStore }
+
+func (r memoryQuizzes) Get(ctx context.Context, quizID string) (*models.Quiz, error) {
+	return r.s.quizzes.get(quizID)
+}
+
+func (r memoryQuizzes) Create(ctx context.Context, quiz *models.Quiz) error {
+	quiz.ID = newID()
+	r.s.quizzes.put(quiz.ID, *quiz)
+	return nil
+}
+
+func (r memoryQuizzes) Save(ctx context.Context, quiz *models.Quiz) error {
+	return r.s.quizzes.update(quiz.ID, func(q *models.Quiz) {
+		quiz.QuestionCount = q.QuestionCount
+		quiz.TotalMarks = q.TotalMarks
+		quiz.Attempted = q.Attempted
+		*q = *quiz
+	})
+}
+
+func (r memoryQuizzes) List(ctx context.Context, filter QuizFilter) ([]models.Quiz, error) {
+	quizzes := r.s.quizzes.filter(func(q models.Quiz) bool {
+		return !q.IsDeleted &&
+			(filter.CourseID == "" || q.CourseID == filter.CourseID) &&
+			(len(filter.CourseIDs) == 0 || contains(filter.CourseIDs, q.CourseID)) &&
+			(filter.TeacherID == "" || q.TeacherID == filter.TeacherID) &&
+			(!filter.PublishedOnly || q.IsPublished)
+	})
+	sort.SliceStable(quizzes, func(i, j int) bool { return quizzes[i].CreatedAt.After(quizzes[j].CreatedAt) })
+	return page(quizzes, 0, filter.Limit), nil
+}
+
+func (r memoryQuizzes) AddQuestionStats(ctx context.Context, quizID string, questions int, marks float64) error {
+	return r.s.quizzes.update(quizID, func(q *models.Quiz) {
+		q.QuestionCount += questions
+		q.TotalMarks += marks
+		q.UpdatedAt = time.Now()
+	})
+}
+
+func (r memoryQuizzes) MarkAttempted(ctx context.Context, quizID string) error {
+	r.s.quizEdits.Lock()
+	defer r.s.quizEdits.Unlock()
+
+	return r.s.quizzes.update(quizID, func(q *models.Quiz) { q.Attempted = true })
+}
+
+// Questions
+
+type memoryQuestions struct{ s *MemoryStore }
+
+func (r memoryQuestions) Get(ctx context.Context, questionID string) (*models.Question, error) {
+	return r.s.questions.get(questionID)
+}
+
+func (r memoryQuestions) Create(ctx context.Context, question *models.Question) error {
+	question.ID = newID()
+	question.QuestionID = question.ID
+	r.s.questions.put(question.ID, *question)
+	return nil
+}
+
+func (r memoryQuestions) Save(ctx context.Context, question *models.Question) error {
+	r.s.questions.put(question.ID, *question)
+	return nil
+}
+
+func (r memoryQuestions) ListByQuiz(ctx context.Context, quizID string) ([]models.Question, error) {
+	return r.list(func(q models.Question) bool { return q.QuizID == quizID }), nil
+}
+
+func (r memoryQuestions) ListByExam(ctx context.Context, examID string) ([]models.Question, error) {
+	return r.list(func(q models.Question) bool { return q.ExamID == examID }), nil
+}
+
+func (r memoryQuestions) ListByBankQuestion(ctx context.Context, bankQuestionID string) ([]models.Question, error) {
+	return r.list(func(q models.Question) bool { return q.BankQuestionID == bankQuestionID }), nil
+}
+
+func (r memoryQuestions) UpdateQuizQuestion(ctx context.Context, question *models.Question, countMarks, gradingChanged bool) error {
+	r.s.quizEdits.Lock()
+	defer r.s.quizEdits.Unlock()
+
+	current, err := r.s.questions.get(question.ID)
+	if err != nil {
+		return err
+	}
+	quiz, err := r.s.quizzes.get(question.QuizID)
+	if err != nil {
+		return err
+	}
+	if gradingChanged && quiz.Attempted {
+		return ErrQuizAttempted
+	}
+	r.s.questions.put(question.ID, *question)
+	return r.s.quizzes.update(question.QuizID, func(q *models.Quiz) {
+		if countMarks {
+			q.TotalMarks += question.Points - current.Points
+		}
+		q.UpdatedAt = time.Now()
+	})
+}
+
+func (r memoryQuestions) DeleteQuizQuestion(ctx context.Context, quizID, questionID string, countMarks bool) error {
+	r.s.quizEdits.Lock()
+	defer r.s.quizEdits.Unlock()
+
+	deleted, err := r.s.questions.get(questionID)
+	if err != nil {
+		return err
+	}
+	if deleted.QuizID != quizID {
+		return ErrNotFound
+	}
+	quiz, err := r.s.quizzes.get(quizID)
+	if err != nil {
+		return err
+	}
+	if quiz.Attempted {
+		return ErrQuizAttempted
+	}
+	if err := r.s.questions.remove(questionID); err != nil {
+		return err
+	}
+
+	// Close the gap so new questions keep getting unique positions
+	for i, q := range r.list(func(q models.Question) bool { return q.QuizID == quizID }) {
+		if q.Order != i+1 {
+			r.s.questions.update(q.ID, func(q *models.Question) { q.Order = i + 1 })
+		}
+	}
+
+	return r.s.quizzes.update(quizID, func(q *models.Quiz) {
+		q.QuestionCount--
+		if countMarks {
+			q.TotalMarks -= deleted.Points
+		}
+		q.UpdatedAt = time.Now()
+	})
+}
+
+func (r memoryQuestions) ReorderQuizQuestions(ctx context.Context, quizID string, questionIDs []string) error {
+	r.s.quizEdits.Lock()
+	defer r.s.quizEdits.Unlock()
+
+	questions := r.list(func(q models.Question) bool { return q.QuizID == quizID })
+	orders, err := reorderedQuestions(questions, questionIDs)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for id, order := range orders {
+		r.s.questions.update(id, func(q *models.Question) {
+			q.Order = order
+			q.UpdatedAt = now
+		})
+	}
+	return r.s.quizzes.update(quizID, func(q *models.Quiz) { q.UpdatedAt = now })
+}
+
+func (r memoryQuestions) list(keep func(models.Question) bool) []models.Question {
+	questions := r.s.questions.filter(keep)
+	sort.SliceStable(questions, func(i, j int) bool {
+		if questions[i].Order != questions[j].Order {
+			return questions[i].Order < questions[j].Order
+		}
+		return questions[i].CreatedAt.Before(questions[j].CreatedAt)
+	})
+	return questions
+}
+
+// Quiz submissions
+
+type memorySubmissions struct{ s *MemoryStore }
+
+func (r memorySubmissions) Get(ctx context.Context, submissionID string) (*models.QuizSubmission, error) {
+	return r.s.submissions.get(submissionID)
+}
+
+func (r memorySubmissions) Create(ctx context.Context, submission *models.QuizSubmission) error {
+	submission.ID = newID()
+	submission.SubmissionID = submission.ID
+	r.s.submissions.put(submission.ID, *submission)
+	return nil
+}
+
+func (r memorySubmissions) Save(ctx context.Context, submission *models.QuizSubmission) error {
+	r.s.submissions.put(submission.ID, *submission)
+	return nil
+}
+
+func (r memorySubmissions) SaveIfVersion(ctx context.Context, submission *models.QuizSubmission, version int) error {
+	next := clone(*submission)
+	next.Version = version + 1
+	stored, err := r.s.submissions.apply(submission.ID, func(current *models.QuizSubmission) error {
+		if current.Version != version {
+			return ErrConflict
+		}
+		next.QuestionViews = current.QuestionViews
+		*current = next
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	submission.Version = stored.Version
+	submission.QuestionViews = stored.QuestionViews
+	return nil
+}
+
+func (r memorySubmissions) AddQuestionView(ctx context.Context, submissionID string, view models.QuestionView) (*models.QuestionView, error) {
+	var first models.QuestionView
+	_, err := r.s.submissions.apply(submissionID, func(submission *models.QuizSubmission) error {
+		viewCount := len(submission.QuestionViews)
+		first = addQuestionView(submission, view)
+		if len(submission.QuestionViews) != viewCount {
+			submission.UpdatedAt = view.ViewedAt
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &first, nil
+}
+
+func (r memorySubmissions) List(ctx context.Context, filter SubmissionFilter) ([]models.QuizSubmission, error) {
+	submissions := r.s.submissions.filter(func(s models.QuizSubmission) bool {
+		return (filter.QuizID == "" || s.QuizID == filter.QuizID) &&
+			(filter.StudentID == "" || s.StudentID == filter.StudentID) &&
+			(len(filter.Statuses) == 0 || contains(filter.Statuses, s.Status))
+	})
+	sort.SliceStable(submissions, func(i, j int) bool {
+		return submissions[i].SubmittedAt.After(submissions[j].SubmittedAt)
+	})
+	return submissions, nil
+}
+
+// Exams
+
+type memoryExams struct{ s *MemoryStore }
+
+func (r memoryExams) Get(ctx context.Context, examID string) (*models.Exam, error) {
+	return r.s.exams.get(examID)
+}
+
+func (r memoryExams) Create(ctx context.Context, exam *models.Exam) error {
+	exam.ExamID = newID()
+	r.s.exams.put(exam.ExamID, *exam)
+	return nil
+}
+
+func (r memoryExams) Save(ctx context.Context, exam *models.Exam) error {
+	r.s.exams.put(exam.ExamID, *exam)
+	return nil
+}
+
+func (r memoryExams) List(ctx context.Context, filter ExamFilter) ([]models.Exam, error) {
+	exams := r.s.exams.filter(func(e models.Exam) bool {
+		return !e.IsDeleted &&
+			(filter.CourseID == "" || e.CourseID == filter.CourseID) &&
+			(len(filter.CourseIDs) == 0 || contains(filter.CourseIDs, e.CourseID)) &&
+			(filter.TeacherID == "" || e.TeacherID == filter.TeacherID) &&
+			(filter.ExamType == "" || e.ExamType == filter.ExamType) &&
+			(!filter.PublishedOnly || e.IsPublished)
+	})
+	sort.SliceStable(exams, func(i, j int) bool { return exams[i].StartTime.Before(exams[j].StartTime) })
+	return page(exams, 0, filter.Limit), nil
+}
+
+func (r memoryExams) AddQuestions(ctx context.Context, examID string, delta int) error {
+	return r.s.exams.update(examID, func(e *models.Exam) {
+		e.QuestionsCount += delta
+		e.UpdatedAt = time.Now()
+	})
+}
+
+// Exam submissions
+
+type memoryExamSubmissions struct{ s *MemoryStore }
+
+func (r memoryExamSubmissions) Get(ctx context.Context, submissionID string) (*models.ExamSubmission, error) {
+	return r.s.examSubmissions.get(submissionID)
+}
+
+func (r memoryExamSubmissions) Create(ctx context.Context, submission *models.ExamSubmission) error {
+	submission.SubmissionID = newID()
+	r.s.examSubmissions.put(submission.SubmissionID, *submission)
+	return nil
+}
+
+func (r memoryExamSubmissions) Save(ctx context.Context, submission *models.ExamSubmission) error {
+	r.s.examSubmissions.put(submission.SubmissionID, *submission)
+	return nil
+}
+
+func (r memoryExamSubmissions) SaveIfVersion(ctx context.Context, submission *models.ExamSubmission, version int) error {
+	next := clone(*submission)
+	next.Version = version + 1
+	_, err := r.s.examSubmissions.apply(submission.SubmissionID, func(current *models.ExamSubmission) error {
+		if current.Version != version {
+			return ErrConflict
+		}
+		*current = next
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	submission.Version = next.Version
+	return nil
+}
+
+func (r memoryExamSubmissions) List(ctx context.Context, filter ExamSubmissionFilter) ([]models.ExamSubmission, error) {
+	submissions := r.s.examSubmissions.filter(func(s models.ExamSubmission) bool {
+		return (filter.ExamID == "" || s.ExamID == filter.ExamID) &&
+			(filter.StudentID == "" || s.StudentID == filter.StudentID) &&
+			(len(filter.Statuses) == 0 || contains(filter.Statuses, s.Status))
+	})
+	sort.SliceStable(submissions, func(i, j int) bool {
+		return submissions[i].SubmittedAt.After(submissions[j].SubmittedAt)
+	})
+	return submissions, nil
+}
+
+// Assignments
+
+type memoryAssignments struct{ s *MemoryStore }
+
+func (r memoryAssignments) Get(ctx context.Context, assignmentID string) (*models.Assignment, error) {
+	return r.s.assignments.get(assignmentID)
+}
+
+func (r memoryAssignments) Create(ctx context.Context, assignment *models.Assignment) error {
+	assignment.AssignmentID = newID()
+	r.s.assignments.put(assignment.AssignmentID, *assignment)
+	return nil
+}
+
+func (r memoryAssignments) Save(ctx context.Context, assignment *models.Assignment) error {
+	r.s.assignments.put(assignment.AssignmentID, *assignment)
+	return nil
+}
+
+func (r memoryAssignments) List(ctx context.Context, filter AssignmentFilter) ([]models.Assignment, error) {
+	assignments := r.s.assignments.filter(func(a models.Assignment) bool {
+		return !a.IsDeleted &&
+			(filter.CourseID == "" || a.CourseID == filter.CourseID) &&
+			(len(filter.CourseIDs) == 0 || contains(filter.CourseIDs, a.CourseID)) &&
+			(filter.TeacherID == "" || a.TeacherID == filter.TeacherID) &&
+			(!filter.PublishedOnly || a.IsPublished)
+	})
+	sort.SliceStable(assignments, func(i, j int) bool { return assignments[i].DueDate.Before(assignments[j].DueDate) })
+	return page(assignments, 0, filter.Limit), nil
+}
+
+// Assignment submissions
+
+type memoryAssignmentSubmissions struct{ s *MemoryStore }
+
+func (r memoryAssignmentSubmissions) Get(ctx context.Context, submissionID string) (*models.AssignmentSubmission, error) {
+	return r.s.assignmentSubmissions.get(submissionID)
+}
+
+func (r memoryAssignmentSubmissions) Create(ctx context.Context, submission *models.AssignmentSubmission) error {
+	submission.SubmissionID = newID()
+	r.s.assignmentSubmissions.put(submission.SubmissionID, *submission)
+	return nil
+}
+
+func (r memoryAssignmentSubmissions) Save(ctx context.Context, submission *models.AssignmentSubmission) error {
+	r.s.assignmentSubmissions.put(submission.SubmissionID, *submission)
+	return nil
+}
+
+func (r memoryAssignmentSubmissions) List(ctx context.Context, filter AssignmentSubmissionFilter) ([]models.AssignmentSubmission, error) {
+	submissions := r.s.assignmentSubmissions.filter(func(s models.AssignmentSubmission) bool {
+		return (filter.AssignmentID == "" || s.AssignmentID == filter.AssignmentID) &&
+			(filter.StudentID == "" || s.StudentID == filter.StudentID) &&
+			(len(filter.Statuses) == 0 || contains(filter.Statuses, s.Status))
+	})
+	sort.SliceStable(submissions, func(i, j int) bool {
+		return submissions[i].SubmittedAt.After(submissions[j].SubmittedAt)
+	})
+	return submissions, nil
+}
+
+// Notifications
+
+type memoryNotifications struct{ s *MemoryStore }
+
+func (r memoryNotifications) Create(ctx context.Context, notification *models.Notification) error {
+	notification.NotificationID = newID()
+	r.s.notifications.put(notification.NotificationID, *notification)
+	return nil
+}
+
+// Audit logs
+
+type memoryAuditLogs struct{ s *MemoryStore }
+
+func (r memoryAuditLogs) Create(ctx context.Context, entry *models.AuditLog) error {
+	entry.LogID = newID()
+	r.s.auditLogs.put(entry.LogID, *entry)
+	return nil
+}
+
+// Accommodations
+
+type memoryAccommodations struct{ s *MemoryStore }
+
+func (r memoryAccommodations) Get(ctx context.Context, accommodationID string) (*models.Accommodation, error) {
+	return r.s.accommodations.get(accommodationID)
+}
+
+func (r memoryAccommodations) Save(ctx context.Context, accommodation *models.Accommodation) error {
+	r.s.accommodations.put(accommodation.ID, *accommodation)
+	return nil
+}
+
+func (r memoryAccommodations) Delete(ctx context.Context, accommodationID string) error {
+	return r.s.accommodations.remove(accommodationID)
+}
+
+func (r memoryAccommodations) List(ctx context.Context, studentID string) ([]models.Accommodation, error) {
+	accommodations := r.s.accommodations.filter(func(a models.Accommodation) bool {
+		return studentID == "" || a.StudentID == studentID
+	})
+	sort.SliceStable(accommodations, func(i, j int) bool {
+		return accommodations[i].UpdatedAt.After(accommodations[j].UpdatedAt)
+	})
+	return accommodations, nil
+}
+
+// Invitations
+
+type memoryInvitations struct{ s *MemoryStore }
+
+func (r memoryInvitations) Get(ctx context.Context, code string) (*models.Invitation, error) {
+	return r.s.invitations.get(code)
+}
+
+func (r memoryInvitations) Save(ctx context.Context, invitation *models.Invitation) error {
+	r.s.invitations.put(invitation.Code, *invitation)
+	return nil
+}
+
+func (r memoryInvitations) List(ctx context.Context) ([]models.Invitation, error) {
+	invitations := r.s.invitations.filter(func(models.Invitation) bool { return true })
+	sort.SliceStable(invitations, func(i, j int) bool {
+		return invitations[i].CreatedAt.After(invitations[j].CreatedAt)
+	})
+	return invitations, nil
+}
+
+func (r memoryInvitations) Redeem(ctx context.Context, code, email string, now time.Time) (*models.Invitation, error) {
+	return r.s.invitations.apply(code, func(invitation *models.Invitation) error {
+		if !InvitationUsable(*invitation, email, now) {
+			return ErrInvitationUnusable
+		}
+		invitation.Uses++
+		return nil
+	})
+}
+
+// Role requests
+
+type memoryRoleRequests struct{ s *MemoryStore }
+
+func (r memoryRoleRequests) Get(ctx context.Context, userID string) (*models.RoleRequest, error) {
+	return r.s.roleRequests.get(userID)
+}
+
+func (r memoryRoleRequests) Save(ctx context.Context, request *models.RoleRequest) error {
+	r.s.roleRequests.put(request.UserID, *request)
+	return nil
+}
+
+func (r memoryRoleRequests) List(ctx context.Context, status string) ([]models.RoleRequest, error) {
+	requests := r.s.roleRequests.filter(func(req models.RoleRequest) bool {
+		return status == "" || req.Status == status
+	})
+	sort.SliceStable(requests, func(i, j int) bool {
+		return requests[i].CreatedAt.Before(requests[j].CreatedAt)
+	})
+	return requests, nil
+}
+
+// Join codes
+
+type memoryJoinCodes struct{ s *MemoryStore }
+
+func (r memoryJoinCodes) Get(ctx context.Context, code string) (*models.JoinCode, error) {
+	return r.s.joinCodes.get(code)
+}
+
+func (r memoryJoinCodes) Save(ctx context.Context, code *models.JoinCode) error {
+	r.s.joinCodes.put(code.Code, *code)
+	return nil
+}
+
+func (r memoryJoinCodes) List(ctx context.Context, courseID string) ([]models.JoinCode, error) {
+	codes := r.s.joinCodes.filter(func(c models.JoinCode) bool { return c.CourseID == courseID })
+	sort.SliceStable(codes, func(i, j int) bool {
+		return codes[i].CreatedAt.After(codes[j].CreatedAt)
+	})
+	return codes, nil
+}
+
+func (r memoryJoinCodes) Redeem(ctx context.Context, code, courseID string, now time.Time) (*models.JoinCode, error) {
+	return r.s.joinCodes.apply(code, func(joinCode *models.JoinCode) error {
+		if !JoinCodeUsable(*joinCode, courseID, now) {
+			return ErrJoinCodeUnusable
+		}
+		joinCode.Uses++
+		return nil
+	})
+}
+
+// Join requests
+
+type memoryJoinRequests struct{ s *MemoryStore }
+
+func (r memoryJoinRequests) Get(ctx context.Context, courseID, studentID string) (*models.JoinRequest, error) {
+	return r.s.joinRequests.get(joinRequestID(courseID, studentID))
+}
+
+func (r memoryJoinRequests) Save(ctx context.Context, request *models.JoinRequest) error {
+	r.s.joinRequests.put(joinRequestID(request.CourseID, request.StudentID), *request)
+	return nil
+}
+
+func (r memoryJoinRequests) Review(ctx context.Context, request *models.JoinRequest, enrollment *models.Enrollment) (bool, error) {
+	r.s.joinReviews.Lock()
+	defer r.s.joinReviews.Unlock()
+
+	id := joinRequestID(request.CourseID, request.StudentID)
+	current, err := r.s.joinRequests.get(id)
+	if err != nil {
+		return false, err
+	}
+	if current.Status != "pending" {
+		return false, ErrRequestReviewed
+	}
+
+	enrolled := false
+	if enrollment != nil {
+		existing := r.s.enrollments.filter(func(e models.Enrollment) bool {
+			return e.StudentID == request.StudentID && e.CourseID == request.CourseID
+		})
+		if len(existing) == 0 {
+			r.s.enrollments.put(enrollment.EnrollmentID, *enrollment)
+			r.s.courses.update(request.CourseID, func(c *models.Course) { c.EnrollmentCount++ })
+			enrolled = true
+		}
+	}
+	r.s.joinRequests.put(id, *request)
+	return enrolled, nil
+}
+
+func (r memoryJoinRequests) List(ctx context.Context, filter JoinRequestFilter) ([]models.JoinRequest, error) {
+	requests := r.s.joinRequests.filter(func(req models.JoinRequest) bool {
+		return (filter.CourseID == "" || req.CourseID == filter.CourseID) &&
+			(filter.StudentID == "" || req.StudentID == filter.StudentID) &&
+			(filter.Status == "" || req.Status == filter.Status)
+	})
+	sort.SliceStable(requests, func(i, j int) bool {
+		return requests[i].CreatedAt.Before(requests[j].CreatedAt)
+	})
+	return requests, nil
+}
+
+// Analytics
+
+type memoryAnalytics struct{ s *MemoryStore }
+
+func (r memoryAnalytics) RecordQuizCompletion(ctx context.Context, studentID string, score float64) error {
+	now := time.Now()
+	r.s.analytics.upsert(studentID, func(t *studentTotals, exists bool) {
+		if !exists {
+			t.StudentID = studentID
+			t.CreatedAt = now
+		}
+		t.QuizzesCompleted++
+		t.TotalQuizScore += int(score)
+		t.UpdatedAt = now
+	})
+	return nil
+}
+
+// Question bank
+
+type memoryBank struct{ s *MemoryStore }
+
+func versionKey(bankQuestionID string, version int) string {
+	return bankQuestionID + "/" + strconv.Itoa(version)
+}
+
+func (r memoryBank) Get(ctx context.Context, bankQuestionID string) (*models.BankQuestion, error) {
+	return r.s.bank.get(bankQuestionID)
+}
+
+func (r memoryBank) Create(ctx context.Context, question *models.BankQuestion, editorID string) error {
+	question.ID = newID()
+	question.Version = 1
+	r.s.bank.put(question.ID, *question)
+	r.s.bankVersions.put(versionKey(question.ID, 1), bankVersion(*question, editorID))
+	return nil
+}
+
+func (r memoryBank) Revise(ctx context.Context, question *models.BankQuestion, version int, editorID string) error {
+	next := *question
+	next.Version = version + 1
+	err := r.s.bank.replaceIf(question.ID, next, func(current models.BankQuestion) error {
+		if current.Version != version {
+			return ErrConflict
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	r.s.bankVersions.put(versionKey(next.ID, next.Version), bankVersion(next, editorID))
+	question.Version = next.Version
+	return nil
+}
+
+func (r memoryBank) GetVersion(ctx context.Context, bankQuestionID string, version int) (*models.BankQuestionVersion, error) {
+	return r.s.bankVersions.get(versionKey(bankQuestionID, version))
+}
+
+func (r memoryBank) ListVersions(ctx context.Context, bankQuestionID string) ([]models.BankQuestionVersion, error) {
+	versions := r.s.bankVersions.filter(func(v models.BankQuestionVersion) bool {
+		return v.BankQuestionID == bankQuestionID
+	})
+	sort.SliceStable(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+	return versions, nil
+}
+
+func (r memoryBank) List(ctx context.Context, filter BankFilter) ([]models.BankQuestion, error) {
+	questions := r.s.bank.filter(func(q models.BankQuestion) bool {
+		visible := filter.OwnerID == "" && filter.SharedDepartment == ""
+		if filter.OwnerID != "" && q.OwnerID == filter.OwnerID {
+			visible = true
+		}
+		if filter.SharedDepartment != "" && q.Shared && q.Department == filter.SharedDepartment {
+			visible = true
+		}
+		return visible && filter.matches(q)
+	})
+	sort.SliceStable(questions, func(i, j int) bool {
+		return questions[i].UpdatedAt.After(questions[j].UpdatedAt)
+	})
+	return page(questions, 0, filter.Limit), nil
+}
@@ -0,0 +1,246 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+)
+
+func TestSaveIfVersionConflict(t *testing.T) {
+	ctx := context.Background()
+	db := NewMemoryStore()
+
+	submission := &models.QuizSubmission{QuizID: "quiz", StudentID: "student", Status: "in_progress"}
+	if err := db.Submissions().Create(ctx, submission); err != nil {
+		t.Fatal(err)
+	}
+
+	// Two tabs load the attempt at the same version
+	first, _ := db.Submissions().Get(ctx, submission.ID)
+	second, _ := db.Submissions().Get(ctx, submission.ID)
+
+	first.Answers = []models.Answer{{QuestionID: "q1", SelectedOptions: []string{"A"}}}
+	if err := db.Submissions().SaveIfVersion(ctx, first, first.Version); err != nil {
+		t.Fatalf("first save: %v", err)
+	}
+	if first.Version != 1 {
+		t.Errorf("version after save = %d, want 1", first.Version)
+	}
+
+	second.Answers = []models.Answer{{QuestionID: "q1", SelectedOptions: []string{"B"}}}
+	if err := db.Submissions().SaveIfVersion(ctx, second, second.Version); err != ErrConflict {
+		t.Fatalf("stale save: got %v, want ErrConflict", err)
+	}
+
+	stored, _ := db.Submissions().Get(ctx, submission.ID)
+	if stored.Version != 1 || stored.Answers[0].SelectedOptions[0] != "A" {
+		t.Errorf("stale save overwrote the attempt: %+v", stored)
+	}
+}
+
+func TestSaveIfVersionMissing(t *testing.T) {
+	db := NewMemoryStore()
+	err := db.Submissions().SaveIfVersion(context.Background(), &models.QuizSubmission{ID: "missing"}, 0)
+	if err != ErrNotFound {
+		t.Errorf("got %v, want ErrNotFound", err)
+	}
+}
+
+func TestSaveIfVersionKeepsQuestionViews(t *testing.T) {
+	ctx := context.Background()
+	db := NewMemoryStore()
+
+	submission := &models.QuizSubmission{QuizID: "quiz", StudentID: "student", Status: "in_progress"}
+	db.Submissions().Create(ctx, submission)
+
+	// An autosave loaded the attempt before the question was viewed
+	loaded, _ := db.Submissions().Get(ctx, submission.ID)
+	viewedAt := time.Now()
+	first, err := db.Submissions().AddQuestionView(ctx, submission.ID, models.QuestionView{QuestionID: "q1", ViewedAt: viewedAt})
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, _ := db.Submissions().AddQuestionView(ctx, submission.ID, models.QuestionView{QuestionID: "q1", ViewedAt: viewedAt.Add(time.Minute)})
+	if !again.ViewedAt.Equal(first.ViewedAt) {
+		t.Errorf("second view replaced the first: %v", again.ViewedAt)
+	}
+
+	if err := db.Submissions().SaveIfVersion(ctx, loaded, 0); err != nil {
+		t.Fatalf("save after view: %v", err)
+	}
+	stored, _ := db.Submissions().Get(ctx, submission.ID)
+	if len(stored.QuestionViews) != 1 || stored.Version != 1 {
+		t.Errorf("stored %d views at version %d, want 1 at 1", len(stored.QuestionViews), stored.Version)
+	}
+}
+
+func TestQuizQuestionEditsAfterAttempt(t *testing.T) {
+	ctx := context.Background()
+	db := NewMemoryStore()
+
+	quiz := &models.Quiz{Title: "Quiz"}
+	db.Quizzes().Create(ctx, quiz)
+	question := &models.Question{QuizID: quiz.ID, Text: "Question", Points: 1, Order: 1}
+	db.Questions().Create(ctx, question)
+
+	if err := db.Quizzes().MarkAttempted(ctx, quiz.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// A later Save of a stale copy keeps the flag
+	quiz.Title = "Renamed"
+	db.Quizzes().Save(ctx, quiz)
+	if stored, _ := db.Quizzes().Get(ctx, quiz.ID); !stored.Attempted {
+		t.Fatal("Save cleared the attempted flag")
+	}
+
+	question.Text = "Reworded"
+	if err := db.Questions().UpdateQuizQuestion(ctx, question, true, false); err != nil {
+		t.Errorf("wording change: %v", err)
+	}
+	question.Points = 5
+	if err := db.Questions().UpdateQuizQuestion(ctx, question, true, true); err != ErrQuizAttempted {
+		t.Errorf("grading change: got %v, want ErrQuizAttempted", err)
+	}
+	if err := db.Questions().DeleteQuizQuestion(ctx, quiz.ID, question.ID, true); err != ErrQuizAttempted {
+		t.Errorf("delete: got %v, want ErrQuizAttempted", err)
+	}
+
+	stored, _ := db.Questions().Get(ctx, question.ID)
+	if stored.Text != "Reworded" || stored.Points != 1 {
+		t.Errorf("stored question = %+v", stored)
+	}
+}
+
+func TestInvitationRedeemIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	db := NewMemoryStore()
+	now := time.Now()
+
+	if err := db.Invitations().Save(ctx, &models.Invitation{Code: "INVITE", Role: "teacher", MaxUses: 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		redeemed int
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := db.Invitations().Redeem(ctx, "INVITE", "teacher@example.com", now)
+			if err == nil {
+				mu.Lock()
+				redeemed++
+				mu.Unlock()
+			} else if err != ErrInvitationUnusable {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if redeemed != 3 {
+		t.Errorf("redeemed %d times, want 3", redeemed)
+	}
+	invitation, _ := db.Invitations().Get(ctx, "INVITE")
+	if invitation.Uses != 3 {
+		t.Errorf("uses = %d, want 3", invitation.Uses)
+	}
+}
+
+func TestJoinCodeRedeem(t *testing.T) {
+	ctx := context.Background()
+	db := NewMemoryStore()
+	now := time.Now()
+	expired := now.Add(-time.Minute)
+
+	db.JoinCodes().Save(ctx, &models.JoinCode{Code: "OPEN", CourseID: "course", MaxUses: 1})
+	db.JoinCodes().Save(ctx, &models.JoinCode{Code: "EXPIRED", CourseID: "course", ExpiresAt: &expired})
+	db.JoinCodes().Save(ctx, &models.JoinCode{Code: "REVOKED", CourseID: "course", Revoked: true})
+
+	tests := []struct {
+		name     string
+		code     string
+		courseID string
+		want     error
+	}{
+		{"wrong course", "OPEN", "other", ErrJoinCodeUnusable},
+		{"first use", "OPEN", "course", nil},
+		{"used up", "OPEN", "course", ErrJoinCodeUnusable},
+		{"expired", "EXPIRED", "course", ErrJoinCodeUnusable},
+		{"revoked", "REVOKED", "course", ErrJoinCodeUnusable},
+		{"unknown", "NOPE", "course", ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := db.JoinCodes().Redeem(ctx, tt.code, tt.courseID, now); err != tt.want {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinRequestReviewEnrollsOnce(t *testing.T) {
+	ctx := context.Background()
+	db := NewMemoryStore()
+
+	course := &models.Course{CourseID: "course", Title: "Course"}
+	db.Courses().Save(ctx, course)
+	db.JoinRequests().Save(ctx, &models.JoinRequest{CourseID: "course", StudentID: "student", Status: "pending"})
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		approved int
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			request := &models.JoinRequest{CourseID: "course", StudentID: "student", Status: "approved"}
+			enrollment := &models.Enrollment{EnrollmentID: fmt.Sprintf("enrollment-%d", i), StudentID: "student", CourseID: "course", Status: "active"}
+			_, err := db.JoinRequests().Review(ctx, request, enrollment)
+			if err == nil {
+				mu.Lock()
+				approved++
+				mu.Unlock()
+			} else if err != ErrRequestReviewed {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	enrollments, _ := db.Enrollments().List(ctx, EnrollmentFilter{StudentID: "student", CourseID: "course"})
+	stored, _ := db.Courses().Get(ctx, "course")
+	if approved != 1 || len(enrollments) != 1 || stored.EnrollmentCount != 1 {
+		t.Errorf("approved %d times, %d enrollments, count %d; want 1 each", approved, len(enrollments), stored.EnrollmentCount)
+	}
+}
+
+func TestJoinRequestReviewAlreadyEnrolled(t *testing.T) {
+	ctx := context.Background()
+	db := NewMemoryStore()
+
+	db.Courses().Save(ctx, &models.Course{CourseID: "course"})
+	db.JoinRequests().Save(ctx, &models.JoinRequest{CourseID: "course", StudentID: "student", Status: "pending"})
+	db.Enrollments().Save(ctx, &models.Enrollment{EnrollmentID: "by-code", StudentID: "student", CourseID: "course", Status: "active"})
+
+	request := &models.JoinRequest{CourseID: "course", StudentID: "student", Status: "approved"}
+	enrolled, err := db.JoinRequests().Review(ctx, request, &models.Enrollment{EnrollmentID: "by-request", StudentID: "student", CourseID: "course"})
+	if err != nil || enrolled {
+		t.Fatalf("Review() = %v, %v; want not enrolled again", enrolled, err)
+	}
+	stored, _ := db.JoinRequests().Get(ctx, "course", "student")
+	if stored.Status != "approved" {
+		t.Errorf("request status %q, want approved", stored.Status)
+	}
+}
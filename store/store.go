@@ -0,0 +1,528 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/utils"
+)
+
+// ErrNotFound is returned when a requested document does not exist
+var ErrNotFound = errors.New("store: not found")
+
+// ErrConflict is returned when a versioned save finds a newer stored copy
+var ErrConflict = errors.New("store: version conflict")
+
+// Store groups the repositories used by the API handlers
+type Store interface {
+	Users() UserRepository
+	Courses() CourseRepository
+	Enrollments() EnrollmentRepository
+	Quizzes() QuizRepository
+	Questions() QuestionRepository
+	Submissions() SubmissionRepository
+	Exams() ExamRepository
+	ExamSubmissions() ExamSubmissionRepository
+	Assignments() AssignmentRepository
+	AssignmentSubmissions() AssignmentSubmissionRepository
+	Notifications() NotificationRepository
+	AuditLogs() AuditLogRepository
+	Analytics() AnalyticsRepository
+	QuestionBank() BankRepository
+	Accommodations() AccommodationRepository
+	Invitations() InvitationRepository
+	RoleRequests() RoleRequestRepository
+	JoinCodes() JoinCodeRepository
+	JoinRequests() JoinRequestRepository
+}
+
+// UserRepository persists user profiles keyed by Firebase UID
+type UserRepository interface {
+	Get(ctx context.Context, uid string) (*models.User, error)
+	Save(ctx context.Context, user *models.User) error
+	// Delete removes a user's document, to undo a registration that failed
+	// part way
+	Delete(ctx context.Context, uid string) error
+}
+
+// CourseFilter narrows course listings; deleted courses are never returned
+type CourseFilter struct {
+	TeacherID     string
+	PublishedOnly bool
+	Offset        int
+	Limit         int
+}
+
+// CourseRepository persists courses
+type CourseRepository interface {
+	Get(ctx context.Context, courseID string) (*models.Course, error)
+	Save(ctx context.Context, course *models.Course) error
+	// List returns courses newest first
+	List(ctx context.Context, filter CourseFilter) ([]models.Course, error)
+	AddEnrollments(ctx context.Context, courseID string, delta int) error
+}
+
+// EnrollmentFilter narrows enrollment listings
+type EnrollmentFilter struct {
+	StudentID string
+	CourseID  string
+	Status    string
+}
+
+// EnrollmentRepository persists course enrollments
+type EnrollmentRepository interface {
+	Save(ctx context.Context, enrollment *models.Enrollment) error
+	// Delete removes an enrollment, to undo a join whose code could not be redeemed
+	Delete(ctx context.Context, enrollmentID string) error
+	// List returns enrollments most recent first
+	List(ctx context.Context, filter EnrollmentFilter) ([]models.Enrollment, error)
+}
+
+// QuizFilter narrows quiz listings; deleted quizzes are never returned
+type QuizFilter struct {
+	CourseID      string
+	CourseIDs     []string
+	TeacherID     string
+	PublishedOnly bool
+	Limit         int
+}
+
+// QuizRepository persists quizzes
+type QuizRepository interface {
+	Get(ctx context.Context, quizID string) (*models.Quiz, error)
+	// Create assigns the quiz a new ID and stores it
+	Create(ctx context.Context, quiz *models.Quiz) error
+	// Save stores every field except QuestionCount, TotalMarks and Attempted,
+	// which it refreshes from the stored quiz; change those with
+	// AddQuestionStats and MarkAttempted
+	Save(ctx context.Context, quiz *models.Quiz) error
+	// List returns quizzes newest first
+	List(ctx context.Context, filter QuizFilter) ([]models.Quiz, error)
+	AddQuestionStats(ctx context.Context, quizID string, questions int, marks float64) error
+	// MarkAttempted records that a student has started the quiz
+	MarkAttempted(ctx context.Context, quizID string) error
+}
+
+// QuestionRepository persists quiz and exam questions
+type QuestionRepository interface {
+	Get(ctx context.Context, questionID string) (*models.Question, error)
+	// Create assigns the question a new ID and stores it
+	Create(ctx context.Context, question *models.Question) error
+	Save(ctx context.Context, question *models.Question) error
+	// ListByQuiz returns a quiz's questions in display order
+	ListByQuiz(ctx context.Context, quizID string) ([]models.Question, error)
+	// ListByExam returns an exam's questions in display order
+	ListByExam(ctx context.Context, examID string) ([]models.Question, error)
+	// ListByBankQuestion returns every quiz and exam copy of a bank question
+	ListByBankQuestion(ctx context.Context, bankQuestionID string) ([]models.Question, error)
+	// UpdateQuizQuestion replaces a quiz question and, when countMarks is set,
+	// moves the quiz's total marks by the change in its points, atomically.
+	// When gradingChanged is set it fails with ErrQuizAttempted once the quiz
+	// has been attempted.
+	UpdateQuizQuestion(ctx context.Context, question *models.Question, countMarks, gradingChanged bool) error
+	// DeleteQuizQuestion removes a quiz question, renumbers the rest and
+	// updates the quiz's question count (and total marks when countMarks is
+	// set), atomically. It fails with ErrQuizAttempted once the quiz has been
+	// attempted.
+	DeleteQuizQuestion(ctx context.Context, quizID, questionID string, countMarks bool) error
+	// ReorderQuizQuestions sets each question's Order from its position in
+	// questionIDs, which must list every question of the quiz exactly once
+	ReorderQuizQuestions(ctx context.Context, quizID string, questionIDs []string) error
+}
+
+// ErrQuizAttempted is returned when a question edit would change grading on a
+// quiz students have already started
+var ErrQuizAttempted = errors.New("store: quiz has been attempted")
+
+// ErrQuestionSet is returned when a reorder does not list exactly the quiz's questions
+var ErrQuestionSet = errors.New("store: question list does not match the quiz")
+
+// reorderedQuestions checks that questionIDs is a permutation of the quiz's
+// questions and returns the new Order for each
+func reorderedQuestions(questions []models.Question, questionIDs []string) (map[string]int, error) {
+	if len(questionIDs) != len(questions) {
+		return nil, ErrQuestionSet
+	}
+	known := make(map[string]bool, len(questions))
+	for _, q := range questions {
+		known[q.ID] = true
+	}
+	orders := make(map[string]int, len(questionIDs))
+	for i, id := range questionIDs {
+		if !known[id] {
+			return nil, ErrQuestionSet
+		}
+		if _, dup := orders[id]; dup {
+			return nil, ErrQuestionSet
+		}
+		orders[id] = i + 1
+	}
+	return orders, nil
+}
+
+// SubmissionFilter narrows quiz submission listings
+type SubmissionFilter struct {
+	QuizID    string
+	StudentID string
+	Statuses  []string
+}
+
+// SubmissionRepository persists quiz attempts
+type SubmissionRepository interface {
+	Get(ctx context.Context, submissionID string) (*models.QuizSubmission, error)
+	// Create assigns the submission a new ID and stores it
+	Create(ctx context.Context, submission *models.QuizSubmission) error
+	Save(ctx context.Context, submission *models.QuizSubmission) error
+	// SaveIfVersion stores the submission only if the stored copy is still at
+	// version, then advances submission.Version; otherwise it returns
+	// ErrConflict. Question views are kept from the stored copy.
+	SaveIfVersion(ctx context.Context, submission *models.QuizSubmission, version int) error
+	// AddQuestionView records the first view of a question and returns it,
+	// or the earlier view if there is one. The Version is left unchanged.
+	AddQuestionView(ctx context.Context, submissionID string, view models.QuestionView) (*models.QuestionView, error)
+	// List returns submissions most recently submitted first
+	List(ctx context.Context, filter SubmissionFilter) ([]models.QuizSubmission, error)
+}
+
+// addQuestionView appends view unless its question was already viewed and
+// returns the view that is stored
+func addQuestionView(submission *models.QuizSubmission, view models.QuestionView) models.QuestionView {
+	for _, existing := range submission.QuestionViews {
+		if existing.QuestionID == view.QuestionID {
+			return existing
+		}
+	}
+	submission.QuestionViews = append(submission.QuestionViews, view)
+	return view
+}
+
+// ExamFilter narrows exam listings; deleted exams are never returned
+type ExamFilter struct {
+	CourseID      string
+	CourseIDs     []string
+	TeacherID     string
+	ExamType      string
+	PublishedOnly bool
+	Limit         int
+}
+
+// ExamRepository persists exams
+type ExamRepository interface {
+	Get(ctx context.Context, examID string) (*models.Exam, error)
+	// Create assigns the exam a new ID and stores it
+	Create(ctx context.Context, exam *models.Exam) error
+	Save(ctx context.Context, exam *models.Exam) error
+	// List returns exams earliest start first
+	List(ctx context.Context, filter ExamFilter) ([]models.Exam, error)
+	AddQuestions(ctx context.Context, examID string, delta int) error
+}
+
+// ExamSubmissionFilter narrows exam submission listings
+type ExamSubmissionFilter struct {
+	ExamID    string
+	StudentID string
+	Statuses  []string
+}
+
+// ExamSubmissionRepository persists exam attempts
+type ExamSubmissionRepository interface {
+	Get(ctx context.Context, submissionID string) (*models.ExamSubmission, error)
+	// Create assigns the submission a new ID and stores it
+	Create(ctx context.Context, submission *models.ExamSubmission) error
+	Save(ctx context.Context, submission *models.ExamSubmission) error
+	// SaveIfVersion stores the submission only if the stored copy is still at
+	// version, then advances submission.Version; otherwise it returns
+	// ErrConflict
+	SaveIfVersion(ctx context.Context, submission *models.ExamSubmission, version int) error
+	// List returns submissions most recently submitted first
+	List(ctx context.Context, filter ExamSubmissionFilter) ([]models.ExamSubmission, error)
+}
+
+// AssignmentFilter narrows assignment listings; deleted assignments are never returned
+type AssignmentFilter struct {
+	CourseID      string
+	CourseIDs     []string
+	TeacherID     string
+	PublishedOnly bool
+	Limit         int
+}
+
+// AssignmentRepository persists assignments
+type AssignmentRepository interface {
+	Get(ctx context.Context, assignmentID string) (*models.Assignment, error)
+	// Create assigns the assignment a new ID and stores it
+	Create(ctx context.Context, assignment *models.Assignment) error
+	Save(ctx context.Context, assignment *models.Assignment) error
+	// List returns assignments nearest due date first
+	List(ctx context.Context, filter AssignmentFilter) ([]models.Assignment, error)
+}
+
+// AssignmentSubmissionFilter narrows assignment submission listings
+type AssignmentSubmissionFilter struct {
+	AssignmentID string
+	StudentID    string
+	Statuses     []string
+}
+
+// AssignmentSubmissionRepository persists assignment submissions
+type AssignmentSubmissionRepository interface {
+	Get(ctx context.Context, submissionID string) (*models.AssignmentSubmission, error)
+	// Create assigns the submission a new ID and stores it
+	Create(ctx context.Context, submission *models.AssignmentSubmission) error
+	Save(ctx context.Context, submission *models.AssignmentSubmission) error
+	// List returns submissions most recently submitted first
+	List(ctx context.Context, filter AssignmentSubmissionFilter) ([]models.AssignmentSubmission, error)
+}
+
+// NotificationRepository persists user notifications
+type NotificationRepository interface {
+	// Create assigns the notification a new ID and stores it
+	Create(ctx context.Context, notification *models.Notification) error
+}
+
+// AuditLogRepository persists audit trail entries
+type AuditLogRepository interface {
+	// Create assigns the entry a new ID and stores it
+	Create(ctx context.Context, entry *models.AuditLog) error
+}
+
+// AccommodationID is the document ID of a student's accommodation in a
+// course, or their global one when courseID is empty. There is at most one
+// of each.
+func AccommodationID(studentID, courseID string) string {
+	if courseID == "" {
+		courseID = "global"
+	}
+	return studentID + "_" + courseID
+}
+
+// StudentAccommodation returns the accommodation that applies to a student in
+// a course: their course-scoped one, else their global one, else nil
+func StudentAccommodation(ctx context.Context, db Store, studentID, courseID string) (*models.Accommodation, error) {
+	for _, scope := range []string{courseID, ""} {
+		accommodation, err := db.Accommodations().Get(ctx, AccommodationID(studentID, scope))
+		if err == nil {
+			return accommodation, nil
+		}
+		if err != ErrNotFound {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// AccommodationRepository persists student accommodations keyed by AccommodationID
+type AccommodationRepository interface {
+	Get(ctx context.Context, accommodationID string) (*models.Accommodation, error)
+	Save(ctx context.Context, accommodation *models.Accommodation) error
+	Delete(ctx context.Context, accommodationID string) error
+	// List returns a student's accommodations, or every one when studentID is empty
+	List(ctx context.Context, studentID string) ([]models.Accommodation, error)
+}
+
+// ErrInvitationUnusable is returned when an invitation is revoked, expired,
+// used up or meant for another email address
+var ErrInvitationUnusable = errors.New("store: invitation cannot be used")
+
+// InvitationUsable reports whether email may register with the invitation at now
+func InvitationUsable(invitation models.Invitation, email string, now time.Time) bool {
+	switch {
+	case invitation.Revoked:
+		return false
+	case invitation.ExpiresAt != nil && now.After(*invitation.ExpiresAt):
+		return false
+	case invitation.MaxUses > 0 && invitation.Uses >= invitation.MaxUses:
+		return false
+	case invitation.Email != "" && !strings.EqualFold(invitation.Email, email):
+		return false
+	}
+	return true
+}
+
+// InvitationRepository persists registration invitations keyed by code
+type InvitationRepository interface {
+	Get(ctx context.Context, code string) (*models.Invitation, error)
+	Save(ctx context.Context, invitation *models.Invitation) error
+	// List returns every invitation, newest first
+	List(ctx context.Context) ([]models.Invitation, error)
+	// Redeem atomically counts one use of the invitation by email, failing
+	// with ErrInvitationUnusable when InvitationUsable does not hold
+	Redeem(ctx context.Context, code, email string, now time.Time) (*models.Invitation, error)
+}
+
+// RoleRequestRepository persists role requests keyed by user ID
+type RoleRequestRepository interface {
+	Get(ctx context.Context, userID string) (*models.RoleRequest, error)
+	Save(ctx context.Context, request *models.RoleRequest) error
+	// List returns requests with the given status, or every one when status
+	// is empty, oldest first
+	List(ctx context.Context, status string) ([]models.RoleRequest, error)
+}
+
+// ErrJoinCodeUnusable is returned when a join code is revoked, expired, used
+// up or belongs to another course
+var ErrJoinCodeUnusable = errors.New("store: join code cannot be used")
+
+// JoinCodeUsable reports whether the code admits a student to courseID at now
+func JoinCodeUsable(code models.JoinCode, courseID string, now time.Time) bool {
+	switch {
+	case code.Revoked:
+		return false
+	case code.CourseID != courseID:
+		return false
+	case code.ExpiresAt != nil && now.After(*code.ExpiresAt):
+		return false
+	case code.MaxUses > 0 && code.Uses >= code.MaxUses:
+		return false
+	}
+	return true
+}
+
+// JoinCodeRepository persists course join codes keyed by code
+type JoinCodeRepository interface {
+	Get(ctx context.Context, code string) (*models.JoinCode, error)
+	Save(ctx context.Context, code *models.JoinCode) error
+	// List returns a course's join codes, newest first
+	List(ctx context.Context, courseID string) ([]models.JoinCode, error)
+	// Redeem atomically counts one use of the code for courseID, failing
+	// with ErrJoinCodeUnusable when JoinCodeUsable does not hold
+	Redeem(ctx context.Context, code, courseID string, now time.Time) (*models.JoinCode, error)
+}
+
+// JoinRequestFilter narrows join request listings; empty fields match all
+type JoinRequestFilter struct {
+	CourseID  string
+	StudentID string
+	Status    string
+}
+
+// ErrRequestReviewed is returned when a join request is no longer pending
+var ErrRequestReviewed = errors.New("store: join request already reviewed")
+
+// JoinRequestRepository persists course join requests, one per course and
+// student
+type JoinRequestRepository interface {
+	Get(ctx context.Context, courseID, studentID string) (*models.JoinRequest, error)
+	Save(ctx context.Context, request *models.JoinRequest) error
+	// List returns matching requests oldest first
+	List(ctx context.Context, filter JoinRequestFilter) ([]models.JoinRequest, error)
+	// Review atomically saves a reviewed request, failing with
+	// ErrRequestReviewed when the stored one is no longer pending. For an
+	// approval, enrollment is created and the course's enrollment count
+	// raised unless the student is already enrolled; enrolled reports which.
+	Review(ctx context.Context, request *models.JoinRequest, enrollment *models.Enrollment) (enrolled bool, err error)
+}
+
+// joinRequestID is the document ID of a student's request to join a course
+func joinRequestID(courseID, studentID string) string {
+	return courseID + "_" + studentID
+}
+
+// AnalyticsRepository maintains per-student running totals
+type AnalyticsRepository interface {
+	RecordQuizCompletion(ctx context.Context, studentID string, score float64) error
+}
+
+// BankFilter narrows question bank searches; deleted questions are never
+// returned. With neither OwnerID nor SharedDepartment set, every question matches.
+type BankFilter struct {
+	OwnerID          string // questions owned by this teacher
+	SharedDepartment string // plus questions shared with this department
+	Topic            string
+	Difficulty       string
+	LearningOutcome  string
+	Type             string
+	Text             string // case-insensitive substring of the question text
+	Limit            int
+}
+
+// matches applies the tag and text filters that are evaluated in memory
+func (f BankFilter) matches(q models.BankQuestion) bool {
+	if q.IsDeleted {
+		return false
+	}
+	if f.Topic != "" && !containsFold(q.Topics, f.Topic) {
+		return false
+	}
+	if f.LearningOutcome != "" && !containsFold(q.LearningOutcomes, f.LearningOutcome) {
+		return false
+	}
+	if f.Difficulty != "" && !strings.EqualFold(q.Difficulty, f.Difficulty) {
+		return false
+	}
+	if f.Type != "" && q.Type != f.Type {
+		return false
+	}
+	if f.Text != "" && !strings.Contains(strings.ToLower(q.Text), strings.ToLower(f.Text)) {
+		return false
+	}
+	return true
+}
+
+func containsFold(values []string, v string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// BankRepository persists question bank entries and their version history
+type BankRepository interface {
+	Get(ctx context.Context, bankQuestionID string) (*models.BankQuestion, error)
+	// Create assigns the question a new ID and stores it as version 1
+	Create(ctx context.Context, question *models.BankQuestion, editorID string) error
+	// Revise stores question as the next version if the stored copy is still
+	// at version; otherwise it returns ErrConflict
+	Revise(ctx context.Context, question *models.BankQuestion, version int, editorID string) error
+	GetVersion(ctx context.Context, bankQuestionID string, version int) (*models.BankQuestionVersion, error)
+	// ListVersions returns a question's history oldest first
+	ListVersions(ctx context.Context, bankQuestionID string) ([]models.BankQuestionVersion, error)
+	// List returns matching questions most recently updated first
+	List(ctx context.Context, filter BankFilter) ([]models.BankQuestion, error)
+}
+
+// bankVersion snapshots question as the history entry for its current version
+func bankVersion(question models.BankQuestion, editorID string) models.BankQuestionVersion {
+	return models.BankQuestionVersion{
+		BankQuestionID: question.ID,
+		Version:        question.Version,
+		Question:       question,
+		EditedBy:       editorID,
+		CreatedAt:      question.UpdatedAt,
+	}
+}
+
+var (
+	defaultStore Store
+	storeMu      sync.Mutex
+)
+
+// SetStore overrides the store returned by GetStore (e.g. an in-memory store)
+func SetStore(s Store) {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+	defaultStore = s
+}
+
+// GetStore returns the configured store, defaulting to Firestore
+func GetStore(ctx context.Context) (Store, error) {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+
+	if defaultStore != nil {
+		return defaultStore, nil
+	}
+
+	client, err := utils.GetFirestoreClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defaultStore = NewFirestoreStore(client)
+	return defaultStore, nil
+}
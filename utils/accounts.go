@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"firebase.google.com/go/v4/auth"
+)
+
+// AccountBatchSize is the most accounts looked up or imported in one call
+const AccountBatchSize = 100
+
+// Account is a sign-in account to create with its role claim
+type Account struct {
+	UID         string
+	Email       string
+	DisplayName string
+	Role        string
+}
+
+// AccountImporter creates sign-in accounts in bulk
+type AccountImporter interface {
+	// ExistingEmails reports which of up to AccountBatchSize emails already
+	// have an account, keyed by lower-cased email
+	ExistingEmails(ctx context.Context, emails []string) (map[string]bool, error)
+	// ImportAccounts creates up to AccountBatchSize accounts. The returned
+	// slice holds each account's error, or nil, in input order.
+	ImportAccounts(ctx context.Context, accounts []Account) ([]error, error)
+	// DeleteAccount removes an account, e.g. after its profile failed to save
+	DeleteAccount(ctx context.Context, uid string) error
+}
+
+// FirebaseImporter imports accounts into Firebase Auth. Accounts are created
+// without a password; users set one through a password reset email.
+type FirebaseImporter struct{}
+
+// ExistingEmails implements AccountImporter
+func (FirebaseImporter) ExistingEmails(ctx context.Context, emails []string) (map[string]bool, error) {
+	authClient, err := GetAuthClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	identifiers := make([]auth.UserIdentifier, len(emails))
+	for i, email := range emails {
+		identifiers[i] = auth.EmailIdentifier{Email: email}
+	}
+	result, err := authClient.GetUsers(ctx, identifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool, len(result.Users))
+	for _, u := range result.Users {
+		existing[strings.ToLower(u.Email)] = true
+	}
+	return existing, nil
+}
+
+// ImportAccounts implements AccountImporter
+func (FirebaseImporter) ImportAccounts(ctx context.Context, accounts []Account) ([]error, error) {
+	authClient, err := GetAuthClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*auth.UserToImport, len(accounts))
+	for i, a := range accounts {
+		users[i] = (&auth.UserToImport{}).
+			UID(a.UID).
+			Email(a.Email).
+			DisplayName(a.DisplayName).
+			EmailVerified(false).
+			Disabled(false).
+			CustomClaims(map[string]interface{}{"role": a.Role})
+	}
+
+	result, err := authClient.ImportUsers(ctx, users)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make([]error, len(accounts))
+	for _, e := range result.Errors {
+		if e.Index >= 0 && e.Index < len(errs) {
+			errs[e.Index] = &FirebaseError{Message: e.Reason}
+		}
+	}
+	return errs, nil
+}
+
+// DeleteAccount implements AccountImporter
+func (FirebaseImporter) DeleteAccount(ctx context.Context, uid string) error {
+	authClient, err := GetAuthClient(ctx)
+	if err != nil {
+		return err
+	}
+	return authClient.DeleteUser(ctx, uid)
+}
+
+var (
+	accountImporter AccountImporter
+	importerMu      sync.Mutex
+)
+
+// SetAccountImporter overrides the importer used by bulk provisioning
+func SetAccountImporter(i AccountImporter) {
+	importerMu.Lock()
+	defer importerMu.Unlock()
+	accountImporter = i
+}
+
+// GetAccountImporter returns the configured importer, Firebase by default
+func GetAccountImporter() AccountImporter {
+	importerMu.Lock()
+	defer importerMu.Unlock()
+
+	if accountImporter == nil {
+		accountImporter = FirebaseImporter{}
+	}
+	return accountImporter
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+)
+
+// DefaultGracePeriod absorbs network latency between the client timer and
+// the server; QUIZ_GRACE_PERIOD (e.g. "30s") overrides it
+const DefaultGracePeriod = 2 * time.Minute
+
+// GracePeriod returns how long after a quiz or exam deadline answers are
+// still accepted
+func GracePeriod() time.Duration {
+	v := os.Getenv("QUIZ_GRACE_PERIOD")
+	if v == "" {
+		return DefaultGracePeriod
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		log.Printf("ERROR: Invalid QUIZ_GRACE_PERIOD %q, using %s", v, DefaultGracePeriod)
+		return DefaultGracePeriod
+	}
+	return d
+}
+
+// TimeMultiplier returns how much longer a student's quiz and exam timers run
+// under their accommodation; 1 when there is none
+func TimeMultiplier(accommodation *models.Accommodation) float64 {
+	if accommodation == nil || accommodation.TimeMultiplier <= 1 {
+		return 1
+	}
+	return accommodation.TimeMultiplier
+}
+
+// ScaleDuration stretches a timer by an attempt's accommodation multiplier
+func ScaleDuration(d time.Duration, multiplier float64) time.Duration {
+	if multiplier <= 1 {
+		return d
+	}
+	return time.Duration(float64(d) * multiplier)
+}
+
+// AccommodatedWindow returns when an assessment scheduled from start to end
+// opens and closes for a student, using their accommodation's alternative
+// window where it sets one
+func AccommodatedWindow(start, end time.Time, accommodation *models.Accommodation) (time.Time, time.Time) {
+	if accommodation == nil {
+		return start, end
+	}
+	if accommodation.StartDate != nil {
+		start = *accommodation.StartDate
+	}
+	if accommodation.EndDate != nil {
+		end = *accommodation.EndDate
+	}
+	return start, end
+}
+
+// IsObjectiveQuestion reports whether a question type can be auto-graded from its options
+func IsObjectiveQuestion(questionType string) bool {
+	return questionType == "mcq" || questionType == "true_false"
+}
+
+// StudentQuestions strips answer keys from questions before they are sent to
+// a student taking a quiz or exam
+func StudentQuestions(questions []models.Question) []models.Question {
+	stripped := make([]models.Question, len(questions))
+	for i, q := range questions {
+		clientQ := q
+		clientQ.CorrectAnswer = ""
+		clientQ.AcceptedAnswers = nil
+		clientQ.Explanation = ""
+		// Remove correct answer info for auto-graded questions
+		if IsObjectiveQuestion(q.Type) {
+			clientOptions := make([]models.QuestionOption, len(q.Options))
+			for j, opt := range q.Options {
+				clientOptions[j] = models.QuestionOption{
+					ID:   opt.ID,
+					Text: opt.Text,
+					// IsCorrect field is intentionally omitted
+				}
+			}
+			clientQ.Options = clientOptions
+		}
+		stripped[i] = clientQ
+	}
+	return stripped
+}
@@ -2,11 +2,14 @@ package utils
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
 	"net/http"
+	"os"
 	"strings"
 )
 
-// AuthMiddleware validates Firebase token and extracts user claims
+// AuthMiddleware validates the bearer token and extracts user claims
 func AuthMiddleware(next http.HandlerFunc, requiredRole ...string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -27,14 +30,12 @@ func AuthMiddleware(next http.HandlerFunc, requiredRole ...string) http.HandlerF
 
 		token := parts[1]
 
-		// Verify token with Firebase
-		authClient, err := GetAuthClient(ctx)
-		if err != nil {
+		// Verify token with the configured verifier (Firebase by default)
+		decodedToken, err := GetTokenVerifier().VerifyToken(ctx, token)
+		if errors.Is(err, ErrVerifierUnavailable) {
 			RespondError(w, http.StatusInternalServerError, "Failed to initialize auth client")
 			return
 		}
-
-		decodedToken, err := authClient.VerifyIDToken(ctx, token)
 		if err != nil {
 			RespondError(w, http.StatusUnauthorized, "Invalid or expired token")
 			return
@@ -89,3 +90,18 @@ func GetUserFromContext(ctx context.Context) (uid, email, role string) {
 	}
 	return
 }
+
+// CronOrAdmin lets a cron scheduler call next with CRON_SECRET as a bearer
+// token; any other caller must be an admin
+func CronOrAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if secret := os.Getenv("CRON_SECRET"); secret != "" {
+			expected := "Bearer " + secret
+			if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(expected)) == 1 {
+				next(w, r)
+				return
+			}
+		}
+		AuthMiddleware(next, "admin")(w, r)
+	}
+}
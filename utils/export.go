@@ -0,0 +1,249 @@
+package utils
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Export formats accepted by the export endpoints
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// Sheet is a table of cells for a CSV or XLSX export. Cells may be strings,
+// numbers, *float64 (nil for a blank cell), bools or times.
+type Sheet struct {
+	Name   string
+	Header []string
+	Rows   [][]interface{}
+}
+
+// ExportFormat reads the format query parameter, defaulting to CSV
+func ExportFormat(r *http.Request) (string, bool) {
+	switch format := strings.ToLower(r.URL.Query().Get("format")); format {
+	case "", FormatCSV:
+		return FormatCSV, true
+	case FormatXLSX:
+		return FormatXLSX, true
+	default:
+		return format, false
+	}
+}
+
+// SafeCell stops spreadsheet programs from reading text as a formula by
+// prefixing a quote to values that start with a formula character
+func SafeCell(s string) string {
+	if s == "" {
+		return s
+	}
+	switch s[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + s
+	}
+	return s
+}
+
+// RespondSheet sends the sheet as a file download in the given format
+func RespondSheet(w http.ResponseWriter, format, filename string, sheet Sheet) {
+	contentType := "text/csv; charset=utf-8"
+	if format == FormatXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+"."+format))
+	w.WriteHeader(http.StatusOK)
+
+	if format == FormatXLSX {
+		WriteXLSX(w, sheet)
+		return
+	}
+	WriteCSV(w, sheet)
+}
+
+// WriteCSV writes the sheet as CSV with text cells made formula-safe
+func WriteCSV(w io.Writer, sheet Sheet) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(safeRow(sheet.Header)); err != nil {
+		return err
+	}
+	for _, row := range sheet.Rows {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			value, numeric := cellValue(cell)
+			if !numeric {
+				value = SafeCell(value)
+			}
+			record[i] = value
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteXLSX writes the sheet as a single-worksheet XLSX workbook. Text is
+// stored as inline strings, so it is never evaluated, and is made
+// formula-safe as well in case the file is later saved as CSV.
+func WriteXLSX(w io.Writer, sheet Sheet) error {
+	name := sheet.Name
+	if name == "" {
+		name = "Sheet1"
+	}
+
+	files := []struct {
+		path string
+		body string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, xmlEscape(sheetName(name)))},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/worksheets/sheet1.xml", worksheetXML(sheet)},
+	}
+
+	archive := zip.NewWriter(w)
+	for _, f := range files {
+		part, err := archive.Create(f.path)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(part, f.body); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// worksheetXML renders the header and rows as sheet data
+func worksheetXML(sheet Sheet) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	header := make([]interface{}, len(sheet.Header))
+	for i, h := range sheet.Header {
+		header[i] = h
+	}
+	writeXLSXRow(&b, 1, header)
+	for i, row := range sheet.Rows {
+		writeXLSXRow(&b, i+2, row)
+	}
+
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+func writeXLSXRow(b *strings.Builder, number int, cells []interface{}) {
+	fmt.Fprintf(b, `<row r="%d">`, number)
+	for i, cell := range cells {
+		ref := columnName(i) + strconv.Itoa(number)
+		value, numeric := cellValue(cell)
+		switch {
+		case value == "":
+			continue
+		case numeric:
+			fmt.Fprintf(b, `<c r="%s"><v>%s</v></c>`, ref, value)
+		default:
+			fmt.Fprintf(b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, xmlEscape(SafeCell(value)))
+		}
+	}
+	b.WriteString(`</row>`)
+}
+
+// cellValue formats a cell and reports whether it is a number
+func cellValue(cell interface{}) (string, bool) {
+	switch v := cell.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, false
+	case int:
+		return strconv.Itoa(v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case *float64:
+		if v == nil {
+			return "", false
+		}
+		return strconv.FormatFloat(*v, 'f', -1, 64), true
+	case bool:
+		if v {
+			return "yes", false
+		}
+		return "no", false
+	case time.Time:
+		if v.IsZero() {
+			return "", false
+		}
+		return v.UTC().Format(time.RFC3339), false
+	default:
+		return fmt.Sprint(v), false
+	}
+}
+
+func safeRow(values []string) []string {
+	row := make([]string, len(values))
+	for i, v := range values {
+		row[i] = SafeCell(v)
+	}
+	return row
+}
+
+// columnName converts a zero-based column index to A, B, ..., Z, AA, ...
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// sheetName trims a worksheet name to the characters and length Excel allows
+func sheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '-'
+		}
+		return r
+	}, name)
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	return name
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+const xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`</Types>`
+
+const xlsxRootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbook = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>` +
+	`</workbook>`
+
+const xlsxWorkbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`</Relationships>`
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+)
+
+var formulaCells = []struct {
+	name string
+	in   string
+	want string
+}{
+	{"equals", "=SUM(A1:A2)", "'=SUM(A1:A2)"},
+	{"plus", "+1+1", "'+1+1"},
+	{"minus", "-2+3", "'-2+3"},
+	{"at", "@SUM(A1)", "'@SUM(A1)"},
+	{"tab", "\t=1", "'\t=1"},
+	{"carriage return", "\r=1", "'\r=1"},
+	{"plain text", "Ada Lovelace", "Ada Lovelace"},
+	{"formula character later", "a=b", "a=b"},
+	{"empty", "", ""},
+}
+
+func TestSafeCell(t *testing.T) {
+	for _, tt := range formulaCells {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SafeCell(tt.in); got != tt.want {
+				t.Errorf("SafeCell(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteCSVFormulaSafe(t *testing.T) {
+	for _, tt := range formulaCells {
+		t.Run(tt.name, func(t *testing.T) {
+			negative := -5.5
+			sheet := Sheet{
+				Header: []string{tt.in, "Score", "Marks", "Count"},
+				Rows:   [][]interface{}{{tt.in, -3.0, &negative, -2}},
+			}
+
+			var buf bytes.Buffer
+			if err := WriteCSV(&buf, sheet); err != nil {
+				t.Fatal(err)
+			}
+			records, err := csv.NewReader(&buf).ReadAll()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if records[0][0] != tt.want || records[1][0] != tt.want {
+				t.Errorf("text cells = %q, %q; want %q", records[0][0], records[1][0], tt.want)
+			}
+			// Negative numbers start with '-' but are never prefixed
+			if got := records[1][1:]; got[0] != "-3" || got[1] != "-5.5" || got[2] != "-2" {
+				t.Errorf("numeric cells = %q, want unprefixed", got)
+			}
+		})
+	}
+}
+
+func TestWriteXLSXFormulaSafe(t *testing.T) {
+	for _, tt := range formulaCells {
+		if tt.in == "" {
+			continue // blank cells are left out of the worksheet
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			sheet := Sheet{
+				Header: []string{"Name", "Score"},
+				Rows:   [][]interface{}{{tt.in, -3.0}},
+			}
+
+			worksheet := xlsxWorksheet(t, sheet)
+			wantText := `<c r="A2" t="inlineStr"><is><t xml:space="preserve">` + xmlEscape(tt.want) + `</t></is></c>`
+			if !strings.Contains(worksheet, wantText) {
+				t.Errorf("worksheet %s does not contain %s", worksheet, wantText)
+			}
+			if !strings.Contains(worksheet, `<c r="B2"><v>-3</v></c>`) {
+				t.Errorf("worksheet %s does not store -3 as a plain number", worksheet)
+			}
+		})
+	}
+}
+
+// xlsxWorksheet writes sheet as XLSX and returns its worksheet XML
+func xlsxWorksheet(t *testing.T, sheet Sheet) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, sheet); err != nil {
+		t.Fatal(err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range archive.File {
+		if f.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		part, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer part.Close()
+		body, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(body)
+	}
+	t.Fatal("workbook has no worksheet")
+	return ""
+}
@@ -1,7 +1,10 @@
 package utils
 
 import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
 	"encoding/json"
+	"hash/fnv"
 	"math/rand"
 	"net/http"
 	"time"
@@ -92,18 +95,59 @@ func parseInt(s string) (int, error) {
 	return i, err
 }
 
-// ShuffleQuestions randomizes question order for quiz
-func ShuffleQuestions(questions *[]models.Question) {
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(len(*questions), func(i, j int) {
+// NewShuffleSeed returns a fresh seed for shuffling an attempt. Store it with
+// the attempt so the same order can be produced again later.
+func NewShuffleSeed() int64 {
+	var buf [8]byte
+	if _, err := cryptorand.Read(buf[:]); err != nil {
+		return time.Now().UnixNano()
+	}
+	return int64(binary.LittleEndian.Uint64(buf[:]))
+}
+
+// DeriveSeed derives an independent seed from an attempt seed and a key, so
+// that e.g. each question's options shuffle the same way regardless of where
+// the question lands in the shuffled order
+func DeriveSeed(seed int64, key string) int64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(seed))
+	h.Write(buf[:])
+	h.Write([]byte(key))
+	return int64(h.Sum64())
+}
+
+// ShuffleQuestions randomizes question order for quiz. The same seed and
+// input order always give the same result. Safe for concurrent use.
+func ShuffleQuestions(questions *[]models.Question, seed int64) {
+	rng := rand.New(rand.NewSource(seed))
+	rng.Shuffle(len(*questions), func(i, j int) {
 		(*questions)[i], (*questions)[j] = (*questions)[j], (*questions)[i]
 	})
 }
 
-// ShuffleOptions randomizes option order for a question
-func ShuffleOptions(options *[]models.QuestionOption) {
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(len(*options), func(i, j int) {
+// ShuffleOptions randomizes option order for a question. The same seed and
+// input order always give the same result. Safe for concurrent use.
+func ShuffleOptions(options *[]models.QuestionOption, seed int64) {
+	rng := rand.New(rand.NewSource(seed))
+	rng.Shuffle(len(*options), func(i, j int) {
 		(*options)[i], (*options)[j] = (*options)[j], (*options)[i]
 	})
 }
+
+// codeAlphabet leaves out characters that are easy to misread
+const codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// NewCode returns a random code of n characters for people to type in,
+// such as an invitation code. Codes grant access, so it fails rather than
+// fall back to a guessable source when the system's random source does.
+func NewCode(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := cryptorand.Read(buf); err != nil {
+		return "", err
+	}
+	for i, b := range buf {
+		buf[i] = codeAlphabet[int(b)%len(codeAlphabet)]
+	}
+	return string(buf), nil
+}
@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Ravikiran27/GOLANG_SmartEdu-LMS/models"
+)
+
+var validDifficulties = map[string]bool{"easy": true, "medium": true, "hard": true}
+
+// ValidDifficulty reports whether difficulty is empty or a known level
+func ValidDifficulty(difficulty string) bool {
+	return difficulty == "" || validDifficulties[difficulty]
+}
+
+// ValidScoringPolicy reports whether policy is empty (default) or a known policy
+func ValidScoringPolicy(policy string) bool {
+	switch policy {
+	case "", models.ScoringAllOrNothing, models.ScoringProportional, models.ScoringRightMinusWrong:
+		return true
+	}
+	return false
+}
+
+// ValidateAcceptedAnswers checks rules before they are stored on a question
+func ValidateAcceptedAnswers(rules []models.AcceptedAnswer) error {
+	for i, rule := range rules {
+		if rule.Value == "" {
+			return fmt.Errorf("accepted answer %d has no value", i+1)
+		}
+
+		switch rule.Match {
+		case models.MatchCaseInsensitive, models.MatchNormalized:
+		case models.MatchNumeric:
+			if _, err := strconv.ParseFloat(rule.Value, 64); err != nil {
+				return fmt.Errorf("accepted answer %d is not a number", i+1)
+			}
+			if rule.Tolerance < 0 {
+				return fmt.Errorf("accepted answer %d has a negative tolerance", i+1)
+			}
+		case models.MatchRegex:
+			if _, err := regexp.Compile(rule.Value); err != nil {
+				return fmt.Errorf("accepted answer %d is not a valid regular expression", i+1)
+			}
+		default:
+			return fmt.Errorf("accepted answer %d has an invalid match type", i+1)
+		}
+	}
+	return nil
+}
+
+// ValidateQuestion checks a quiz or bank question before it is stored; types
+// lists the question types the caller accepts. The error message is suitable
+// for returning to the client.
+func ValidateQuestion(question models.Question, types map[string]bool) error {
+	if strings.TrimSpace(question.Text) == "" {
+		return errors.New("Question text is required")
+	}
+	if question.Type == "" {
+		return errors.New("Question type is required")
+	}
+	if !types[question.Type] {
+		return errors.New("Invalid question type")
+	}
+	if question.Points <= 0 {
+		return errors.New("Points must be greater than 0")
+	}
+	if !ValidDifficulty(question.Difficulty) {
+		return errors.New("Invalid difficulty. Must be easy, medium, or hard")
+	}
+
+	// Scoring policy is only meaningful for multiple-select MCQs
+	if !ValidScoringPolicy(question.ScoringPolicy) {
+		return errors.New("Invalid scoring policy. Must be all_or_nothing, proportional, or right_minus_wrong")
+	}
+
+	// Short answer auto-grading rules
+	if question.Type == "short_answer" {
+		if err := ValidateAcceptedAnswers(question.AcceptedAnswers); err != nil {
+			return errors.New("Invalid accepted answers: " + err.Error())
+		}
+	}
+
+	// MCQ and True/False need options with at least one correct
+	if IsObjectiveQuestion(question.Type) {
+		if len(question.Options) == 0 {
+			return errors.New("Options are required for MCQ and True/False questions")
+		}
+		hasCorrect := false
+		for _, opt := range question.Options {
+			if opt.IsCorrect {
+				hasCorrect = true
+				break
+			}
+		}
+		if !hasCorrect {
+			return errors.New("At least one option must be marked as correct")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"os"
+	"strings"
+)
+
+// AllowedEmailDomains returns the domains accepted for self-signup, read
+// from the comma-separated ALLOWED_EMAIL_DOMAINS. Empty means any domain.
+func AllowedEmailDomains() []string {
+	var domains []string
+	for _, d := range strings.Split(os.Getenv("ALLOWED_EMAIL_DOMAINS"), ",") {
+		d = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@"))
+		if d != "" {
+			domains = append(domains, d)
+		}
+	}
+	return domains
+}
+
+// EmailDomainAllowed reports whether self-signup accepts the address. A
+// listed domain also accepts its subdomains.
+func EmailDomainAllowed(email string) bool {
+	domains := AllowedEmailDomains()
+	if len(domains) == 0 {
+		return true
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	host := strings.ToLower(email[at+1:])
+	for _, d := range domains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
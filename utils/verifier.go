@@ -0,0 +1,178 @@
+package utils
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// ErrVerifierUnavailable means the verifier could not be initialized, as
+// opposed to the token itself being invalid
+var ErrVerifierUnavailable = errors.New("token verifier unavailable")
+
+// VerifiedToken is the identity carried by a valid bearer token
+type VerifiedToken struct {
+	UID    string
+	Claims map[string]interface{}
+}
+
+// TokenVerifier validates bearer tokens for AuthMiddleware
+type TokenVerifier interface {
+	VerifyToken(ctx context.Context, token string) (*VerifiedToken, error)
+}
+
+// FirebaseVerifier verifies Firebase ID tokens with the Admin SDK
+type FirebaseVerifier struct{}
+
+// VerifyToken implements TokenVerifier
+func (FirebaseVerifier) VerifyToken(ctx context.Context, token string) (*VerifiedToken, error) {
+	authClient, err := GetAuthClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVerifierUnavailable, err)
+	}
+
+	decoded, err := authClient.VerifyIDToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return &VerifiedToken{UID: decoded.UID, Claims: decoded.Claims}, nil
+}
+
+// JWTVerifier validates locally signed HS256 or RS256 tokens. The subject
+// claim is the user's UID and the role is read from the `role` claim, the
+// same custom claim Firebase tokens carry.
+type JWTVerifier struct {
+	method jwt.SigningMethod
+	key    interface{}
+}
+
+// NewHS256Verifier accepts tokens signed with a shared secret
+func NewHS256Verifier(secret []byte) *JWTVerifier {
+	return &JWTVerifier{method: jwt.SigningMethodHS256, key: secret}
+}
+
+// NewRS256Verifier accepts tokens signed with the private half of key
+func NewRS256Verifier(key *rsa.PublicKey) *JWTVerifier {
+	return &JWTVerifier{method: jwt.SigningMethodRS256, key: key}
+}
+
+// VerifyToken implements TokenVerifier. Tokens must carry an expiry and must
+// not be used before their iat or nbf time.
+func (v *JWTVerifier) VerifyToken(ctx context.Context, token string) (*VerifiedToken, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return v.key, nil
+	}, jwt.WithValidMethods([]string{v.method.Alg()}))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	if !claims.VerifyExpiresAt(now, true) {
+		return nil, errors.New("token has no expiry or is expired")
+	}
+	if !claims.VerifyIssuedAt(now, false) {
+		return nil, errors.New("token is issued in the future")
+	}
+	if !claims.VerifyNotBefore(now, false) {
+		return nil, errors.New("token is not valid yet")
+	}
+
+	uid, _ := claims["sub"].(string)
+	if uid == "" {
+		return nil, errors.New("token has no subject")
+	}
+	return &VerifiedToken{UID: uid, Claims: claims}, nil
+}
+
+// unavailableVerifier rejects every token because the configured verifier
+// could not be built
+type unavailableVerifier struct{ err error }
+
+// VerifyToken implements TokenVerifier
+func (v unavailableVerifier) VerifyToken(ctx context.Context, token string) (*VerifiedToken, error) {
+	return nil, fmt.Errorf("%w: %v", ErrVerifierUnavailable, v.err)
+}
+
+var (
+	tokenVerifier TokenVerifier
+	verifierMu    sync.Mutex
+)
+
+// SetTokenVerifier overrides the verifier used by AuthMiddleware
+func SetTokenVerifier(v TokenVerifier) {
+	verifierMu.Lock()
+	defer verifierMu.Unlock()
+	tokenVerifier = v
+}
+
+// InitTokenVerifier configures the verifier from the environment and fails
+// on an invalid key, so a misconfigured server refuses to start rather than
+// silently verifying a different kind of token.
+func InitTokenVerifier() error {
+	verifierMu.Lock()
+	defer verifierMu.Unlock()
+
+	v, err := verifierFromEnv()
+	if err != nil {
+		return err
+	}
+	tokenVerifier = v
+	return nil
+}
+
+// GetTokenVerifier returns the configured verifier. Unless one has been set,
+// AUTH_JWT_SECRET selects HS256, AUTH_JWT_PUBLIC_KEY (PEM) selects RS256 and
+// otherwise Firebase ID tokens are verified. An invalid key makes every
+// token fail with ErrVerifierUnavailable.
+func GetTokenVerifier() TokenVerifier {
+	verifierMu.Lock()
+	defer verifierMu.Unlock()
+
+	if tokenVerifier == nil {
+		v, err := verifierFromEnv()
+		if err != nil {
+			log.Printf("ERROR: %v", err)
+			v = unavailableVerifier{err}
+		}
+		tokenVerifier = v
+	}
+	return tokenVerifier
+}
+
+// minJWTSecretLength is the shortest accepted HS256 secret, in bytes
+const minJWTSecretLength = 32
+
+func verifierFromEnv() (TokenVerifier, error) {
+	secret := os.Getenv("AUTH_JWT_SECRET")
+	pem := os.Getenv("AUTH_JWT_PUBLIC_KEY")
+
+	switch {
+	case secret != "" && pem != "":
+		return nil, errors.New("set only one of AUTH_JWT_SECRET and AUTH_JWT_PUBLIC_KEY")
+	case secret != "":
+		if len(secret) < minJWTSecretLength {
+			return nil, fmt.Errorf("AUTH_JWT_SECRET must be at least %d bytes", minJWTSecretLength)
+		}
+		log.Println("Using local HS256 token verifier")
+		return NewHS256Verifier([]byte(secret)), nil
+	case pem != "":
+		// Allow the key to be stored on one line with escaped newlines
+		key, err := jwt.ParseRSAPublicKeyFromPEM([]byte(strings.ReplaceAll(pem, `\n`, "\n")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid AUTH_JWT_PUBLIC_KEY: %v", err)
+		}
+		log.Println("Using local RS256 token verifier")
+		return NewRS256Verifier(key), nil
+	}
+
+	return FirebaseVerifier{}, nil
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func signHS256(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestJWTVerifierClaims(t *testing.T) {
+	now := time.Now()
+	hour := time.Hour
+
+	tests := []struct {
+		name   string
+		claims jwt.MapClaims
+		valid  bool
+	}{
+		{"valid", jwt.MapClaims{"sub": "u1", "iat": now.Unix(), "exp": now.Add(hour).Unix()}, true},
+		{"missing exp", jwt.MapClaims{"sub": "u1", "iat": now.Unix()}, false},
+		{"expired", jwt.MapClaims{"sub": "u1", "exp": now.Add(-hour).Unix()}, false},
+		{"issued in the future", jwt.MapClaims{"sub": "u1", "iat": now.Add(hour).Unix(), "exp": now.Add(2 * hour).Unix()}, false},
+		{"not valid yet", jwt.MapClaims{"sub": "u1", "nbf": now.Add(hour).Unix(), "exp": now.Add(2 * hour).Unix()}, false},
+		{"missing subject", jwt.MapClaims{"exp": now.Add(hour).Unix()}, false},
+	}
+
+	verifier := NewHS256Verifier([]byte(testSecret))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifier.VerifyToken(context.Background(), signHS256(t, tt.claims))
+			if (err == nil) != tt.valid {
+				t.Errorf("VerifyToken() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestVerifierFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  string
+		pem     string
+		wantErr string
+	}{
+		{"firebase by default", "", "", ""},
+		{"hs256", testSecret, "", ""},
+		{"short secret", "change-me", "", "at least"},
+		{"invalid public key", "", "not a key", "invalid AUTH_JWT_PUBLIC_KEY"},
+		{"both set", testSecret, "not a key", "only one"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AUTH_JWT_SECRET", tt.secret)
+			t.Setenv("AUTH_JWT_PUBLIC_KEY", tt.pem)
+
+			v, err := verifierFromEnv()
+			if tt.wantErr == "" {
+				if err != nil || v == nil {
+					t.Errorf("verifierFromEnv() = %v, %v", v, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("verifierFromEnv() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInvalidEnvFailsClosed(t *testing.T) {
+	t.Setenv("AUTH_JWT_SECRET", "")
+	t.Setenv("AUTH_JWT_PUBLIC_KEY", "not a key")
+	SetTokenVerifier(nil)
+	t.Cleanup(func() { SetTokenVerifier(nil) })
+
+	if err := InitTokenVerifier(); err == nil {
+		t.Fatal("InitTokenVerifier() accepted an invalid key")
+	}
+
+	_, err := GetTokenVerifier().VerifyToken(context.Background(), "token")
+	if !errors.Is(err, ErrVerifierUnavailable) {
+		t.Errorf("VerifyToken() error = %v, want ErrVerifierUnavailable", err)
+	}
+}